@@ -2,10 +2,10 @@ package cmd
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
 	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/output"
 	"github.com/spf13/cobra"
 )
 
@@ -47,7 +47,9 @@ var attachmentListCmd = &cobra.Command{
 
 Examples:
   jcfa attachment list PROJ-123
-  jcfa attachment list PROJ-123 --json`,
+  jcfa attachment list PROJ-123 --json
+  jcfa attachment list PROJ-123 --output ndjson
+  jcfa attachment list PROJ-123 --output csv --columns id,filename,size,mimeType`,
 	Args: cobra.ExactArgs(1),
 	RunE: runAttachmentList,
 }
@@ -111,6 +113,7 @@ func init() {
 	attachmentsCmd.AddCommand(attachmentDeleteCmd)
 
 	// Add flags
+	// Note: shadows the global --output format flag for this subcommand only
 	attachmentDownloadCmd.Flags().StringVar(&attachmentOutput, "output", "", "Output path (file or directory)")
 	attachmentUploadCmd.Flags().BoolVar(&attachmentNoProgress, "no-progress", false, "Disable progress bar")
 	attachmentDownloadCmd.Flags().BoolVar(&attachmentNoProgress, "no-progress", false, "Disable progress bar")
@@ -138,41 +141,21 @@ func runAttachmentList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list attachments: %w", err)
 	}
 
-	if jsonOutput {
+	if isJSONFormat() {
 		return outputJSON(attachments)
 	}
 
-	// Display attachments
-	if len(attachments) == 0 {
-		fmt.Printf("No attachments found on issue %s\n", issueKey)
-		return nil
-	}
-
-	fmt.Printf("Attachments for %s (%d total):\n\n", issueKey, len(attachments))
-	fmt.Printf("%-10s %-30s %-10s %-20s %s\n", "ID", "Filename", "Size", "Author", "Date")
-	fmt.Println(strings.Repeat("-", 90))
-
-	for _, att := range attachments {
-		filename := att.Filename
-		if len(filename) > 30 {
-			filename = filename[:27] + "..."
+	if currentFormat() == output.FormatTable {
+		// Display attachments
+		if len(attachments) == 0 {
+			fmt.Printf("No attachments found on issue %s\n", issueKey)
+			return nil
 		}
 
-		author := att.Author.DisplayName
-		if len(author) > 20 {
-			author = author[:17] + "..."
-		}
-
-		fmt.Printf("%-10s %-30s %-10s %-20s %s\n",
-			att.ID,
-			filename,
-			jira.FormatFileSize(att.Size),
-			author,
-			jira.FormatDate(att.Created),
-		)
+		fmt.Printf("Attachments for %s (%d total):\n\n", issueKey, len(attachments))
 	}
 
-	return nil
+	return outputList(attachments, attachmentColumns)
 }
 
 func runAttachmentUpload(cmd *cobra.Command, args []string) error {
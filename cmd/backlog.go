@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backlogBoard string
+	backlogLimit int
+)
+
+var backlogCmd = &cobra.Command{
+	Use:   "backlog",
+	Short: "List the backlog of a board",
+	Long: `List the issues in a board's backlog (not in any open sprint), in rank order.
+
+Examples:
+  jcfa backlog --board 7
+  jcfa backlog --board "PROJ board" --limit 100 --json
+  jcfa backlog --board 7 --output tsv --columns key,priority,summary`,
+	Args: cobra.NoArgs,
+	RunE: runBacklog,
+}
+
+func init() {
+	rootCmd.AddCommand(backlogCmd)
+	backlogCmd.Flags().StringVarP(&backlogBoard, "board", "b", "", "board ID or name (required)")
+	backlogCmd.Flags().IntVarP(&backlogLimit, "limit", "l", 50, "maximum number of issues to return")
+}
+
+func runBacklog(cmd *cobra.Command, args []string) error {
+	agileService := jira.NewAgileService(jiraClient)
+	board, err := resolveBoard(agileService, backlogBoard)
+	if err != nil {
+		return err
+	}
+
+	result, err := agileService.GetBacklog(board.ID, backlogLimit)
+	if err != nil {
+		return fmt.Errorf("failed to get backlog: %w", err)
+	}
+
+	if isJSONFormat() {
+		return outputJSON(result)
+	}
+
+	return outputSearchResults(result)
+}
@@ -128,6 +128,7 @@ func runBatchCreate(cmd *cobra.Command, args []string) error {
 
 	// Initialize services
 	templateService := template.NewService(filepath.Join(os.Getenv("HOME"), ".jcfa", "templates"))
+	templateService.Mentions = mentionResolver()
 	issueService := jira.NewIssueService(jiraClient)
 	linkService := jira.NewLinkService(jiraClient)
 
@@ -147,8 +148,7 @@ func runBatchCreate(cmd *cobra.Command, args []string) error {
 	// Dry run: just show what would be created
 	if batchDryRun {
 		if jsonOutput {
-			output, _ := json.MarshalIndent(preparedItems, "", "  ")
-			fmt.Println(string(output))
+			return outputJSON(preparedItems)
 		} else {
 			fmt.Printf("✓ Validation passed. Would create %d issues:\n", len(preparedItems))
 			for i, item := range preparedItems {
@@ -159,6 +159,12 @@ func runBatchCreate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Requests below go through jiraClient, so they share its rate limiter
+	// with any other jcfa processes
+	if verbose && jiraClient.Limiter != nil {
+		fmt.Fprintf(os.Stderr, "Rate limit: %s\n", jiraClient.Limiter)
+	}
+
 	// Separate epics from other issues
 	epics, others := separateEpics(preparedItems)
 
@@ -209,8 +215,9 @@ func runBatchCreate(cmd *cobra.Command, args []string) error {
 
 	// Output results
 	if jsonOutput {
-		output, _ := json.MarshalIndent(result, "", "  ")
-		fmt.Println(string(output))
+		if err := outputJSON(result); err != nil {
+			return err
+		}
 	} else {
 		if bar != nil {
 			fmt.Println() // New line after progress bar
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	boardProject string
+	boardType    string
+)
+
+// boardCmd is the parent command for Jira Software boards
+var boardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "List Jira Software boards",
+	Long: `List Jira Software (Agile) boards.
+
+Sprint and backlog commands take a board by ID or name with --board.
+
+Subcommands:
+  list      - List boards, optionally by project or type`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var boardListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List boards",
+	Long: `List the boards you can see, optionally only those of a project or of a type
+(scrum, kanban or simple).
+
+Examples:
+  jcfa board list
+  jcfa board list --project PROJ
+  jcfa board list --type scrum --json`,
+	Args: cobra.NoArgs,
+	RunE: runBoardList,
+}
+
+func init() {
+	rootCmd.AddCommand(boardCmd)
+	boardCmd.AddCommand(boardListCmd)
+
+	boardListCmd.Flags().StringVarP(&boardProject, "project", "p", "", "only boards of this project")
+	boardListCmd.Flags().StringVar(&boardType, "type", "", "only boards of this type: scrum, kanban or simple")
+}
+
+func runBoardList(cmd *cobra.Command, args []string) error {
+	boards, err := jira.NewAgileService(jiraClient).ListBoards(boardProject, boardType, "")
+	if err != nil {
+		return fmt.Errorf("failed to list boards: %w", err)
+	}
+
+	if isJSONFormat() {
+		return outputJSON(boards)
+	}
+	if len(boards) == 0 && useDetailView() {
+		fmt.Println("No boards found")
+		return nil
+	}
+	return outputList(boards, boardColumns)
+}
+
+// resolveBoard finds the board given with --board, by ID or name
+func resolveBoard(agileService *jira.AgileService, board string) (*models.Board, error) {
+	if board == "" {
+		return nil, fmt.Errorf("a board must be specified using --board (see 'jcfa board list')")
+	}
+	found, err := agileService.FindBoard(board)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find board: %w", err)
+	}
+	return found, nil
+}
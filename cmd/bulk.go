@@ -0,0 +1,868 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/config"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	bulkJQL         string
+	bulkDryRun      bool
+	bulkYes         bool
+	bulkMax         int
+	bulkConcurrency int
+
+	bulkUpdateFields []string
+	bulkUpdateFormat string
+
+	bulkTransitionTo         string
+	bulkTransitionCategory   string
+	bulkTransitionResolution string
+	bulkTransitionComment    string
+)
+
+// BulkResult represents the result of a bulk command, in the shape of BatchResult
+type BulkResult struct {
+	Success int         `json:"success"`
+	Failed  int         `json:"failed"`
+	Skipped int         `json:"skipped"` // Issues that needed no change
+	Updated []BulkIssue `json:"updated"`
+	Errors  []BulkError `json:"errors"`
+}
+
+// BulkIssue represents an issue changed by a bulk command
+type BulkIssue struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+}
+
+// BulkError represents an issue a bulk command failed to change
+type BulkError struct {
+	Key   string `json:"key"`
+	Error string `json:"error"`
+}
+
+// bulkPlan describes what a bulk update would change
+type bulkPlan struct {
+	JQL     string           `json:"jql"`
+	Total   int              `json:"total"`   // Issues matching the query
+	Changes int              `json:"changes"` // Issues that would change
+	Issues  []*bulkPlanIssue `json:"issues"`
+}
+
+// bulkPlanIssue is the planned update of a single issue
+type bulkPlanIssue struct {
+	Key     string        `json:"key"`
+	Summary string        `json:"summary"`
+	Changes []fieldChange `json:"changes,omitempty"`
+	Error   string        `json:"error,omitempty"` // Why the issue can't be updated
+
+	// The part of the update that changes this issue
+	fields     map[string]interface{}
+	operations jira.FieldOperations
+}
+
+// fieldChange is a field's value before and after an update
+type fieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// bulkTransitionPlan describes what a bulk transition would do
+type bulkTransitionPlan struct {
+	JQL         string                 `json:"jql"`
+	Target      string                 `json:"target,omitempty"`
+	Category    string                 `json:"category,omitempty"`
+	Total       int                    `json:"total"`   // Issues matching the query
+	Changes     int                    `json:"changes"` // Issues that would be transitioned
+	Issues      []*bulkTransitionIssue `json:"issues"`
+	Unreachable []unreachableGroup     `json:"unreachable"` // Issues that can't reach the target, by status
+
+	target jira.TransitionTarget
+}
+
+// bulkTransitionIssue is the planned transition of a single issue
+type bulkTransitionIssue struct {
+	Key        string `json:"key"`
+	Summary    string `json:"summary"`
+	Action     string `json:"action"` // transition, skip (already there), unreachable or error
+	From       string `json:"from"`
+	Transition string `json:"transition,omitempty"` // Name of the transition to take
+	To         string `json:"to,omitempty"`
+	Error      string `json:"error,omitempty"`
+
+	transitionID string
+	fields       map[string]interface{}
+}
+
+// unreachableGroup lists issues in a status with no transition to the target
+type unreachableGroup struct {
+	Status    string   `json:"status"`
+	Available []string `json:"available"` // Statuses reachable from it
+	Issues    []string `json:"issues"`
+}
+
+// bulkCmd is the parent command for changes to many issues at once
+var bulkCmd = &cobra.Command{
+	Use:   "bulk",
+	Short: "Change all issues matching a JQL query",
+	Long: `Change all issues matching a JQL query.
+
+Bulk commands print a plan of the changes first. Changing more issues than
+bulk_threshold in the config file (default: 10) requires --yes; use
+--dry-run to review the plan without changing anything.
+
+Subcommands:
+  update        - Update fields on matching issues
+  transition    - Move matching issues to a status`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var bulkUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update fields on all issues matching a JQL query",
+	Long: `Update fields on all issues matching a JQL query.
+
+Fields are given as for 'jcfa update' (name=value, name+=value, name-=value,
+name~=value) and converted and validated using each issue's edit metadata.
+The plan shows each issue's changed fields before and after; issues already
+up to date are skipped. Up to --concurrency issues are updated at a time.
+
+Examples:
+  # Review the plan
+  jcfa bulk update --jql "project = PROJ AND sprint in openSprints()" -f labels+=q3 --dry-run
+  jcfa bulk update --jql "labels = legacy" -f labels-=legacy --dry-run --json
+
+  # Apply it
+  jcfa bulk update --jql "project = PROJ AND priority = Low" -f priority=Medium --yes
+  jcfa bulk update --jql "fixVersion = 1.1 AND status != Done" -f fixVersions+=1.2 -f fixVersions-=1.1 --yes`,
+	Args: cobra.NoArgs,
+	RunE: runBulkUpdate,
+}
+
+var bulkTransitionCmd = &cobra.Command{
+	Use:   "transition",
+	Short: "Move all issues matching a JQL query to a status",
+	Long: `Move all issues matching a JQL query to a status.
+
+The transition is looked up per issue, since issues in different statuses
+(or workflows) reach the target through different transitions. Issues already
+in the target status are skipped; issues with no transition to it are grouped
+by their current status in the plan and reported as failures.
+
+The target is matched like 'jcfa transition': by status or transition name,
+trying status_aliases from the config, or by status category with --category.
+
+--resolution sets the resolution on the transition screen, and --comment
+(Markdown) adds a comment with the transition.
+
+Examples:
+  jcfa bulk transition --jql "sprint = 42 AND status = 'In Review'" --to Done --dry-run
+  jcfa bulk transition --jql "sprint = 42" --to Done --resolution Done --comment "Closed with the sprint" --yes
+  jcfa bulk transition --jql "labels = wontfix" --to Closed --resolution "Won't Do" --json --yes
+  jcfa bulk transition --jql "fixVersion = 1.2" --category done --yes`,
+	Args: cobra.NoArgs,
+	RunE: runBulkTransition,
+}
+
+func init() {
+	rootCmd.AddCommand(bulkCmd)
+	bulkCmd.AddCommand(bulkUpdateCmd)
+	bulkCmd.AddCommand(bulkTransitionCmd)
+
+	bulkCmd.PersistentFlags().StringVar(&bulkJQL, "jql", "", "JQL query selecting the issues to change (required)")
+	bulkCmd.PersistentFlags().BoolVar(&bulkDryRun, "dry-run", false, "print the plan without changing any issues")
+	bulkCmd.PersistentFlags().BoolVarP(&bulkYes, "yes", "y", false, "change more issues than bulk_threshold without refusing")
+	bulkCmd.PersistentFlags().IntVar(&bulkMax, "max", jira.DefaultSearchAllMax, "maximum number of issues to change")
+	bulkCmd.PersistentFlags().IntVar(&bulkConcurrency, "concurrency", 4, "number of issues changed at a time")
+
+	bulkUpdateCmd.Flags().StringArrayVarP(&bulkUpdateFields, "field", "f", []string{}, "field to update in format name=value (can be specified multiple times)")
+	bulkUpdateCmd.Flags().StringVar(&bulkUpdateFormat, "format", jira.TextFormatMarkdown, "format of rich text field values: markdown, plain or adf")
+
+	bulkTransitionCmd.Flags().StringVar(&bulkTransitionTo, "to", "", "target status (required unless --category is given)")
+	bulkTransitionCmd.Flags().StringVar(&bulkTransitionCategory, "category", "", "target status category (todo, in progress, done) instead of --to")
+	bulkTransitionCmd.Flags().StringVar(&bulkTransitionResolution, "resolution", "", "resolution to set with the transition (e.g., Done, \"Won't Do\")")
+	bulkTransitionCmd.Flags().StringVar(&bulkTransitionComment, "comment", "", "comment (Markdown) to add with the transition")
+}
+
+func runBulkUpdate(cmd *cobra.Command, args []string) error {
+	if len(bulkUpdateFields) == 0 {
+		return inputError(nil, "at least one field must be specified using --field")
+	}
+	if _, err := splitFieldUpdates(bulkUpdateFields); err != nil {
+		return err
+	}
+
+	issues, err := searchBulkIssues()
+	if err != nil {
+		return err
+	}
+
+	plan := planBulkUpdate(issues)
+
+	if isJSONFormat() {
+		if bulkDryRun {
+			if err := outputJSON(plan); err != nil {
+				return err
+			}
+		}
+	} else {
+		printBulkPlan(plan)
+	}
+
+	var planned []BulkIssue
+	result := &BulkResult{Errors: make([]BulkError, 0)}
+	for _, item := range plan.Issues {
+		switch {
+		case item.Error != "":
+			result.Errors = append(result.Errors, BulkError{Key: item.Key, Error: item.Error})
+		case len(item.Changes) == 0:
+			result.Skipped++
+		default:
+			planned = append(planned, BulkIssue{Key: item.Key, Summary: item.Summary})
+		}
+	}
+
+	if bulkDryRun {
+		if len(result.Errors) > 0 {
+			return client.NewValidationError(fmt.Sprintf("%d of %d issues can't be updated", len(result.Errors), plan.Total), nil)
+		}
+		return nil
+	}
+
+	if err := confirmBulk("update", len(planned)); err != nil {
+		return err
+	}
+
+	// Updates go through jiraClient, so they share its rate limiter
+	searchService := jira.NewSearchService(jiraClient)
+	items := make(map[string]*bulkPlanIssue, len(plan.Issues))
+	for _, item := range plan.Issues {
+		items[item.Key] = item
+	}
+	runBulk(planned, result, func(issue BulkIssue) error {
+		item := items[issue.Key]
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Updating %s\n", issue.Key)
+		}
+		return searchService.EditIssue(issue.Key, item.fields, item.operations)
+	})
+
+	return finishBulk(result, "update", "updated")
+}
+
+func runBulkTransition(cmd *cobra.Command, args []string) error {
+	if strings.TrimSpace(bulkTransitionTo) == "" && bulkTransitionCategory == "" {
+		return inputError(nil, "a target status must be specified using --to or --category")
+	}
+	target, err := transitionTarget(bulkTransitionTo, bulkTransitionCategory)
+	if err != nil {
+		return err
+	}
+
+	// The comment is the same for every issue; the resolution is checked
+	// against each transition's screen
+	operations := make(jira.FieldOperations)
+	if bulkTransitionComment != "" {
+		body, err := textToADF(bulkTransitionComment, jira.TextFormatMarkdown)
+		if err != nil {
+			return inputError(err, "invalid comment")
+		}
+		operations["comment"] = []map[string]interface{}{{"add": map[string]interface{}{"body": body}}}
+	}
+
+	issues, err := searchBulkIssues("summary", "status")
+	if err != nil {
+		return err
+	}
+
+	searchService := jira.NewSearchService(jiraClient)
+	plan := planBulkTransition(searchService, issues, target, bulkTransitionResolution, operations)
+
+	if isJSONFormat() {
+		if bulkDryRun {
+			if err := outputJSON(plan); err != nil {
+				return err
+			}
+		}
+	} else {
+		printBulkTransitionPlan(plan)
+	}
+
+	var planned []BulkIssue
+	result := &BulkResult{Errors: make([]BulkError, 0)}
+	items := make(map[string]*bulkTransitionIssue, len(plan.Issues))
+	for _, item := range plan.Issues {
+		items[item.Key] = item
+		switch item.Action {
+		case "transition":
+			planned = append(planned, BulkIssue{Key: item.Key, Summary: item.Summary})
+		case "skip":
+			result.Skipped++
+		default:
+			result.Errors = append(result.Errors, BulkError{Key: item.Key, Error: item.Error})
+		}
+	}
+
+	if bulkDryRun {
+		if len(result.Errors) > 0 {
+			return client.NewValidationError(fmt.Sprintf("%d of %d issues can't be transitioned to %s", len(result.Errors), plan.Total, plan.target), nil)
+		}
+		return nil
+	}
+
+	if err := confirmBulk("transition", len(planned)); err != nil {
+		return err
+	}
+
+	runBulk(planned, result, func(issue BulkIssue) error {
+		item := items[issue.Key]
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Transitioning %s: %s -> %s\n", issue.Key, item.From, item.To)
+		}
+		return searchService.PerformTransition(issue.Key, item.transitionID, item.fields, operations)
+	})
+
+	return finishBulk(result, "transition", "transitioned")
+}
+
+// planBulkTransition looks up the transition to the target status for each
+// issue, with up to --concurrency lookups at a time, and checks the
+// resolution and required fields against the transition's screen
+// Parameters:
+//   - searchService: Service used to look up transitions
+//   - issues: The issues to transition
+//   - target: The target status or category
+//   - resolution: The resolution to set ("" = none)
+//   - operations: Update operations applied with each transition
+func planBulkTransition(searchService *jira.SearchService, issues []models.Issue, target jira.TransitionTarget, resolution string, operations jira.FieldOperations) *bulkTransitionPlan {
+	plan := &bulkTransitionPlan{
+		JQL:         bulkJQL,
+		Category:    target.Category,
+		Total:       len(issues),
+		Issues:      make([]*bulkTransitionIssue, len(issues)),
+		Unreachable: make([]unreachableGroup, 0),
+		target:      target,
+	}
+	if len(target.Names) > 0 {
+		plan.Target = target.Names[0]
+	}
+	available := make([][]string, len(issues))
+
+	forEachConcurrently(len(issues), func(i int) {
+		issue := issues[i]
+		item := &bulkTransitionIssue{Key: issue.Key, Summary: issueSummary(issue), From: issueStatus(issue)}
+		plan.Issues[i] = item
+
+		if target.MatchesStatus(issueStatusValue(issue)) {
+			item.Action = "skip"
+			return
+		}
+
+		transitions, err := searchService.GetTransitions(issue.Key)
+		if err != nil {
+			item.Action = "error"
+			item.Error = fmt.Sprintf("failed to get transitions: %v", err)
+			return
+		}
+
+		transition, err := jira.FindTransitionTo(transitions, target)
+		if err != nil {
+			item.Action = "unreachable"
+			item.Error = fmt.Sprintf("no transition to %s from '%s'", target, item.From)
+			for _, t := range transitions {
+				available[i] = append(available[i], t.To.Name)
+			}
+			return
+		}
+
+		fields := make(map[string]interface{})
+		if resolution != "" {
+			fields["resolution"] = map[string]interface{}{"name": resolution}
+			if meta, ok := transition.Fields["resolution"]; ok {
+				value, err := jira.CoerceFieldValue("resolution", meta, resolution, nil)
+				if err != nil {
+					item.Action = "error"
+					item.Error = err.Error()
+					return
+				}
+				fields["resolution"] = value
+			}
+		}
+		if err := jira.ValidateTransitionFields(transition, fields, operations); err != nil {
+			item.Action = "error"
+			item.Error = err.Error()
+			return
+		}
+
+		item.Action = "transition"
+		item.Transition = transition.Name
+		item.To = transition.To.Name
+		item.transitionID = transition.ID
+		item.fields = fields
+	})
+
+	// Group unreachable issues by their current status
+	groups := make(map[string]int)
+	for i, item := range plan.Issues {
+		switch item.Action {
+		case "transition":
+			plan.Changes++
+		case "unreachable":
+			index, ok := groups[item.From]
+			if !ok {
+				index = len(plan.Unreachable)
+				groups[item.From] = index
+				plan.Unreachable = append(plan.Unreachable, unreachableGroup{Status: item.From, Available: append([]string{}, available[i]...)})
+			}
+			plan.Unreachable[index].Issues = append(plan.Unreachable[index].Issues, item.Key)
+		}
+	}
+
+	return plan
+}
+
+// printBulkTransitionPlan prints the transition planned for each issue
+func printBulkTransitionPlan(plan *bulkTransitionPlan) {
+	fmt.Printf("Plan: transition %d of %d issue(s) to %s matching: %s\n", plan.Changes, plan.Total, plan.target, plan.JQL)
+
+	var skipped []string
+	for _, item := range plan.Issues {
+		switch item.Action {
+		case "transition":
+			fmt.Printf("  %s %s\n", item.Key, item.Summary)
+			fmt.Printf("      %s → %s (via '%s')\n", item.From, item.To, item.Transition)
+		case "skip":
+			skipped = append(skipped, item.Key)
+		case "error":
+			fmt.Printf("  ✗ %s %s\n", item.Key, item.Summary)
+			fmt.Printf("      %s\n", item.Error)
+		}
+	}
+
+	if len(skipped) > 0 {
+		fmt.Printf("  Already in %s: %s\n", plan.target, strings.Join(skipped, ", "))
+	}
+	for _, group := range plan.Unreachable {
+		fmt.Printf("  ✗ Can't reach %s from '%s' (available: %s): %s\n", plan.target, group.Status, strings.Join(group.Available, ", "), strings.Join(group.Issues, ", "))
+	}
+	fmt.Println()
+}
+
+// issueStatus returns the status name of an issue from a search result
+func issueStatus(issue models.Issue) string {
+	status, _ := issue.Fields["status"].(map[string]interface{})
+	name, _ := status["name"].(string)
+	return name
+}
+
+// issueStatusValue returns the status of an issue from a search result,
+// with its category
+func issueStatusValue(issue models.Issue) models.Status {
+	status, _ := issue.Fields["status"].(map[string]interface{})
+	category, _ := status["statusCategory"].(map[string]interface{})
+
+	value := models.Status{}
+	value.ID, _ = status["id"].(string)
+	value.Name, _ = status["name"].(string)
+	value.StatusCategory.Key, _ = category["key"].(string)
+	value.StatusCategory.Name, _ = category["name"].(string)
+	return value
+}
+
+// searchBulkIssues returns the issues matching --jql, up to --max
+// Parameters:
+//   - fields: The fields to fetch (none = all)
+func searchBulkIssues(fields ...string) ([]models.Issue, error) {
+	if strings.TrimSpace(bulkJQL) == "" {
+		return nil, inputError(nil, "a JQL query must be specified using --jql")
+	}
+	if bulkConcurrency < 1 {
+		return nil, inputError(nil, "--concurrency must be at least 1")
+	}
+
+	// Fetch one issue past --max to tell whether more match
+	limit := bulkMax
+	if limit > 0 {
+		limit++
+	}
+
+	var issues []models.Issue
+	_, err := jira.NewSearchService(jiraClient).SearchAll(bulkJQL, fields, limit, func(page []models.Issue) error {
+		issues = append(issues, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+	if bulkMax > 0 && len(issues) > bulkMax {
+		issues = issues[:bulkMax]
+		fmt.Fprintf(os.Stderr, "Warning: stopped at --max %d issues; more match\n", bulkMax)
+	}
+	return issues, nil
+}
+
+// planBulkUpdate works out the changes --field makes to each issue. Values
+// are converted and validated once per project and issue type, which share
+// an edit screen.
+func planBulkUpdate(issues []models.Issue) *bulkPlan {
+	type screenUpdate struct {
+		fields     map[string]interface{}
+		operations jira.FieldOperations
+		err        error
+	}
+
+	metadataService := jira.NewMetadataService(jiraClient)
+	screens := make(map[string]*screenUpdate)
+
+	plan := &bulkPlan{JQL: bulkJQL, Total: len(issues), Issues: make([]*bulkPlanIssue, 0, len(issues))}
+	for _, issue := range issues {
+		screen := editScreenKey(issue)
+		update, ok := screens[screen]
+		if !ok {
+			update = &screenUpdate{}
+			update.fields, update.operations, update.err = parseBulkUpdate(metadataService, issue.Key)
+			screens[screen] = update
+		}
+
+		item := &bulkPlanIssue{Key: issue.Key, Summary: issueSummary(issue)}
+		if update.err != nil {
+			item.Error = update.err.Error()
+		} else {
+			item.Changes, item.fields, item.operations = diffFieldUpdates(issue.Fields, update.fields, update.operations)
+			if len(item.Changes) > 0 {
+				plan.Changes++
+			}
+		}
+		plan.Issues = append(plan.Issues, item)
+	}
+
+	return plan
+}
+
+// parseBulkUpdate converts --field for an issue and validates the result
+// against its edit metadata
+func parseBulkUpdate(metadataService *jira.MetadataService, issueKey string) (map[string]interface{}, jira.FieldOperations, error) {
+	editMeta, err := metadataService.GetEditMetadata(issueKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get edit metadata: %w", err)
+	}
+
+	fields, operations, err := parseFieldUpdates(bulkUpdateFields, bulkUpdateFormat, editMeta.Fields)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := metadataService.ValidateEditData(issueKey, fields, operations); err != nil {
+		return nil, nil, err
+	}
+	return fields, operations, nil
+}
+
+// editScreenKey identifies the edit screen of an issue by its project and
+// issue type, falling back to the issue key
+func editScreenKey(issue models.Issue) string {
+	project, _ := issue.Fields["project"].(map[string]interface{})
+	issueType, _ := issue.Fields["issuetype"].(map[string]interface{})
+	projectKey, _ := project["key"].(string)
+	issueTypeID, _ := issueType["id"].(string)
+	if projectKey == "" || issueTypeID == "" {
+		return issue.Key
+	}
+	return projectKey + "/" + issueTypeID
+}
+
+// issueSummary returns the summary of an issue from a search result
+func issueSummary(issue models.Issue) string {
+	summary, _ := issue.Fields["summary"].(string)
+	return summary
+}
+
+// diffFieldUpdates compares an issue's current field values with an update
+// and returns the changes, along with the part of the update that makes them
+// Parameters:
+//   - current: The issue's fields
+//   - fields: New field values by field ID
+//   - operations: Update operations by field ID
+func diffFieldUpdates(current map[string]interface{}, fields map[string]interface{}, operations jira.FieldOperations) ([]fieldChange, map[string]interface{}, jira.FieldOperations) {
+	var changes []fieldChange
+	changedFields := make(map[string]interface{})
+	changedOperations := make(jira.FieldOperations)
+
+	fieldIDs := make([]string, 0, len(fields)+len(operations))
+	for fieldID := range fields {
+		fieldIDs = append(fieldIDs, fieldID)
+	}
+	for fieldID := range operations {
+		fieldIDs = append(fieldIDs, fieldID)
+	}
+	sort.Strings(fieldIDs)
+
+	for _, fieldID := range fieldIDs {
+		// Compare the current value by the key the update uses, e.g. a
+		// priority by ID for -f priority=3
+		key := updateKey(fields[fieldID], operations[fieldID])
+		before := editableValueBy(current[fieldID], key)
+
+		var after interface{}
+		if value, ok := fields[fieldID]; ok {
+			after = editableValue(value)
+		} else {
+			after = applyFieldOperations(before, operations[fieldID])
+		}
+
+		if sameEditableValue(before, after) || (isEmptyValue(before) && isEmptyValue(after)) {
+			continue
+		}
+
+		changes = append(changes, fieldChange{Field: fieldID, Before: before, After: after})
+		if value, ok := fields[fieldID]; ok {
+			changedFields[fieldID] = value
+		} else {
+			changedOperations[fieldID] = operations[fieldID]
+		}
+	}
+
+	return changes, changedFields, changedOperations
+}
+
+// updateKey returns the key an update identifies objects by: that of the
+// new value, or else of the first operand of the operations that has one
+func updateKey(value interface{}, operations []map[string]interface{}) string {
+	if key := editableKey(value); key != "" {
+		return key
+	}
+	for _, operation := range operations {
+		for _, operand := range operation {
+			if key := editableKey(operand); key != "" {
+				return key
+			}
+		}
+	}
+	return ""
+}
+
+// applyFieldOperations returns a field's value (as from editableValue) after
+// update operations
+func applyFieldOperations(value interface{}, operations []map[string]interface{}) interface{} {
+	for _, operation := range operations {
+		for op, operand := range operation {
+			operand = editableValue(operand)
+
+			switch op {
+			case "set", "edit":
+				value = operand
+			case "add":
+				items := listValue(value)
+				if !containsValue(items, operand) {
+					items = append(items, operand)
+				}
+				value = items
+			case "remove":
+				items := make([]interface{}, 0)
+				for _, item := range listValue(value) {
+					if !sameEditableValue(item, operand) {
+						items = append(items, item)
+					}
+				}
+				value = items
+			}
+		}
+	}
+	return value
+}
+
+// listValue returns a copy of a multi-value field's items (none if unset)
+func listValue(value interface{}) []interface{} {
+	items, _ := value.([]interface{})
+	return append(make([]interface{}, 0, len(items)+1), items...)
+}
+
+// containsValue reports whether items contains value
+func containsValue(items []interface{}, value interface{}) bool {
+	for _, item := range items {
+		if sameEditableValue(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmptyValue reports whether a field value is unset: null, "" or []
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// printBulkPlan prints the changes planned for each issue
+func printBulkPlan(plan *bulkPlan) {
+	fmt.Printf("Plan: update %d of %d issue(s) matching: %s\n", plan.Changes, plan.Total, plan.JQL)
+
+	var unchanged []string
+	for _, item := range plan.Issues {
+		switch {
+		case item.Error != "":
+			fmt.Printf("  ✗ %s %s\n", item.Key, item.Summary)
+			fmt.Printf("      %s\n", item.Error)
+		case len(item.Changes) == 0:
+			unchanged = append(unchanged, item.Key)
+		default:
+			fmt.Printf("  %s %s\n", item.Key, item.Summary)
+			for _, change := range item.Changes {
+				fmt.Printf("      %s: %s → %s\n", change.Field, planValue(change.Before), planValue(change.After))
+			}
+		}
+	}
+
+	if len(unchanged) > 0 {
+		fmt.Printf("  Already up to date: %s\n", strings.Join(unchanged, ", "))
+	}
+	fmt.Println()
+}
+
+// planValue formats a field value on one line for a plan
+func planValue(value interface{}) string {
+	if isEmptyValue(value) {
+		if _, ok := value.([]interface{}); ok {
+			return "[]"
+		}
+		return "(empty)"
+	}
+
+	var text string
+	if items, ok := value.([]interface{}); ok {
+		parts := make([]string, 0, len(items))
+		for _, item := range items {
+			parts = append(parts, fmt.Sprint(item))
+		}
+		text = "[" + strings.Join(parts, ", ") + "]"
+	} else {
+		text = fmt.Sprint(value)
+	}
+
+	text = strings.Join(strings.Fields(text), " ")
+	if len(text) > 60 {
+		text = text[:57] + "..."
+	}
+	return text
+}
+
+// confirmBulk refuses to change more issues than bulk_threshold unless --yes
+// was given
+func confirmBulk(action string, count int) error {
+	threshold := config.DefaultBulkThreshold
+	if cfg != nil {
+		threshold = cfg.GetBulkThreshold()
+	}
+	if bulkYes || count <= threshold {
+		return nil
+	}
+
+	err := client.NewValidationError(fmt.Sprintf("refusing to %s %d issues without --yes (bulk_threshold is %d)", action, count, threshold), nil)
+	err.Hints = []string{
+		"Review the plan with --dry-run, then re-run with --yes",
+		"Raise bulk_threshold in ~/.jcfa/config.yaml to change the limit",
+	}
+	return err
+}
+
+// runBulk applies fn to each issue, with at most --concurrency calls at a
+// time, and records the outcomes in result
+func runBulk(issues []BulkIssue, result *BulkResult, fn func(issue BulkIssue) error) {
+	errs := make([]error, len(issues))
+	forEachConcurrently(len(issues), func(i int) {
+		errs[i] = fn(issues[i])
+	})
+
+	if result.Updated == nil {
+		result.Updated = make([]BulkIssue, 0, len(issues))
+	}
+	for i, issue := range issues {
+		if errs[i] != nil {
+			result.Errors = append(result.Errors, BulkError{Key: issue.Key, Error: errs[i].Error()})
+		} else {
+			result.Updated = append(result.Updated, issue)
+		}
+	}
+}
+
+// forEachConcurrently calls fn(0) through fn(n-1) with at most --concurrency
+// calls running at a time, and waits for them to finish
+func forEachConcurrently(n int, fn func(i int)) {
+	sem := make(chan struct{}, bulkConcurrency)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// finishBulk prints the result of a bulk command and exits with an error
+// code if any issue failed
+// Parameters:
+//   - result: The outcome per issue
+//   - action: What was done, as a verb (e.g., "update")
+//   - done: The past tense (e.g., "updated")
+func finishBulk(result *BulkResult, action, done string) error {
+	if result.Updated == nil {
+		result.Updated = make([]BulkIssue, 0)
+	}
+	result.Success = len(result.Updated)
+	result.Failed = len(result.Errors)
+
+	if isJSONFormat() {
+		if err := outputJSON(result); err != nil {
+			return err
+		}
+	} else {
+		if result.Success > 0 {
+			fmt.Printf("✓ Successfully %s %d issue(s):\n", done, result.Success)
+			for _, issue := range result.Updated {
+				fmt.Printf("  %s: %s\n", issue.Key, issue.Summary)
+			}
+			fmt.Println()
+		}
+		if result.Skipped > 0 {
+			fmt.Printf("Skipped %d issue(s) needing no change\n", result.Skipped)
+		}
+		if result.Failed > 0 {
+			fmt.Printf("✗ Failed to %s %d issue(s):\n", action, result.Failed)
+			for _, bulkErr := range result.Errors {
+				fmt.Printf("  %s: %s\n", bulkErr.Key, bulkErr.Error)
+			}
+		}
+	}
+
+	// Exit with error code if there were failures
+	if result.Failed > 0 {
+		os.Exit(2)
+	}
+	return nil
+}
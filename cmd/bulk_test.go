@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/config"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+func TestDiffFieldUpdates(t *testing.T) {
+	current := map[string]interface{}{
+		"summary":    "Fix login",
+		"priority":   map[string]interface{}{"id": "3", "name": "Low"},
+		"labels":     []interface{}{"backend", "triage"},
+		"components": []interface{}{map[string]interface{}{"id": "10", "name": "Backend"}},
+	}
+	fields := map[string]interface{}{
+		"summary":  "Fix login",
+		"priority": map[string]interface{}{"name": "High"},
+		"duedate":  nil,
+	}
+	operations := jira.FieldOperations{
+		"labels":     {{"add": "urgent"}, {"add": "backend"}, {"remove": "triage"}},
+		"components": {{"remove": map[string]interface{}{"name": "API"}}},
+	}
+
+	changes, changedFields, changedOperations := diffFieldUpdates(current, fields, operations)
+
+	got, _ := json.Marshal(changes)
+	expected := `[{"field":"labels","before":["backend","triage"],"after":["backend","urgent"]},{"field":"priority","before":"Low","after":"High"}]`
+	if string(got) != expected {
+		t.Errorf("Expected changes %s, got %s", expected, got)
+	}
+
+	// Unchanged fields aren't sent
+	if len(changedFields) != 1 || changedFields["priority"] == nil {
+		t.Errorf("Expected only priority to be set, got %v", changedFields)
+	}
+	if len(changedOperations) != 1 || len(changedOperations["labels"]) != 3 {
+		t.Errorf("Expected only the labels operations, got %v", changedOperations)
+	}
+}
+
+func TestDiffFieldUpdates_ByID(t *testing.T) {
+	current := map[string]interface{}{
+		"priority":   map[string]interface{}{"id": "3", "name": "High"},
+		"components": []interface{}{map[string]interface{}{"id": "10", "name": "Backend"}},
+	}
+
+	// -f priority=3 coerces to {"id": "3"}, which is the current priority
+	fields := map[string]interface{}{"priority": map[string]interface{}{"id": "3"}}
+	operations := jira.FieldOperations{
+		"components": {{"add": map[string]interface{}{"id": "10"}}},
+	}
+	if changes, _, _ := diffFieldUpdates(current, fields, operations); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changes)
+	}
+
+	fields = map[string]interface{}{"priority": map[string]interface{}{"id": "2"}}
+	operations = jira.FieldOperations{
+		"components": {{"remove": map[string]interface{}{"id": "10"}}},
+	}
+	changes, _, _ := diffFieldUpdates(current, fields, operations)
+	got, _ := json.Marshal(changes)
+	expected := `[{"field":"components","before":["10"],"after":[]},{"field":"priority","before":"3","after":"2"}]`
+	if string(got) != expected {
+		t.Errorf("Expected changes %s, got %s", expected, got)
+	}
+}
+
+func TestPlanValue(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected string
+	}{
+		{nil, "(empty)"},
+		{"", "(empty)"},
+		{[]interface{}{}, "[]"},
+		{[]interface{}{"a", "b"}, "[a, b]"},
+		{8.0, "8"},
+		{"line one\nline two", "line one line two"},
+		{"0123456789012345678901234567890123456789012345678901234567890123456789", "012345678901234567890123456789012345678901234567890123456..."},
+	}
+
+	for _, tt := range tests {
+		if got := planValue(tt.value); got != tt.expected {
+			t.Errorf("planValue(%v) = %q, expected %q", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestConfirmBulk(t *testing.T) {
+	defer func(saved *config.Config, yes bool) { cfg, bulkYes = saved, yes }(cfg, bulkYes)
+	cfg = &config.Config{BulkThreshold: 5}
+	bulkYes = false
+
+	if err := confirmBulk("update", 5); err != nil {
+		t.Errorf("Expected no error at the threshold, got %v", err)
+	}
+
+	var validationErr *client.ValidationError
+	if err := confirmBulk("update", 6); !errors.As(err, &validationErr) || len(validationErr.Hints) == 0 {
+		t.Errorf("Expected a validation error with hints above the threshold, got %v", err)
+	}
+
+	bulkYes = true
+	if err := confirmBulk("update", 6); err != nil {
+		t.Errorf("Expected --yes to allow it, got %v", err)
+	}
+}
+
+func TestPlanBulkTransition(t *testing.T) {
+	// Transitions available from each issue's status
+	transitions := map[string][]models.Transition{
+		"PROJ-1": {{ID: "31", Name: "Resolve", To: models.Status{Name: "Done"}}},
+		"PROJ-3": {{ID: "11", Name: "Start", To: models.Status{Name: "In Progress"}}},
+		"PROJ-4": {{ID: "11", Name: "Start", To: models.Status{Name: "In Progress"}}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.Split(strings.TrimPrefix(r.URL.Path, "/issue/"), "/")[0]
+		if key == "PROJ-2" {
+			t.Errorf("Expected no lookup for an issue already in the target status")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.TransitionsResponse{Transitions: transitions[key]})
+	}))
+	defer server.Close()
+
+	defer func(saved int) { bulkConcurrency = saved }(bulkConcurrency)
+	bulkConcurrency = 2
+
+	issue := func(key, status string) models.Issue {
+		return models.Issue{Key: key, Fields: map[string]interface{}{
+			"summary": "Issue " + key,
+			"status":  map[string]interface{}{"name": status},
+		}}
+	}
+	issues := []models.Issue{
+		issue("PROJ-1", "In Review"),
+		issue("PROJ-2", "Done"),
+		issue("PROJ-3", "Backlog"),
+		issue("PROJ-4", "Backlog"),
+	}
+
+	searchService := jira.NewSearchService(&client.Client{BaseURL: server.URL, HTTPClient: resty.New().SetBaseURL(server.URL)})
+	plan := planBulkTransition(searchService, issues, jira.TransitionTarget{Names: []string{"done"}}, "", nil)
+
+	actions := make([]string, 0, len(plan.Issues))
+	for _, item := range plan.Issues {
+		actions = append(actions, item.Key+":"+item.Action)
+	}
+	if got := strings.Join(actions, " "); got != "PROJ-1:transition PROJ-2:skip PROJ-3:unreachable PROJ-4:unreachable" {
+		t.Errorf("Unexpected actions: %s", got)
+	}
+	if item := plan.Issues[0]; item.transitionID != "31" || item.To != "Done" || item.Transition != "Resolve" {
+		t.Errorf("Expected the Resolve transition for PROJ-1, got %+v", item)
+	}
+	if plan.Changes != 1 {
+		t.Errorf("Expected 1 change, got %d", plan.Changes)
+	}
+
+	got, _ := json.Marshal(plan.Unreachable)
+	expected := `[{"status":"Backlog","available":["In Progress"],"issues":["PROJ-3","PROJ-4"]}]`
+	if string(got) != expected {
+		t.Errorf("Expected unreachable groups %s, got %s", expected, got)
+	}
+}
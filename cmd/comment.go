@@ -13,7 +13,8 @@ import (
 var commentCmd = &cobra.Command{
 	Use:   "comment <issue-key> \"<text>\"",
 	Short: "Add a comment to a Jira issue (legacy command)",
-	Long: `Add a text comment to an existing Jira issue.
+	Long: `Add a comment to an existing Jira issue. The text is Markdown by default
+(see 'jcfa comments add --help').
 
 This is a legacy command maintained for backward compatibility.
 Use 'jcfa comments' for more advanced comment operations.
@@ -21,6 +22,7 @@ Use 'jcfa comments' for more advanced comment operations.
 Examples:
   jcfa comment PROJ-123 "This is a comment"
   jcfa comment PROJ-123 "Updated the implementation" --json
+  jcfa comment PROJ-123 "Plain *text*" --format plain
 
 For more options, see:
   jcfa comments --help`,
@@ -36,11 +38,16 @@ func runComment(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Adding comment to issue %s\n", issueKey)
 	}
 
+	doc, err := textToADF(commentText, commentFormat)
+	if err != nil {
+		return err
+	}
+
 	// Use the new CommentService
 	commentService := jira.NewCommentService(jiraClient)
 
 	// Add the comment
-	comment, err := commentService.AddComment(issueKey, commentText)
+	comment, err := commentService.AddCommentADF(issueKey, doc)
 	if err != nil {
 		return fmt.Errorf("failed to add comment: %w", err)
 	}
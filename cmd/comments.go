@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
 	"github.com/spf13/cobra"
 )
 
@@ -12,6 +13,10 @@ var (
 	commentLimit   int
 	commentOrder   string
 	commentConfirm bool
+	commentFormat  string
+
+	// commentBodyFormat is the rich text format for comments list/get output
+	commentBodyFormat string
 )
 
 // commentsCmd is the new parent command for comment operations
@@ -39,11 +44,18 @@ For backward compatibility, you can still use:
 var commentAddCmd = &cobra.Command{
 	Use:   "add <issue-key> \"<text>\"",
 	Short: "Add a comment to an issue",
-	Long: `Add a text comment to an existing Jira issue.
+	Long: `Add a comment to an existing Jira issue.
+
+The text is Markdown by default: headings, lists, task lists, fenced code,
+blockquotes, tables, links and inline formatting are converted to Jira's rich
+text. Use --format plain to send the text as-is, or --format adf to send a raw
+ADF JSON document.
 
 Examples:
   jcfa comments add PROJ-123 "This is a comment"
-  jcfa comments add PROJ-123 "Updated the implementation" --json`,
+  jcfa comments add PROJ-123 "Updated the implementation" --json
+  jcfa comments add PROJ-123 "$(cat notes.md)"
+  jcfa comments add PROJ-123 "Use *literal* asterisks" --format plain`,
 	Args: cobra.ExactArgs(2),
 	RunE: runCommentAdd,
 }
@@ -57,7 +69,10 @@ var commentListCmd = &cobra.Command{
 Examples:
   jcfa comments list PROJ-123
   jcfa comments list PROJ-123 --limit 10
-  jcfa comments list PROJ-123 --order -created --json`,
+  jcfa comments list PROJ-123 --order -created --json
+  jcfa comments list PROJ-123 --output ndjson
+  jcfa comments list PROJ-123 --output csv --columns id,author,created,body
+  jcfa comments list PROJ-123 --format markdown`,
 	Args: cobra.ExactArgs(1),
 	RunE: runCommentList,
 }
@@ -70,7 +85,9 @@ var commentGetCmd = &cobra.Command{
 
 Examples:
   jcfa comments get PROJ-123 10001
-  jcfa comments get PROJ-123 10001 --json`,
+  jcfa comments get PROJ-123 10001 --json
+  jcfa comments get PROJ-123 10001 --output yaml
+  jcfa comments get PROJ-123 10001 --json --format markdown`,
 	Args: cobra.ExactArgs(2),
 	RunE: runCommentGet,
 }
@@ -81,11 +98,15 @@ var commentUpdateCmd = &cobra.Command{
 	Short: "Update an existing comment",
 	Long: `Update the text of an existing comment.
 
+The text is Markdown by default; use --format plain or --format adf as with
+'jcfa comments add'.
+
 Note: You can only update comments you created or if you have admin permissions.
 
 Examples:
   jcfa comments update PROJ-123 10001 "Updated comment text"
-  jcfa comments update PROJ-123 10001 "Fixed typo" --json`,
+  jcfa comments update PROJ-123 10001 "Fixed typo" --json
+  jcfa comments update PROJ-123 10001 "$(cat status.md)"`,
 	Args: cobra.ExactArgs(3),
 	RunE: runCommentUpdate,
 }
@@ -117,6 +138,12 @@ func init() {
 	// Add flags
 	commentListCmd.Flags().IntVar(&commentLimit, "limit", 0, "Limit number of comments (0 = all)")
 	commentListCmd.Flags().StringVar(&commentOrder, "order", "created", "Sort order (created or -created)")
+	commentListCmd.Flags().StringVar(&commentBodyFormat, "format", "", "Comment body format: plain, markdown or adf (default: plain text, or ADF in JSON)")
+	commentGetCmd.Flags().StringVar(&commentBodyFormat, "format", "", "Comment body format: plain, markdown or adf (default: plain text, or ADF in JSON)")
+
+	commentAddCmd.Flags().StringVar(&commentFormat, "format", jira.TextFormatMarkdown, "text format: markdown, plain or adf")
+	commentUpdateCmd.Flags().StringVar(&commentFormat, "format", jira.TextFormatMarkdown, "text format: markdown, plain or adf")
+	commentCmd.Flags().StringVar(&commentFormat, "format", jira.TextFormatMarkdown, "text format: markdown, plain or adf")
 
 	commentDeleteCmd.Flags().BoolVar(&commentConfirm, "confirm", false, "Confirm deletion")
 	commentDeleteCmd.MarkFlagRequired("confirm")
@@ -137,11 +164,16 @@ func runCommentAdd(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Adding comment to issue %s\n", issueKey)
 	}
 
+	doc, err := textToADF(commentText, commentFormat)
+	if err != nil {
+		return err
+	}
+
 	// Create comment service
 	commentService := jira.NewCommentService(jiraClient)
 
 	// Add the comment
-	comment, err := commentService.AddComment(issueKey, commentText)
+	comment, err := commentService.AddCommentADF(issueKey, doc)
 	if err != nil {
 		return fmt.Errorf("failed to add comment: %w", err)
 	}
@@ -161,6 +193,10 @@ func runCommentAdd(cmd *cobra.Command, args []string) error {
 func runCommentList(cmd *cobra.Command, args []string) error {
 	issueKey := args[0]
 
+	if err := validateBodyFormat(commentBodyFormat); err != nil {
+		return err
+	}
+
 	if verbose {
 		fmt.Printf("Listing comments for issue %s\n", issueKey)
 	}
@@ -168,16 +204,30 @@ func runCommentList(cmd *cobra.Command, args []string) error {
 	// Create comment service
 	commentService := jira.NewCommentService(jiraClient)
 
-	// List comments
-	result, err := commentService.ListComments(issueKey, commentOrder)
-	if err != nil {
-		return fmt.Errorf("failed to list comments: %w", err)
+	// JSON keeps the full response shape; the detail view is for humans
+	if isJSONFormat() || useDetailView() {
+		// List comments
+		result, err := commentService.ListComments(issueKey, commentOrder)
+		if err != nil {
+			return fmt.Errorf("failed to list comments: %w", err)
+		}
+
+		if jsonOutput {
+			for i := range result.Comments {
+				result.Comments[i].Body = convertRichText(result.Comments[i].Body, commentBodyFormat)
+			}
+			return outputJSON(result)
+		}
+
+		return printCommentList(issueKey, result)
 	}
 
-	if jsonOutput {
-		return outputJSON(result)
-	}
+	// Other formats stream comments page by page
+	return streamComments(commentService, issueKey)
+}
 
+// printCommentList prints comments in the human-readable detail view
+func printCommentList(issueKey string, result *models.CommentsResponse) error {
 	// Display comments
 	if len(result.Comments) == 0 {
 		fmt.Printf("No comments found on issue %s\n", issueKey)
@@ -197,8 +247,8 @@ func runCommentList(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Author: %s\n", comment.Author.DisplayName)
 		fmt.Printf("Date: %s\n", jira.FormatDate(comment.Created))
 
-		// Extract plain text from ADF
-		text := jira.ExtractPlainText(comment.Body)
+		// Extract text from ADF (plain by default, see --format)
+		text := richTextString(comment.Body, commentBodyFormat)
 		fmt.Printf("Text: %s\n", strings.TrimSpace(text))
 		fmt.Println()
 	}
@@ -206,10 +256,47 @@ func runCommentList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// streamComments writes comments in the selected format as each page arrives, honouring --limit
+func streamComments(commentService *jira.CommentService, issueKey string) error {
+	formatter, err := newFormatter()
+	if err != nil {
+		return err
+	}
+	stream := formatter.NewStream(commentColumns)
+
+	errLimitReached := fmt.Errorf("limit reached")
+
+	err = commentService.ListCommentsPaged(issueKey, commentOrder, func(comments []models.Comment) error {
+		for _, comment := range comments {
+			if commentLimit > 0 && stream.Count() >= commentLimit {
+				return errLimitReached
+			}
+			comment.Body = convertRichText(comment.Body, commentBodyFormat)
+			if err := stream.Write(comment); err != nil {
+				return err
+			}
+		}
+		return stream.Flush()
+	})
+
+	if closeErr := stream.Close(); closeErr != nil && (err == nil || err == errLimitReached) {
+		return closeErr
+	}
+	if err != nil && err != errLimitReached {
+		return fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	return nil
+}
+
 func runCommentGet(cmd *cobra.Command, args []string) error {
 	issueKey := args[0]
 	commentID := args[1]
 
+	if err := validateBodyFormat(commentBodyFormat); err != nil {
+		return err
+	}
+
 	if verbose {
 		fmt.Printf("Getting comment %s from issue %s\n", commentID, issueKey)
 	}
@@ -223,8 +310,9 @@ func runCommentGet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get comment: %w", err)
 	}
 
-	if jsonOutput {
-		return outputJSON(comment)
+	if !useDetailView() {
+		comment.Body = convertRichText(comment.Body, commentBodyFormat)
+		return outputItem(comment, commentColumns)
 	}
 
 	// Display comment
@@ -237,8 +325,8 @@ func runCommentGet(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Println()
 
-	// Extract plain text from ADF
-	text := jira.ExtractPlainText(comment.Body)
+	// Extract text from ADF (plain by default, see --format)
+	text := richTextString(comment.Body, commentBodyFormat)
 	fmt.Printf("Text:\n%s\n", strings.TrimSpace(text))
 
 	return nil
@@ -253,11 +341,16 @@ func runCommentUpdate(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Updating comment %s on issue %s\n", commentID, issueKey)
 	}
 
+	doc, err := textToADF(newText, commentFormat)
+	if err != nil {
+		return err
+	}
+
 	// Create comment service
 	commentService := jira.NewCommentService(jiraClient)
 
 	// Update comment
-	err := commentService.UpdateComment(issueKey, commentID, newText)
+	err = commentService.UpdateCommentADF(issueKey, commentID, doc)
 	if err != nil {
 		return fmt.Errorf("failed to update comment: %w", err)
 	}
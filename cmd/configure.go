@@ -20,7 +20,14 @@ var configureCmd = &cobra.Command{
 You will need:
 - Your Jira domain (e.g., yourcompany.atlassian.net)
 - Your email address
-- An API token (create one at https://id.atlassian.com/manage/api-tokens)`,
+- An API token (create one at https://id.atlassian.com/manage/api-tokens)
+
+Use --profile to save the settings as a named profile instead of the
+top-level configuration, e.g. to work with a production and a sandbox site.
+
+Examples:
+  jcfa configure
+  jcfa configure --profile sandbox`,
 	RunE: runConfigure,
 }
 
@@ -85,6 +92,15 @@ func runConfigure(cmd *cobra.Command, args []string) error {
 		FieldMappings:  make(map[string]string),
 	}
 
+	// Profiles get their own keyring entry so sites sharing an email don't collide
+	profile := selectedProfile()
+	if profile == config.DefaultProfileName {
+		profile = ""
+	}
+	if profile != "" {
+		cfg.KeyringAccount = fmt.Sprintf("%s:%s", profile, email)
+	}
+
 	// Validate credentials before saving
 	fmt.Println()
 	fmt.Println("Validating credentials...")
@@ -114,7 +130,7 @@ func runConfigure(cmd *cobra.Command, args []string) error {
 		backend := store.GetBackend()
 
 		// Store token in keyring
-		if err := store.Store(email, &secrets.Credentials{APIToken: apiToken}); err != nil {
+		if err := store.Store(cfg.GetKeyringAccount(), &secrets.Credentials{APIToken: apiToken}); err != nil {
 			fmt.Printf("Warning: Failed to store token in keyring: %v\n", err)
 			fmt.Println("Falling back to storing token in config file.")
 			useKeyring = false
@@ -127,13 +143,69 @@ func runConfigure(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Merge into the existing config file so other profiles and mappings are kept
+	configPath := cfgFile
+	if configPath == "" {
+		configPath, err = config.GetConfigPath()
+		if err != nil {
+			return err
+		}
+	}
+
+	fileCfg, err := config.ReadFile(configPath)
+	if err != nil {
+		if _, statErr := os.Stat(configPath); !os.IsNotExist(statErr) {
+			return fmt.Errorf("failed to load existing config: %w", err)
+		}
+		fileCfg = &config.Config{}
+	}
+
+	if profile != "" {
+		if fileCfg.Profiles == nil {
+			fileCfg.Profiles = make(map[string]*config.Profile)
+		}
+		var mappings map[string]string
+		if existing, ok := fileCfg.Profiles[profile]; ok {
+			mappings = existing.FieldMappings
+		}
+		fileCfg.Profiles[profile] = &config.Profile{
+			Domain:         cfg.Domain,
+			Email:          cfg.Email,
+			APIToken:       cfg.APIToken,
+			KeyringAccount: cfg.KeyringAccount,
+			KeyringBackend: cfg.KeyringBackend,
+			UseKeyring:     cfg.UseKeyring,
+			DefaultProject: cfg.DefaultProject,
+			FieldMappings:  mappings,
+		}
+
+		// The first profile of a profiles-only config becomes the current one
+		if fileCfg.CurrentProfile == "" && fileCfg.Domain == "" {
+			fileCfg.CurrentProfile = profile
+		}
+	} else {
+		fileCfg.Domain = cfg.Domain
+		fileCfg.Email = cfg.Email
+		fileCfg.APIToken = cfg.APIToken
+		fileCfg.DefaultProject = cfg.DefaultProject
+		fileCfg.UseKeyring = cfg.UseKeyring
+		fileCfg.KeyringBackend = cfg.KeyringBackend
+		fileCfg.KeyringAccount = ""
+		if fileCfg.FieldMappings == nil {
+			fileCfg.FieldMappings = make(map[string]string)
+		}
+	}
+
 	// Save config
-	if err := cfg.Save(); err != nil {
+	if err := fileCfg.SaveToPath(configPath); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
-	configPath, _ := config.GetConfigPath()
-	fmt.Printf("✓ Configuration saved to: %s\n", configPath)
+	if profile != "" {
+		fmt.Printf("✓ Profile '%s' saved to: %s\n", profile, configPath)
+	} else {
+		fmt.Printf("✓ Configuration saved to: %s\n", configPath)
+	}
 	fmt.Println()
 	fmt.Println("You're all set! Try running 'jcfa --help' to see available commands.")
 
@@ -74,6 +74,7 @@ func runCreate(cmd *cobra.Command, args []string) error {
 
 	// Initialize services
 	templateService := template.NewService(filepath.Join(os.Getenv("HOME"), ".jcfa", "templates"))
+	templateService.Mentions = mentionResolver()
 	issueService := jira.NewIssueService(jiraClient)
 
 	// Load template
@@ -124,8 +125,7 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	// Dry run: just show what would be created
 	if dryRun {
 		if jsonOutput {
-			output, _ := json.MarshalIndent(fields, "", "  ")
-			fmt.Println(string(output))
+			return outputJSON(fields)
 		} else {
 			fmt.Println("✓ Validation passed. Would create issue with fields:")
 			printFields(fields)
@@ -152,8 +152,7 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		if parentIssue != "" {
 			outputData["parent"] = parentIssue
 		}
-		output, _ := json.MarshalIndent(outputData, "", "  ")
-		fmt.Println(string(output))
+		return outputJSON(outputData)
 	} else {
 		if parentIssue != "" {
 			fmt.Printf("✓ Created subtask: %s (under parent %s)\n", result.Key, parentIssue)
@@ -244,6 +243,10 @@ func ensureRequiredFields(fields map[string]interface{}, tmpl *template.Template
 // printFields prints fields in a human-readable format
 func printFields(fields map[string]interface{}) {
 	for key, value := range fields {
+		if jira.IsRichTextField(key) {
+			// Show rich text as text rather than the ADF structure
+			value = jira.ADFToPlainText(value)
+		}
 		fmt.Printf("  %s: %v\n", key, value)
 	}
 }
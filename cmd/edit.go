@@ -0,0 +1,620 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	editFields []string
+	editFrom   string
+	editPrint  bool
+	editForce  bool
+)
+
+var editCmd = &cobra.Command{
+	Use:   "edit <issue-key>",
+	Short: "Edit an issue's summary, description and fields in your editor",
+	Long: `Edit an issue as a Markdown file.
+
+The issue is rendered with YAML frontmatter (key, updated timestamp, summary and
+any fields selected with --field) followed by the description as Markdown, and
+opened in $VISUAL or $EDITOR. When the editor exits, only the fields you changed
+are sent to Jira.
+
+The edit is refused if the issue was updated by someone else since it was
+fetched (the "updated" timestamp in the frontmatter no longer matches); re-run
+the command to start from the latest version, or use --force to overwrite.
+
+Deleting a field from the frontmatter leaves it unchanged; an empty value clears
+it. Users are shown by account ID, and can be changed to an email address,
+display name or "me". Markdown can't express every Jira formatting feature (panels, mentions), so
+those are only lost if you change the description.
+
+For scripts and agents, print the file with --print, edit it, and apply it with
+--from.
+
+Examples:
+  # Edit summary and description in $EDITOR
+  jcfa edit PROJ-123
+
+  # Include more fields in the frontmatter
+  jcfa edit PROJ-123 --field labels --field priority --field story_points
+
+  # Non-interactive: print, edit, apply
+  jcfa edit PROJ-123 --print > PROJ-123.md
+  jcfa edit PROJ-123 --from PROJ-123.md
+  cat PROJ-123.md | jcfa edit PROJ-123 --from -`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEdit,
+}
+
+func init() {
+	rootCmd.AddCommand(editCmd)
+	editCmd.Flags().StringArrayVarP(&editFields, "field", "f", []string{}, "extra field to include in the frontmatter (name or alias, can be specified multiple times)")
+	editCmd.Flags().StringVar(&editFrom, "from", "", "apply an edited file instead of opening an editor ('-' for stdin)")
+	editCmd.Flags().BoolVar(&editPrint, "print", false, "print the editable file and exit")
+	editCmd.Flags().BoolVar(&editForce, "force", false, "save even if the issue changed since it was fetched")
+}
+
+func runEdit(cmd *cobra.Command, args []string) error {
+	issueKey := args[0]
+
+	if editPrint && editFrom != "" {
+		return client.NewValidationError("--print and --from cannot be used together", nil)
+	}
+
+	searchService := jira.NewSearchService(jiraClient)
+
+	var edited []byte
+	if editFrom != "" {
+		data, err := readEditFile(editFrom)
+		if err != nil {
+			return err
+		}
+		edited = data
+	} else {
+		issue, err := searchService.GetIssue(issueKey)
+		if err != nil {
+			return fmt.Errorf("failed to get issue: %w", err)
+		}
+
+		original, err := newIssueDocument(issue, append([]string{"summary"}, editFields...)).render()
+		if err != nil {
+			return err
+		}
+
+		if editPrint {
+			_, err := os.Stdout.Write(original)
+			return err
+		}
+
+		edited, err = editInEditor(issueKey, original)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(edited, original) {
+			return printEditUnchanged(issueKey)
+		}
+	}
+
+	doc, err := parseIssueDocument(edited)
+	if err != nil {
+		return err
+	}
+	if doc.Key != "" && !strings.EqualFold(doc.Key, issueKey) {
+		return client.NewValidationError(fmt.Sprintf("file is for issue %s, not %s", doc.Key, issueKey), nil)
+	}
+	if doc.Updated == "" && !editForce {
+		return client.NewValidationError("file has no 'updated' timestamp in its frontmatter (use --force to save anyway)", nil)
+	}
+
+	// Fetch the issue again right before saving, so concurrent edits aren't overwritten
+	current, err := searchService.GetIssue(issueKey)
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+	currentUpdated, _ := current.Fields["updated"].(string)
+	if !editForce && doc.Updated != currentUpdated {
+		return &client.APIError{
+			StatusCode: 409,
+			Message:    fmt.Sprintf("issue %s was updated at %s, after it was fetched for editing (%s)", issueKey, currentUpdated, doc.Updated),
+			Hints:      []string{fmt.Sprintf("Run 'jcfa edit %s' again to edit the latest version, or use --force to overwrite", issueKey)},
+		}
+	}
+
+	fields, err := diffIssueDocument(current, doc)
+	if err != nil {
+		return err
+	}
+
+	if len(fields) == 0 {
+		return printEditUnchanged(issueKey)
+	}
+
+	changed := make([]string, 0, len(fields))
+	for fieldID := range fields {
+		changed = append(changed, fieldID)
+	}
+	sort.Strings(changed)
+
+	if verbose {
+		fmt.Printf("Updating issue %s fields: %s\n", issueKey, strings.Join(changed, ", "))
+	}
+
+	if err := searchService.UpdateIssue(issueKey, fields); err != nil {
+		return fmt.Errorf("failed to update issue: %w", err)
+	}
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"status":  "success",
+			"message": fmt.Sprintf("Successfully updated issue %s", issueKey),
+			"changed": changed,
+		})
+	}
+
+	fmt.Printf("✓ Successfully updated issue %s (%s)\n", issueKey, strings.Join(changed, ", "))
+	return nil
+}
+
+// readEditFile reads an edited issue file, or stdin for "-"
+func readEditFile(path string) ([]byte, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stdin: %w", err)
+		}
+		return data, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return data, nil
+}
+
+// editInEditor writes content to a temporary file, opens it in the user's
+// editor and returns the saved content
+func editInEditor(issueKey string, content []byte) ([]byte, error) {
+	file, err := os.CreateTemp("", fmt.Sprintf("jcfa-%s-*.md", issueKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	path := file.Name()
+	defer os.Remove(path)
+
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	// The editor setting may include arguments, e.g. "code --wait"
+	editor := strings.Fields(editorCommand())
+	editorCmd := exec.Command(editor[0], append(editor[1:], path)...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return nil, fmt.Errorf("editor %s failed: %w", editor[0], err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read edited file: %w", err)
+	}
+	return data, nil
+}
+
+// editorCommand returns the editor from $VISUAL or $EDITOR, or a platform default
+func editorCommand() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(name)); editor != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// issueDocument is an issue rendered for editing: YAML frontmatter with the
+// key, the updated timestamp and simple field values, followed by the
+// description as Markdown
+type issueDocument struct {
+	Key         string
+	Updated     string
+	Fields      []documentField
+	Description string
+}
+
+// documentField is a frontmatter field, keyed by the name the user selected
+// (a field ID or alias)
+type documentField struct {
+	Name  string
+	Value interface{}
+}
+
+// newIssueDocument renders an issue for editing
+// Parameters:
+//   - issue: The fetched issue
+//   - names: Field names or aliases to include in the frontmatter, in order
+func newIssueDocument(issue *models.Issue, names []string) *issueDocument {
+	doc := &issueDocument{Key: issue.Key}
+	doc.Updated, _ = issue.Fields["updated"].(string)
+
+	seen := make(map[string]bool)
+	for _, name := range names {
+		fieldID := resolveFieldName(name)
+		if seen[fieldID] || fieldID == "description" {
+			continue
+		}
+		seen[fieldID] = true
+		doc.Fields = append(doc.Fields, documentField{Name: name, Value: editableValue(issue.Fields[fieldID])})
+	}
+
+	doc.Description = strings.TrimSpace(jira.ADFToMarkdown(issue.Fields["description"]))
+	return doc
+}
+
+// render encodes the document as Markdown with YAML frontmatter
+func (d *issueDocument) render() ([]byte, error) {
+	frontmatter := &yaml.Node{Kind: yaml.MappingNode}
+	addPair := func(name string, value interface{}) error {
+		var valueNode yaml.Node
+		if err := valueNode.Encode(value); err != nil {
+			return fmt.Errorf("failed to encode field %s: %w", name, err)
+		}
+		frontmatter.Content = append(frontmatter.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: name}, &valueNode)
+		return nil
+	}
+
+	if err := addPair("key", d.Key); err != nil {
+		return nil, err
+	}
+	if err := addPair("updated", d.Updated); err != nil {
+		return nil, err
+	}
+	for _, field := range d.Fields {
+		if err := addPair(field.Name, field.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(frontmatter); err != nil {
+		return nil, fmt.Errorf("failed to encode frontmatter: %w", err)
+	}
+	encoder.Close()
+	buf.WriteString("---\n\n")
+	if d.Description != "" {
+		buf.WriteString(d.Description)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// parseIssueDocument parses an edited issue file
+func parseIssueDocument(data []byte) (*issueDocument, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	text = strings.TrimPrefix(text, "\ufeff")
+
+	if !strings.HasPrefix(text, "---\n") {
+		return nil, client.NewValidationError("file must start with YAML frontmatter ('---')", nil)
+	}
+	rest := text[len("---\n"):]
+
+	var frontmatter, body string
+	if strings.HasPrefix(rest, "---\n") || rest == "---" {
+		body = strings.TrimPrefix(rest, "---")
+	} else {
+		end := strings.Index(rest, "\n---\n")
+		if end < 0 {
+			if !strings.HasSuffix(rest, "\n---") {
+				return nil, client.NewValidationError("frontmatter is not closed with '---'", nil)
+			}
+			end = len(rest) - len("\n---")
+		}
+		frontmatter = rest[:end]
+		body = strings.TrimPrefix(rest[end:], "\n---")
+	}
+
+	doc := &issueDocument{Description: strings.TrimSpace(body)}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(frontmatter), &root); err != nil {
+		return nil, client.NewValidationError(fmt.Sprintf("invalid frontmatter: %v", err), nil)
+	}
+	if len(root.Content) == 0 {
+		return doc, nil
+	}
+
+	mapping := root.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil, client.NewValidationError("frontmatter must be a mapping of field names to values", nil)
+	}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		name := mapping.Content[i].Value
+		valueNode := mapping.Content[i+1]
+
+		switch name {
+		case "key":
+			doc.Key = valueNode.Value
+		case "updated":
+			// Read as text so the timestamp isn't reformatted
+			doc.Updated = valueNode.Value
+		case "description":
+			return nil, client.NewValidationError("edit the description below the frontmatter, not as a field", nil)
+		default:
+			var value interface{}
+			if err := valueNode.Decode(&value); err != nil {
+				return nil, client.NewValidationError(fmt.Sprintf("invalid value for %s: %v", name, err), nil)
+			}
+			doc.Fields = append(doc.Fields, documentField{Name: name, Value: value})
+		}
+	}
+
+	return doc, nil
+}
+
+// diffIssueDocument compares an edited document with the issue and returns
+// the changed fields, converted to the values Jira expects
+func diffIssueDocument(issue *models.Issue, doc *issueDocument) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+
+	for _, field := range doc.Fields {
+		fieldID := resolveFieldName(field.Name)
+		original := issue.Fields[fieldID]
+		if sameEditableValue(editableValue(original), field.Value) {
+			continue
+		}
+
+		value, err := fieldUpdateValue(fieldID, original, field.Value)
+		if err != nil {
+			return nil, inputError(err, "invalid value for %s", field.Name)
+		}
+		fields[fieldID] = value
+	}
+
+	original := strings.TrimSpace(jira.ADFToMarkdown(issue.Fields["description"]))
+	if doc.Description != original {
+		if doc.Description == "" {
+			fields["description"] = nil
+		} else {
+			description, err := textToADF(doc.Description, jira.TextFormatMarkdown)
+			if err != nil {
+				return nil, inputError(err, "invalid description")
+			}
+			fields["description"] = description
+		}
+	}
+
+	return fields, nil
+}
+
+// printEditUnchanged reports that an edit left an issue unchanged
+func printEditUnchanged(issueKey string) error {
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"status":  "unchanged",
+			"message": fmt.Sprintf("No changes to %s", issueKey),
+			"changed": []string{},
+		})
+	}
+	fmt.Printf("No changes to %s\n", issueKey)
+	return nil
+}
+
+// editableValue simplifies a field value for the frontmatter: rich text as
+// Markdown, users by account ID, and other objects by their name or value
+func editableValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		items := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			items = append(items, editableValue(item))
+		}
+		return items
+	case map[string]interface{}:
+		if v["type"] == "doc" {
+			return strings.TrimSpace(jira.ADFToMarkdown(v))
+		}
+		if key := editableKey(v); key != "" {
+			return v[key]
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// editableKey returns the key editableValue simplifies an object by, looking
+// at the first item of a list ("" for other values)
+func editableKey(value interface{}) string {
+	switch v := value.(type) {
+	case []interface{}:
+		if len(v) > 0 {
+			return editableKey(v[0])
+		}
+	case map[string]interface{}:
+		if v["type"] == "doc" {
+			return ""
+		}
+		for _, key := range []string{"accountId", "value", "name", "key", "id"} {
+			if _, ok := v[key]; ok {
+				return key
+			}
+		}
+	}
+	return ""
+}
+
+// editableValueBy simplifies a field value like editableValue, but takes
+// objects that have key by it, so a current value compares like with like
+// against an update (e.g. a priority by ID when the update is {"id": "3"})
+// Parameters:
+//   - value: The field's current value from Jira
+//   - key: The key the update identifies objects by ("" = as editableValue)
+func editableValueBy(value interface{}, key string) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		items := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			items = append(items, editableValueBy(item, key))
+		}
+		return items
+	case map[string]interface{}:
+		if s, ok := v[key]; ok && key != "" {
+			return s
+		}
+	}
+	return editableValue(value)
+}
+
+// sameEditableValue reports whether two frontmatter values are equal,
+// ignoring differences YAML introduces (e.g. 8 vs 8.0)
+func sameEditableValue(a, b interface{}) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return bytes.Equal(aJSON, bJSON)
+}
+
+// fieldUpdateValue converts an edited frontmatter value back to the shape of
+// the field's current value (e.g. a priority name to {"name": ...})
+// Parameters:
+//   - fieldID: The field being updated
+//   - original: The field's current value from Jira (nil if unset)
+//   - edited: The value from the frontmatter
+func fieldUpdateValue(fieldID string, original, edited interface{}) (interface{}, error) {
+	if edited == nil || edited == "" {
+		if _, ok := original.([]interface{}); ok {
+			return []interface{}{}, nil
+		}
+		return nil, nil
+	}
+
+	if doc, ok := original.(map[string]interface{}); (ok && doc["type"] == "doc") || jira.IsRichTextField(fieldID) {
+		text, ok := edited.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected text, got %v", edited)
+		}
+		return textToADF(text, jira.TextFormatMarkdown)
+	}
+
+	kind, err := editUserFieldKind(fieldID, original)
+	if err != nil {
+		return nil, err
+	}
+	if kind != "" {
+		var refs []string
+		if items, ok := edited.([]interface{}); ok {
+			for _, item := range items {
+				refs = append(refs, fmt.Sprint(item))
+			}
+		} else {
+			refs = []string{fmt.Sprint(edited)}
+		}
+		return userFieldValue(fieldID, kind, refs)
+	}
+
+	if items, ok := edited.([]interface{}); ok {
+		// Items take the shape of the existing items, if any
+		var sample interface{}
+		if list, ok := original.([]interface{}); ok && len(list) > 0 {
+			sample = list[0]
+		}
+		values := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			values = append(values, listItemValue(fieldID, sample, item))
+		}
+		return values, nil
+	}
+
+	if object, ok := original.(map[string]interface{}); ok {
+		return objectValue(object, edited), nil
+	}
+
+	if text, ok := edited.(string); ok && original == nil {
+		return parseFieldValue(fieldID, text), nil
+	}
+	return edited, nil
+}
+
+// editUserFieldKind returns the user field kind (see userFieldKind), judged
+// from the current value when there is one, so only unset custom fields need
+// the field list
+func editUserFieldKind(fieldID string, original interface{}) (string, error) {
+	switch v := original.(type) {
+	case map[string]interface{}:
+		if _, ok := v["accountId"]; ok {
+			return "user", nil
+		}
+		return "", nil
+	case []interface{}:
+		if len(v) == 0 {
+			return userFieldKind(fieldID)
+		}
+		if item, ok := v[0].(map[string]interface{}); ok {
+			if _, ok := item["accountId"]; ok {
+				return "array", nil
+			}
+		}
+		return "", nil
+	case nil:
+		return userFieldKind(fieldID)
+	default:
+		return "", nil
+	}
+}
+
+// objectValue wraps an edited value in the attribute that identifies the
+// original object (accountId, value, name, key or id)
+func objectValue(original map[string]interface{}, edited interface{}) interface{} {
+	for _, key := range []string{"accountId", "value", "name", "key", "id"} {
+		if _, ok := original[key]; ok {
+			return map[string]interface{}{key: edited}
+		}
+	}
+	return edited
+}
+
+// listItemValue converts an edited list item to the shape of the existing
+// items; components and versions are referenced by name when the list was empty
+func listItemValue(fieldID string, sample, item interface{}) interface{} {
+	if object, ok := sample.(map[string]interface{}); ok {
+		return objectValue(object, item)
+	}
+	if sample == nil {
+		switch fieldID {
+		case "components", "fixVersions", "versions":
+			return map[string]interface{}{"name": item}
+		}
+	}
+	return item
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+// editTestIssue returns an issue with a mix of field shapes
+func editTestIssue() *models.Issue {
+	var fields map[string]interface{}
+	json.Unmarshal([]byte(`{
+		"summary": "Login fails",
+		"updated": "2024-05-01T10:00:00.000+0000",
+		"duedate": "2024-06-01",
+		"labels": ["auth", "bug"],
+		"priority": {"id": "3", "name": "Medium"},
+		"assignee": {"accountId": "abc123", "displayName": "Jane Doe"},
+		"components": [{"id": "10", "name": "Backend"}],
+		"customfield_10016": 5,
+		"description": {"type": "doc", "version": 1, "content": [
+			{"type": "paragraph", "content": [{"type": "text", "text": "Steps "}, {"type": "text", "text": "here", "marks": [{"type": "strong"}]}]}
+		]}
+	}`), &fields)
+	return &models.Issue{Key: "PROJ-1", Fields: fields}
+}
+
+func TestIssueDocumentRoundTrip(t *testing.T) {
+	issue := editTestIssue()
+	names := []string{"summary", "labels", "priority", "assignee", "duedate", "customfield_10016"}
+
+	data, err := newIssueDocument(issue, names).render()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	text := string(data)
+	for _, want := range []string{
+		"---\nkey: PROJ-1\nupdated: 2024-05-01T10:00:00.000+0000\n",
+		"labels:\n  - auth\n  - bug\n",
+		"summary: Login fails\n",
+		"priority: Medium\n",
+		"assignee: abc123\n",
+		"\n---\n\nSteps **here**\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected rendered document to contain %q, got:\n%s", want, text)
+		}
+	}
+
+	doc, err := parseIssueDocument(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if doc.Key != "PROJ-1" || doc.Updated != "2024-05-01T10:00:00.000+0000" {
+		t.Errorf("Expected key and updated to round trip, got %q and %q", doc.Key, doc.Updated)
+	}
+
+	fields, err := diffIssueDocument(issue, doc)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(fields) != 0 {
+		t.Errorf("Expected no changes for an unedited document, got %v", fields)
+	}
+}
+
+func TestDiffIssueDocument(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		expected string
+	}{
+		{
+			name:     "summary only",
+			document: "---\nkey: PROJ-1\nupdated: x\nsummary: Login fails on Safari\n---\n\nSteps **here**\n",
+			expected: `{"summary":"Login fails on Safari"}`,
+		},
+		{
+			name:     "object fields keep their shape",
+			document: "---\npriority: High\nassignee: 5b10a2844c20165700ede21a\ncomponents: [Backend, Frontend]\n---\nSteps **here**",
+			expected: `{"assignee":{"accountId":"5b10a2844c20165700ede21a"},"components":[{"name":"Backend"},{"name":"Frontend"}],"priority":{"name":"High"}}`,
+		},
+		{
+			name:     "labels and numbers",
+			document: "---\nlabels: [auth]\ncustomfield_10016: 8\n---\nSteps **here**",
+			expected: `{"customfield_10016":8,"labels":["auth"]}`,
+		},
+		{
+			name:     "unchanged number and cleared field",
+			document: "---\ncustomfield_10016: 5.0\nduedate:\n---\nSteps **here**",
+			expected: `{"duedate":null}`,
+		},
+		{
+			name:     "new field without a current value",
+			document: "---\nfixVersions: [\"1.0\"]\n---\nSteps **here**",
+			expected: `{"fixVersions":[{"name":"1.0"}]}`,
+		},
+		{
+			name:     "cleared description",
+			document: "---\nsummary: Login fails\n---\n",
+			expected: `{"description":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parseIssueDocument([]byte(tt.document))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			fields, err := diffIssueDocument(editTestIssue(), doc)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			got, _ := json.Marshal(fields)
+			if string(got) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDiffIssueDocument_Description(t *testing.T) {
+	doc, err := parseIssueDocument([]byte("---\nsummary: Login fails\n---\n\n# Steps\n\n1. Open the page\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	fields, err := diffIssueDocument(editTestIssue(), doc)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got, _ := json.Marshal(fields["description"])
+	expected, _ := json.Marshal(jira.MarkdownToADF("# Steps\n\n1. Open the page"))
+	if string(got) != string(expected) {
+		t.Errorf("Expected description %s, got %s", expected, got)
+	}
+	if len(fields) != 1 {
+		t.Errorf("Expected only the description to change, got %v", fields)
+	}
+}
+
+func TestParseIssueDocument_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+	}{
+		{"no frontmatter", "just text"},
+		{"unclosed frontmatter", "---\nsummary: x\n"},
+		{"invalid yaml", "---\nsummary: [x\n---\n"},
+		{"not a mapping", "---\n- a\n- b\n---\n"},
+		{"description in frontmatter", "---\ndescription: x\n---\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseIssueDocument([]byte(tt.document)); err == nil {
+				t.Errorf("Expected error for %q", tt.document)
+			}
+		})
+	}
+}
+
+func TestParseIssueDocument_EmptyFrontmatter(t *testing.T) {
+	doc, err := parseIssueDocument([]byte("---\r\n---\r\nBody text\r\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if doc.Description != "Body text" || len(doc.Fields) != 0 {
+		t.Errorf("Expected only a description, got %+v", doc)
+	}
+}
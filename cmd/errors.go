@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/output"
+)
+
+// Exit codes returned by the CLI
+const (
+	exitGeneral     = 1 // Unclassified failure
+	exitAuth        = 1 // Authentication failure (HTTP 401)
+	exitValidation  = 2 // Invalid input (HTTP 400 or client-side validation)
+	exitAPI         = 3 // Other API errors (e.g. HTTP 5xx)
+	exitConfig      = 4 // Missing or invalid configuration
+	exitNotFound    = 5 // Issue, comment or other resource not found (HTTP 404)
+	exitPermission  = 6 // Permission denied (HTTP 403)
+	exitRateLimited = 7 // Rate limited by Jira (HTTP 429)
+)
+
+// errorEnvelope is the machine-readable error written to stderr with --json,
+// so agents can react to failures without parsing prose
+type errorEnvelope struct {
+	Code              string                      `json:"code"`
+	ExitCode          int                         `json:"exitCode"`
+	Message           string                      `json:"message"`
+	HTTPStatus        int                         `json:"httpStatus,omitempty"`
+	FieldErrors       map[string]fieldErrorDetail `json:"fieldErrors,omitempty"`
+	Hints             []string                    `json:"hints,omitempty"`
+	Retryable         bool                        `json:"retryable"`
+	RetryAfterSeconds float64                     `json:"retryAfterSeconds,omitempty"`
+}
+
+// fieldErrorDetail describes the problem with a single field
+type fieldErrorDetail struct {
+	Message       string   `json:"message,omitempty"`
+	AllowedValues []string `json:"allowedValues,omitempty"`
+}
+
+// classifyError returns the error code and exit code for err
+func classifyError(err error) (string, int) {
+	var (
+		authErr       *client.AuthError
+		permissionErr *client.PermissionError
+		notFoundErr   *client.NotFoundError
+		validationErr *client.ValidationError
+		rateLimitErr  *client.RateLimitError
+		configErr     *client.ConfigError
+	)
+
+	switch {
+	case errors.As(err, &authErr):
+		return "auth_failed", exitAuth
+	case errors.As(err, &permissionErr):
+		return "permission_denied", exitPermission
+	case errors.As(err, &notFoundErr):
+		return "not_found", exitNotFound
+	case errors.As(err, &validationErr):
+		return "validation_failed", exitValidation
+	case errors.As(err, &rateLimitErr):
+		return "rate_limited", exitRateLimited
+	case errors.As(err, &configErr):
+		return "config_error", exitConfig
+	}
+
+	if _, ok := client.AsAPIError(err); ok {
+		return "api_error", exitAPI
+	}
+
+	// Connection failures, timeouts and DNS errors never reached Jira's API
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return "network_error", exitAPI
+	}
+
+	return "error", exitGeneral
+}
+
+// inputError returns a client-side validation error (exit code 2) for bad
+// command-line input. A cause that already has a classification of its own,
+// such as a user lookup that got a 404, is wrapped instead so it keeps it.
+// Parameters:
+//   - cause: The underlying error (nil = none)
+//   - format: Message format, followed by its args
+func inputError(cause error, format string, args ...interface{}) error {
+	message := fmt.Sprintf(format, args...)
+	if cause == nil {
+		return client.NewValidationError(message, nil)
+	}
+	if code, _ := classifyError(cause); code != "error" {
+		return fmt.Errorf("%s: %w", message, cause)
+	}
+	return client.NewValidationError(fmt.Sprintf("%s: %v", message, cause), nil)
+}
+
+// getExitCode determines the appropriate exit code based on the error type
+func getExitCode(err error) int {
+	_, exitCode := classifyError(err)
+	return exitCode
+}
+
+// newErrorEnvelope builds the machine-readable form of err
+func newErrorEnvelope(err error) errorEnvelope {
+	code, exitCode := classifyError(err)
+	env := errorEnvelope{
+		Code:     code,
+		ExitCode: exitCode,
+		Message:  err.Error(),
+	}
+
+	if apiErr, ok := client.AsAPIError(err); ok {
+		env.HTTPStatus = apiErr.StatusCode
+		env.Hints = append(env.Hints, apiErr.Hints...)
+		for field, msg := range apiErr.FieldErrors {
+			env.addFieldError(field, fieldErrorDetail{Message: msg})
+		}
+	}
+
+	var validationErr *client.ValidationError
+	if errors.As(err, &validationErr) {
+		for field, allowed := range validationErr.AllowedValues {
+			detail := env.FieldErrors[field]
+			detail.AllowedValues = allowed
+			env.addFieldError(field, detail)
+		}
+	}
+
+	var rateLimitErr *client.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		env.RetryAfterSeconds = rateLimitErr.RetryAfter.Seconds()
+	}
+
+	switch code {
+	case "rate_limited", "network_error":
+		env.Retryable = true
+	case "api_error":
+		env.Retryable = env.HTTPStatus >= 500
+	}
+
+	if hint := defaultHint(code, env.RetryAfterSeconds); hint != "" {
+		env.Hints = append(env.Hints, hint)
+	}
+
+	return env
+}
+
+// addFieldError sets the details for a field
+func (e *errorEnvelope) addFieldError(field string, detail fieldErrorDetail) {
+	if e.FieldErrors == nil {
+		e.FieldErrors = make(map[string]fieldErrorDetail)
+	}
+	e.FieldErrors[field] = detail
+}
+
+// defaultHint returns a generic suggestion for an error code
+func defaultHint(code string, retryAfterSeconds float64) string {
+	switch code {
+	case "auth_failed":
+		return "Check your email and API token, or run 'jcfa configure'"
+	case "config_error":
+		return "Run 'jcfa configure', or set JIRA_DOMAIN, JIRA_EMAIL and JIRA_API_TOKEN"
+	case "not_found":
+		return "Check the key or ID, and that your account can access the project"
+	case "permission_denied":
+		return "Your Jira account lacks permission for this operation"
+	case "rate_limited":
+		if retryAfterSeconds > 0 {
+			return fmt.Sprintf("Retry after %.0f seconds", retryAfterSeconds)
+		}
+		return "Wait before retrying"
+	case "network_error":
+		return "Check your network connection and the Jira domain, then retry"
+	}
+	return ""
+}
+
+// argsRequestJSON reports whether the command line asks for machine-readable
+// output (--json or a structured --output format). It looks at the raw
+// arguments because errors can occur before flags are parsed.
+func argsRequestJSON(args []string) bool {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+
+		var format string
+		switch {
+		case arg == "--json" || arg == "--json=true":
+			return true
+		case arg == "--output" || arg == "-o":
+			if i+1 < len(args) {
+				format = args[i+1]
+			}
+		case strings.HasPrefix(arg, "--output="):
+			format = strings.TrimPrefix(arg, "--output=")
+		case strings.HasPrefix(arg, "-o") && !strings.HasPrefix(arg, "--"):
+			format = strings.TrimPrefix(strings.TrimPrefix(arg, "-o"), "=")
+		default:
+			continue
+		}
+
+		if parsed, err := output.ParseFormat(format); err == nil && parsed.IsStructured() {
+			return true
+		}
+	}
+	return false
+}
+
+// writeErrorJSON writes err as a single-line JSON error envelope
+func writeErrorJSON(w io.Writer, err error) {
+	// Encoding strings, numbers and maps of them can't fail
+	_ = json.NewEncoder(w).Encode(newErrorEnvelope(err))
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+// TestGetExitCode tests that exit codes come from the error type, not the message
+func TestGetExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"plain error mentioning config", errors.New("summary 'update config docs' is too long"), exitGeneral},
+		{"not found", client.NewError(http.StatusNotFound, "issue 'PROJ-1' not found"), exitNotFound},
+		{"wrapped not found", fmt.Errorf("failed to get issue: %w", client.NewError(http.StatusNotFound, "issue 'PROJ-1' not found")), exitNotFound},
+		{"auth", client.NewAPIError(http.StatusUnauthorized, nil, nil), exitAuth},
+		{"permission", client.NewAPIError(http.StatusForbidden, nil, nil), exitPermission},
+		{"validation", client.NewAPIError(http.StatusBadRequest, nil, &models.ErrorResponse{Errors: map[string]string{"summary": "required"}}), exitValidation},
+		{"client-side validation", client.NewValidationError("invalid value", nil), exitValidation},
+		{"rate limited", client.NewAPIError(http.StatusTooManyRequests, nil, nil), exitRateLimited},
+		{"server error", client.NewAPIError(http.StatusBadGateway, nil, nil), exitAPI},
+		{"config", &client.ConfigError{Err: errors.New("domain is required")}, exitConfig},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getExitCode(tt.err); got != tt.expected {
+				t.Errorf("Expected exit code %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestGetExitCode_InputErrors tests that bad command-line input exits with
+// the validation code
+func TestGetExitCode_InputErrors(t *testing.T) {
+	defer func(saved string, concurrency int) { bulkJQL, bulkConcurrency = saved, concurrency }(bulkJQL, bulkConcurrency)
+	bulkJQL, bulkConcurrency = "project = PROJ", 0
+
+	_, dateErr := sprintDate("start", "notadate")
+	_, concurrencyErr := searchBulkIssues()
+	_, _, fieldErr := parseFieldUpdates([]string{"summary"}, "", nil)
+	notFound := client.NewError(http.StatusNotFound, "user 'nobody' not found")
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"invalid date", dateErr, exitValidation},
+		{"invalid concurrency", concurrencyErr, exitValidation},
+		{"invalid field format", fieldErr, exitValidation},
+		{"plain cause", inputError(errors.New("unclosed code block"), "invalid comment"), exitValidation},
+		{"classified cause", inputError(notFound, "invalid value for assignee"), exitNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				t.Fatalf("Expected an error")
+			}
+			if got := getExitCode(tt.err); got != tt.expected {
+				t.Errorf("Expected exit code %d, got %d (%v)", tt.expected, got, tt.err)
+			}
+		})
+	}
+}
+
+// TestWriteErrorJSON tests the machine-readable error written with --json
+func TestWriteErrorJSON(t *testing.T) {
+	header := http.Header{}
+	header.Set("Retry-After", "30")
+	err := fmt.Errorf("failed to search issues: %w", client.NewAPIError(http.StatusTooManyRequests, header, nil))
+
+	var buf bytes.Buffer
+	writeErrorJSON(&buf, err)
+
+	var out errorEnvelope
+	if jsonErr := json.Unmarshal(buf.Bytes(), &out); jsonErr != nil {
+		t.Fatalf("Invalid JSON %q: %v", buf.String(), jsonErr)
+	}
+
+	if out.Code != "rate_limited" || out.ExitCode != exitRateLimited {
+		t.Errorf("Expected rate_limited/%d, got %s/%d", exitRateLimited, out.Code, out.ExitCode)
+	}
+	if out.HTTPStatus != http.StatusTooManyRequests {
+		t.Errorf("Expected httpStatus 429, got %d", out.HTTPStatus)
+	}
+	if out.RetryAfterSeconds != 30 {
+		t.Errorf("Expected retryAfterSeconds 30, got %v", out.RetryAfterSeconds)
+	}
+	if out.Message != err.Error() {
+		t.Errorf("Expected message %q, got %q", err.Error(), out.Message)
+	}
+}
+
+// TestErrorEnvelope_AllowedValues tests that allowed values and hints reach the envelope
+func TestErrorEnvelope_AllowedValues(t *testing.T) {
+	err := fmt.Errorf("failed to transition issue: %w",
+		client.NewAllowedValuesError("status", "status 'Closed' not found", []string{"In Progress", "Done"}))
+
+	env := newErrorEnvelope(err)
+
+	if env.Code != "validation_failed" || env.ExitCode != exitValidation {
+		t.Errorf("Expected validation_failed/%d, got %s/%d", exitValidation, env.Code, env.ExitCode)
+	}
+	if env.Retryable {
+		t.Error("Validation errors should not be retryable")
+	}
+
+	detail, ok := env.FieldErrors["status"]
+	if !ok {
+		t.Fatalf("Expected status field error, got %v", env.FieldErrors)
+	}
+	if detail.Message != "status 'Closed' not found" {
+		t.Errorf("Unexpected field message %q", detail.Message)
+	}
+	if len(detail.AllowedValues) != 2 || detail.AllowedValues[1] != "Done" {
+		t.Errorf("Expected allowed values [In Progress Done], got %v", detail.AllowedValues)
+	}
+	if len(env.Hints) == 0 {
+		t.Error("Expected a hint listing the allowed values")
+	}
+}
+
+// TestErrorEnvelope_Retryable tests which errors are marked retryable
+func TestErrorEnvelope_Retryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"server error", client.NewAPIError(http.StatusServiceUnavailable, nil, nil), true},
+		{"rate limited", client.NewAPIError(http.StatusTooManyRequests, nil, nil), true},
+		{"network error", fmt.Errorf("failed to get issue: %w", &net.OpError{Op: "dial", Err: errors.New("connection refused")}), true},
+		{"not found", client.NewError(http.StatusNotFound, "issue 'PROJ-1' not found"), false},
+		{"plain error", errors.New("deletion requires --confirm flag for safety"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newErrorEnvelope(tt.err).Retryable; got != tt.expected {
+				t.Errorf("Expected retryable %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestArgsRequestJSON tests detection of machine-readable output from raw arguments
+func TestArgsRequestJSON(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected bool
+	}{
+		{[]string{"get", "PROJ-1", "--json"}, true},
+		{[]string{"search", "project = X", "-o", "ndjson"}, true},
+		{[]string{"search", "project = X", "--output=yaml"}, true},
+		{[]string{"search", "project = X", "-ocsv"}, true},
+		{[]string{"search", "project = X", "-o", "table"}, false},
+		{[]string{"get", "PROJ-1"}, false},
+		{[]string{"comment", "PROJ-1", "--", "--json"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := argsRequestJSON(tt.args); got != tt.expected {
+			t.Errorf("argsRequestJSON(%v) = %v, expected %v", tt.args, got, tt.expected)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"sort"
@@ -97,7 +96,7 @@ func runFieldsMap(cmd *cobra.Command, args []string) error {
 
 	// Validate alias format (no spaces, alphanumeric + underscore)
 	if !isValidAlias(alias) {
-		return fmt.Errorf("invalid alias '%s': alias must contain only letters, numbers, and underscores", alias)
+		return inputError(nil, "invalid alias '%s': alias must contain only letters, numbers, and underscores", alias)
 	}
 
 	// Create field service
@@ -126,11 +125,9 @@ func runFieldsMap(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// outputFieldsJSON outputs fields in JSON format
+// outputFieldsJSON outputs fields in JSON or another structured format (see --output)
 func outputFieldsJSON(fields []models.Field) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(fields)
+	return outputItem(fields, fieldColumns)
 }
 
 // outputFieldsTable outputs fields in a human-readable table format
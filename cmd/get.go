@@ -3,7 +3,6 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
@@ -17,6 +16,7 @@ var (
 	showSubtasks bool
 	showComments bool
 	showFull     bool
+	getFormat    string
 )
 
 var getCmd = &cobra.Command{
@@ -51,8 +51,16 @@ Examples:
   jcfa get PROJ-123 --full
   jcfa get PROJ-123 -f
 
+  # Description and comments as Markdown
+  jcfa get PROJ-123 --format markdown
+  jcfa get PROJ-123 --json --format markdown
+
   # JSON output
-  jcfa get PROJ-123 --json`,
+  jcfa get PROJ-123 --json
+
+  # Selected fields as YAML, or one row with chosen columns
+  jcfa get PROJ-123 --output yaml
+  jcfa get PROJ-123 --columns key,status,assignee,customfield_10016`,
 	Args: cobra.ExactArgs(1),
 	RunE: runGet,
 }
@@ -65,11 +73,16 @@ func init() {
 	getCmd.Flags().BoolVarP(&showSubtasks, "subtasks", "s", false, "Show subtasks")
 	getCmd.Flags().BoolVarP(&showComments, "comments", "c", false, "Show comments")
 	getCmd.Flags().BoolVarP(&showFull, "full", "f", false, "Show all details (links + subtasks + comments)")
+	getCmd.Flags().StringVar(&getFormat, "format", "", "Rich text format: plain, markdown or adf (default: plain text, or ADF in JSON)")
 }
 
 func runGet(cmd *cobra.Command, args []string) error {
 	issueKey := args[0]
 
+	if err := validateBodyFormat(getFormat); err != nil {
+		return err
+	}
+
 	// If --full flag is set, enable all optional sections
 	if showFull {
 		showLinks = true
@@ -86,22 +99,21 @@ func runGet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get issue: %w", err)
 	}
 
-	// Output based on format
-	if jsonOutput {
-		return outputJSON(issue)
+	// Output based on format; --columns turns the table view into a single row
+	if !useDetailView() {
+		for fieldID, value := range issue.Fields {
+			issue.Fields[fieldID] = convertRichText(value, getFormat)
+		}
+		return outputItem(issue, issueColumns)
 	}
 
 	return outputHumanReadable(issue, issueKey)
 }
 
-// outputJSON outputs the issue in JSON format
-func outputJSON(issue interface{}) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(issue); err != nil {
-		return fmt.Errorf("failed to encode JSON: %w", err)
-	}
-	return nil
+// outputJSON outputs a command result in the selected machine-readable format
+// (JSON by default; see --output)
+func outputJSON(v interface{}) error {
+	return outputItem(v, nil)
 }
 
 // outputHumanReadable outputs the issue in a human-readable format
@@ -260,17 +272,17 @@ func printDescription(fields map[string]interface{}) {
 		return
 	}
 
-	// Use ADF parser to convert to plain text
-	plainText := jira.ADFToPlainText(description)
+	// Use ADF parser to convert to text (plain by default, see --format)
+	text := richTextString(description, getFormat)
 
-	if plainText == "" {
+	if text == "" {
 		return
 	}
 
 	fmt.Println()
 	fmt.Println("Description:")
 	fmt.Println(strings.Repeat("-", 80))
-	fmt.Println(plainText)
+	fmt.Println(text)
 }
 
 // printAttachments prints the list of attachments
@@ -445,7 +457,7 @@ func printComments(issueKey string) error {
 		}
 
 		// Parse comment body (ADF format)
-		bodyText := jira.ADFToPlainText(comment.Body)
+		bodyText := richTextString(comment.Body, getFormat)
 
 		fmt.Printf("[%s] %s:\n", dateStr, authorName)
 		fmt.Println(bodyText)
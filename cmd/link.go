@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/output"
 	"github.com/spf13/cobra"
 )
 
@@ -116,7 +117,8 @@ Shows the link ID (for deletion), direction, link type, and linked issue details
 
 Examples:
   jcfa link list PROJ-123
-  jcfa link list PROJ-123 --json`,
+  jcfa link list PROJ-123 --json
+  jcfa link list PROJ-123 --output ndjson`,
 	Args: cobra.ExactArgs(1),
 	RunE: runLinkList,
 }
@@ -237,30 +239,22 @@ func runLinkTypes(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get link types: %w", err)
 	}
 
-	if jsonOutput {
+	if isJSONFormat() {
 		return outputJSON(linkTypes)
 	}
 
-	// Display as formatted table
-	if len(linkTypes) == 0 {
-		fmt.Println("No link types found.")
-		return nil
-	}
+	if currentFormat() == output.FormatTable {
+		// Display as formatted table
+		if len(linkTypes) == 0 {
+			fmt.Println("No link types found.")
+			return nil
+		}
 
-	fmt.Println("Available Link Types:")
-	fmt.Println(strings.Repeat("─", 80))
-	fmt.Printf("  %-20s %-25s %-25s\n", "Name", "Inward", "Outward")
-	fmt.Printf("  %-20s %-25s %-25s\n", strings.Repeat("─", 18), strings.Repeat("─", 23), strings.Repeat("─", 23))
-
-	for _, lt := range linkTypes {
-		fmt.Printf("  %-20s %-25s %-25s\n",
-			truncateString(lt.Name, 20),
-			truncateString(lt.Inward, 25),
-			truncateString(lt.Outward, 25),
-		)
+		fmt.Println("Available Link Types:")
+		fmt.Println()
 	}
 
-	return nil
+	return outputList(linkTypes, linkTypeColumns)
 }
 
 // runLinkList lists all links on an issue
@@ -280,7 +274,7 @@ func runLinkList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get links: %w", err)
 	}
 
-	if jsonOutput {
+	if isJSONFormat() {
 		return outputJSON(map[string]interface{}{
 			"issueKey": issueKey,
 			"links":    links,
@@ -288,48 +282,18 @@ func runLinkList(cmd *cobra.Command, args []string) error {
 		})
 	}
 
-	// Display as formatted table
-	if len(links) == 0 {
-		fmt.Printf("No links found on issue %s\n", issueKey)
-		return nil
-	}
-
-	fmt.Printf("Links for %s (%d total):\n", issueKey, len(links))
-	fmt.Println(strings.Repeat("─", 95))
-	fmt.Printf("  %-8s %-10s %-18s %-12s %-14s %s\n",
-		"ID", "Direction", "Type", "Issue", "Status", "Summary")
-	fmt.Printf("  %-8s %-10s %-18s %-12s %-14s %s\n",
-		strings.Repeat("─", 6), strings.Repeat("─", 8), strings.Repeat("─", 16),
-		strings.Repeat("─", 10), strings.Repeat("─", 12), strings.Repeat("─", 20))
-
-	for _, link := range links {
-		var direction, relationType, linkedKey, status, summary string
-
-		if link.OutwardIssue != nil {
-			// This issue -> outward issue (outward relationship)
-			direction = "→"
-			relationType = link.Type.Outward
-			linkedKey = link.OutwardIssue.Key
-			status, summary = extractIssueDetails(link.OutwardIssue.Fields)
-		} else if link.InwardIssue != nil {
-			// Inward issue -> this issue (inward relationship)
-			direction = "←"
-			relationType = link.Type.Inward
-			linkedKey = link.InwardIssue.Key
-			status, summary = extractIssueDetails(link.InwardIssue.Fields)
+	if currentFormat() == output.FormatTable {
+		// Display as formatted table
+		if len(links) == 0 {
+			fmt.Printf("No links found on issue %s\n", issueKey)
+			return nil
 		}
 
-		fmt.Printf("  %-8s %-10s %-18s %-12s %-14s %s\n",
-			truncateString(link.ID, 8),
-			direction,
-			truncateString(relationType, 18),
-			truncateString(linkedKey, 12),
-			truncateString(fmt.Sprintf("[%s]", status), 14),
-			truncateString(summary, 30),
-		)
+		fmt.Printf("Links for %s (%d total):\n", issueKey, len(links))
+		fmt.Println()
 	}
 
-	return nil
+	return outputList(links, linkColumns)
 }
 
 // runLinkDelete deletes a link by ID
@@ -363,41 +327,3 @@ func runLinkDelete(cmd *cobra.Command, args []string) error {
 	fmt.Printf("✓ Successfully deleted link %s\n", linkID)
 	return nil
 }
-
-// -----------------------------------------------------------------------------
-// Helper functions
-// -----------------------------------------------------------------------------
-
-// truncateString truncates a string to maxLen runes, adding "..." if truncated.
-// Uses rune count instead of byte length to properly handle Unicode characters.
-func truncateString(s string, maxLen int) string {
-	runes := []rune(s)
-	if len(runes) <= maxLen {
-		return s
-	}
-	if maxLen <= 3 {
-		return string(runes[:maxLen])
-	}
-	return string(runes[:maxLen-3]) + "..."
-}
-
-// extractIssueDetails extracts status and summary from issue fields map
-func extractIssueDetails(fields map[string]interface{}) (status, summary string) {
-	if fields == nil {
-		return "", ""
-	}
-
-	// Extract status
-	if statusMap, ok := fields["status"].(map[string]interface{}); ok {
-		if name, ok := statusMap["name"].(string); ok {
-			status = name
-		}
-	}
-
-	// Extract summary
-	if s, ok := fields["summary"].(string); ok {
-		summary = s
-	}
-
-	return status, summary
-}
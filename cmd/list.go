@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
@@ -12,34 +13,55 @@ var (
 	listProject  string
 	listAssignee string
 	listStatus   string
+	listSprint   string
 	listLimit    int
+	listAll      bool
+	listMax      int
 )
 
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List issues with optional filters",
-	Long: `List Jira issues with optional filtering by project, assignee, and status.
+	Long: `List Jira issues with optional filtering by project, assignee, status and sprint.
 By default, lists recent issues for the current user.
 
+--sprint takes a sprint ID or name, "active" (open sprints), "future",
+"closed" or "none" (issues in no sprint).
+
 Examples:
   jcfa list
   jcfa list --project PROJ
   jcfa list --assignee john@example.com --status "In Progress"
-  jcfa list --limit 10 --json`,
+  jcfa list --assignee "John Smith"
+  jcfa list --project PROJ --assignee none
+  jcfa list --project PROJ --sprint active --assignee me
+  jcfa list --sprint "Sprint 12"
+  jcfa list --limit 10 --json
+  jcfa list --project PROJ --all --max 500
+  jcfa list --project PROJ --all --output ndjson
+  jcfa list --project PROJ --output tsv --columns key,status,assignee`,
 	RunE: runList,
 }
 
 func init() {
 	rootCmd.AddCommand(listCmd)
 	listCmd.Flags().StringVarP(&listProject, "project", "p", "", "filter by project key")
-	listCmd.Flags().StringVarP(&listAssignee, "assignee", "a", "", "filter by assignee email (use 'currentUser()' for yourself)")
+	listCmd.Flags().StringVarP(&listAssignee, "assignee", "a", "", "filter by assignee: email, display name, account ID, 'me' (default) or 'none'")
 	listCmd.Flags().StringVarP(&listStatus, "status", "s", "", "filter by status")
+	listCmd.Flags().StringVar(&listSprint, "sprint", "", "filter by sprint: ID, name, 'active', 'future', 'closed' or 'none'")
 	listCmd.Flags().IntVarP(&listLimit, "limit", "l", 25, "maximum number of results to return")
+	listCmd.Flags().BoolVar(&listAll, "all", false, "fetch all pages of results (ignores --limit, capped by --max)")
+	listCmd.Flags().IntVar(&listMax, "max", jira.DefaultSearchAllMax, "hard cap on the number of issues fetched with --all")
 }
 
 func runList(cmd *cobra.Command, args []string) error {
+	assignee, err := assigneeJQL(listAssignee)
+	if err != nil {
+		return err
+	}
+
 	// Build JQL query from flags
-	jql := buildJQL()
+	jql := buildJQL(assignee)
 
 	if verbose {
 		fmt.Printf("JQL: %s\n", jql)
@@ -48,6 +70,14 @@ func runList(cmd *cobra.Command, args []string) error {
 	// Create search service
 	searchService := jira.NewSearchService(jiraClient)
 
+	// Paginate through every page, streaming output as pages arrive
+	if listAll {
+		if err := streamSearchResults(searchService, jql, nil, listMax); err != nil {
+			return fmt.Errorf("failed to list issues: %w", err)
+		}
+		return nil
+	}
+
 	// Execute search
 	result, err := searchService.Search(jql, listLimit, nil)
 	if err != nil {
@@ -55,15 +85,56 @@ func runList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Use the same output function as search command
-	if jsonOutput {
+	if isJSONFormat() {
 		return outputJSON(result)
 	}
 
 	return outputSearchResults(result)
 }
 
+// assigneeJQL returns the JQL condition for --assignee: the current user by
+// default or for "me", unassigned issues for "none", or the account ID of a
+// user given by email, display name or account ID (Jira Cloud doesn't match
+// emails in JQL)
+func assigneeJQL(assignee string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(assignee)) {
+	case "", "me", "currentuser()":
+		return "assignee = currentUser()", nil
+	case "none", "unassigned":
+		return "assignee is EMPTY", nil
+	}
+
+	user, err := newUserService().ResolveUser("assignee", assignee)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("assignee = \"%s\"", user.AccountID), nil
+}
+
+// sprintJQL returns the JQL condition for --sprint: open, future or closed
+// sprints, issues in no sprint, or a sprint by ID or name
+func sprintJQL(sprint string) string {
+	switch strings.ToLower(strings.TrimSpace(sprint)) {
+	case "active", "open", "current":
+		return "sprint in openSprints()"
+	case "future":
+		return "sprint in futureSprints()"
+	case "closed":
+		return "sprint in closedSprints()"
+	case "none", "backlog":
+		return "sprint is EMPTY"
+	}
+
+	if _, err := strconv.Atoi(sprint); err == nil {
+		return fmt.Sprintf("sprint = %s", sprint)
+	}
+	return fmt.Sprintf("sprint = \"%s\"", strings.ReplaceAll(sprint, `"`, `\"`))
+}
+
 // buildJQL builds a JQL query from the list command flags
-func buildJQL() string {
+// Parameters:
+//   - assignee: The assignee condition (see assigneeJQL)
+func buildJQL(assignee string) string {
 	var conditions []string
 
 	// Project filter
@@ -72,23 +143,18 @@ func buildJQL() string {
 	}
 
 	// Assignee filter
-	if listAssignee != "" {
-		// Check if it's currentUser() or a specific email
-		if listAssignee == "currentUser()" || listAssignee == "me" {
-			conditions = append(conditions, "assignee = currentUser()")
-		} else {
-			conditions = append(conditions, fmt.Sprintf("assignee = \"%s\"", listAssignee))
-		}
-	} else {
-		// Default to current user if no assignee specified
-		conditions = append(conditions, "assignee = currentUser()")
-	}
+	conditions = append(conditions, assignee)
 
 	// Status filter
 	if listStatus != "" {
 		conditions = append(conditions, fmt.Sprintf("status = \"%s\"", listStatus))
 	}
 
+	// Sprint filter
+	if listSprint != "" {
+		conditions = append(conditions, sprintJQL(listSprint))
+	}
+
 	// Combine conditions
 	jql := strings.Join(conditions, " AND ")
 
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestAssigneeJQL_WithoutLookup(t *testing.T) {
+	tests := []struct {
+		assignee string
+		expected string
+	}{
+		{"", "assignee = currentUser()"},
+		{"me", "assignee = currentUser()"},
+		{"currentUser()", "assignee = currentUser()"},
+		{"none", "assignee is EMPTY"},
+		{"Unassigned", "assignee is EMPTY"},
+		{"5b10a2844c20165700ede21a", `assignee = "5b10a2844c20165700ede21a"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.assignee, func(t *testing.T) {
+			got, err := assigneeJQL(tt.assignee)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSprintJQL(t *testing.T) {
+	tests := []struct {
+		sprint   string
+		expected string
+	}{
+		{"active", "sprint in openSprints()"},
+		{"Future", "sprint in futureSprints()"},
+		{"closed", "sprint in closedSprints()"},
+		{"none", "sprint is EMPTY"},
+		{"42", "sprint = 42"},
+		{"Sprint 12", `sprint = "Sprint 12"`},
+		{`The "big" one`, `sprint = "The \"big\" one"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sprint, func(t *testing.T) {
+			if got := sprintJQL(tt.sprint); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+)
+
+// mentionResolver returns the resolver for @mentions in Markdown comments and
+// descriptions; ambiguous mentions fail with --strict-mentions or strict_mentions
+func mentionResolver() jira.MentionResolver {
+	strict := strictMentions || (cfg != nil && cfg.StrictMentions)
+	return newUserService().MentionResolver(strict)
+}
+
+// textToADF converts comment or description text in the given format to ADF,
+// turning @mentions in Markdown into mention nodes (see jira.ToADF)
+func textToADF(text, format string) (map[string]interface{}, error) {
+	return jira.ToADFWithMentions(text, format, mentionResolver())
+}
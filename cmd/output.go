@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/output"
+)
+
+var (
+	// outputColumns selects columns for table, csv and tsv output (--columns)
+	outputColumns []string
+	// outputTemplate is the Go template used with --output template (--template)
+	outputTemplate string
+)
+
+// applyOutputFlag validates --output and reconciles it with the legacy --json flag.
+// Every format other than table enables jsonOutput so commands take their
+// machine-readable code paths (no progress bars, no prose).
+func applyOutputFlag() error {
+	name := strings.ToLower(strings.TrimSpace(outputFormat))
+
+	if name == "" {
+		switch {
+		case jsonOutput:
+			name = string(output.FormatJSON)
+		case outputTemplate != "":
+			// --template on its own implies --output template
+			name = string(output.FormatTemplate)
+		default:
+			name = string(output.FormatTable)
+		}
+	}
+
+	format, err := output.ParseFormat(name)
+	if err != nil {
+		return inputError(nil, "invalid --output '%s': must be one of %s", name, formatNames())
+	}
+
+	if format == output.FormatTemplate && strings.TrimSpace(outputTemplate) == "" {
+		return inputError(nil, "--output template requires --template")
+	}
+
+	outputFormat = string(format)
+	jsonOutput = format.IsStructured()
+
+	return nil
+}
+
+// formatNames returns the accepted --output values for error and help text
+func formatNames() string {
+	names := make([]string, len(output.Formats))
+	for i, f := range output.Formats {
+		names[i] = string(f)
+	}
+	return strings.Join(names, ", ")
+}
+
+// currentFormat returns the resolved --output format
+func currentFormat() output.Format {
+	if outputFormat == "" {
+		return output.FormatTable
+	}
+	return output.Format(outputFormat)
+}
+
+// isJSONFormat reports whether pretty-printed JSON output was requested.
+// Commands keep their full JSON response shape in this mode for compatibility.
+func isJSONFormat() bool {
+	return currentFormat() == output.FormatJSON
+}
+
+// useDetailView reports whether a single-record command should print its
+// human-readable detail view rather than going through the formatter
+func useDetailView() bool {
+	return currentFormat() == output.FormatTable && len(outputColumns) == 0
+}
+
+// newFormatter creates a formatter for stdout from the global output flags
+func newFormatter() (*output.Formatter, error) {
+	return output.New(os.Stdout, currentFormat(), selectedColumns(), outputTemplate)
+}
+
+// selectedColumns parses --columns, resolving field aliases from the config
+// (e.g. story_points -> customfield_10016)
+func selectedColumns() []output.Column {
+	columns := output.ParseColumns(outputColumns)
+	if cfg == nil {
+		return columns
+	}
+
+	for i, col := range columns {
+		if fieldID, ok := cfg.FieldMappings[col.Path]; ok {
+			columns[i].Path = fieldID
+		}
+	}
+	return columns
+}
+
+// outputList renders a list of records in the selected format
+// Parameters:
+//   - items: Slice of records
+//   - defaultColumns: Columns used for tabular output when --columns isn't set
+func outputList(items interface{}, defaultColumns []output.Column) error {
+	formatter, err := newFormatter()
+	if err != nil {
+		return err
+	}
+	return formatter.WriteList(items, defaultColumns)
+}
+
+// outputItem renders a single record in the selected format
+func outputItem(item interface{}, defaultColumns []output.Column) error {
+	formatter, err := newFormatter()
+	if err != nil {
+		return err
+	}
+	return formatter.WriteItem(item, defaultColumns)
+}
+
+// Default columns for tabular output of common record types
+
+// issueColumns are the default columns for issue lists
+var issueColumns = []output.Column{
+	{Header: "KEY", Path: "key"},
+	{Header: "TYPE", Path: "issuetype"},
+	{Header: "STATUS", Path: "status"},
+	{Header: "SUMMARY", Path: "summary"},
+}
+
+// commentColumns are the default columns for comment lists
+var commentColumns = []output.Column{
+	{Header: "ID", Path: "id"},
+	{Header: "AUTHOR", Path: "author"},
+	{Header: "CREATED", Path: "created", Format: formatDateValue},
+	{Header: "TEXT", Path: "body"},
+}
+
+// attachmentColumns are the default columns for attachment lists
+var attachmentColumns = []output.Column{
+	{Header: "ID", Path: "id"},
+	{Header: "FILENAME", Path: "filename"},
+	{Header: "SIZE", Path: "size", Format: formatSizeValue},
+	{Header: "AUTHOR", Path: "author"},
+	{Header: "DATE", Path: "created", Format: formatDateValue},
+}
+
+// linkColumns are the default columns for issue link lists.
+// Direction, type and linked issue depend on which side of the link is set.
+var linkColumns = []output.Column{
+	{Header: "ID", Path: "id"},
+	{Header: "DIRECTION", Value: func(r map[string]interface{}) interface{} {
+		if r["outwardIssue"] != nil {
+			return "→"
+		}
+		return "←"
+	}},
+	{Header: "TYPE", Value: func(r map[string]interface{}) interface{} {
+		if r["outwardIssue"] != nil {
+			return output.Lookup(r, "type.outward")
+		}
+		return output.Lookup(r, "type.inward")
+	}},
+	{Header: "ISSUE", Value: func(r map[string]interface{}) interface{} {
+		return output.Lookup(r, linkedIssuePath(r)+".key")
+	}},
+	{Header: "STATUS", Value: func(r map[string]interface{}) interface{} {
+		return output.Lookup(r, linkedIssuePath(r)+".fields.status")
+	}},
+	{Header: "SUMMARY", Value: func(r map[string]interface{}) interface{} {
+		return output.Lookup(r, linkedIssuePath(r)+".fields.summary")
+	}},
+}
+
+// linkTypeColumns are the default columns for link type lists
+var linkTypeColumns = []output.Column{
+	{Header: "NAME", Path: "name"},
+	{Header: "INWARD", Path: "inward"},
+	{Header: "OUTWARD", Path: "outward"},
+}
+
+// fieldColumns are the default columns for field lists
+var fieldColumns = []output.Column{
+	{Header: "ID", Path: "id"},
+	{Header: "NAME", Path: "name"},
+	{Header: "TYPE", Path: "schema.type"},
+	{Header: "CUSTOM", Path: "custom"},
+}
+
+// userColumns are the default columns for user lists
+var userColumns = []output.Column{
+	{Header: "ACCOUNT ID", Path: "accountId"},
+	{Header: "NAME", Path: "displayName"},
+	{Header: "EMAIL", Path: "emailAddress"},
+	{Header: "ACTIVE", Path: "active"},
+}
+
+// boardColumns are the default columns for board lists
+var boardColumns = []output.Column{
+	{Header: "ID", Path: "id"},
+	{Header: "NAME", Path: "name"},
+	{Header: "TYPE", Path: "type"},
+	{Header: "PROJECT", Path: "location.projectKey"},
+}
+
+// sprintColumns are the default columns for sprint lists
+var sprintColumns = []output.Column{
+	{Header: "ID", Path: "id"},
+	{Header: "NAME", Path: "name"},
+	{Header: "STATE", Path: "state"},
+	{Header: "START", Path: "startDate", Format: formatDateValue},
+	{Header: "END", Path: "endDate", Format: formatDateValue},
+	{Header: "GOAL", Path: "goal"},
+}
+
+// linkedIssuePath returns the record key holding the other side of a link
+func linkedIssuePath(record map[string]interface{}) string {
+	if record["outwardIssue"] != nil {
+		return "outwardIssue"
+	}
+	return "inwardIssue"
+}
+
+// formatDateValue renders an ISO 8601 timestamp cell
+func formatDateValue(v interface{}) string {
+	s, _ := v.(string)
+	return jira.FormatDate(s)
+}
+
+// formatSizeValue renders a byte count cell as a human-readable size
+func formatSizeValue(v interface{}) string {
+	size, ok := v.(float64)
+	if !ok {
+		return output.RenderValue(v)
+	}
+	return jira.FormatFileSize(int64(size))
+}
+
+// Body formats for rich text (descriptions, comment bodies) in command output (--format)
+const (
+	bodyFormatPlain    = "plain"
+	bodyFormatMarkdown = "markdown"
+	bodyFormatADF      = "adf"
+)
+
+// validateBodyFormat checks a --format value for rich text output ("" = default)
+func validateBodyFormat(format string) error {
+	switch format {
+	case "", bodyFormatPlain, bodyFormatMarkdown, bodyFormatADF:
+		return nil
+	}
+	allowed := []string{bodyFormatPlain, bodyFormatMarkdown, bodyFormatADF}
+	msg := fmt.Sprintf("unknown format '%s' (use %s)", format, strings.Join(allowed, ", "))
+	return client.NewAllowedValuesError("format", msg, allowed)
+}
+
+// richTextString renders rich text for the human-readable detail view (default: plain text)
+func richTextString(value interface{}, format string) string {
+	switch format {
+	case bodyFormatMarkdown:
+		return jira.ADFToMarkdown(value)
+	case bodyFormatADF:
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return jira.ADFToPlainText(value)
+		}
+		return string(data)
+	default:
+		return jira.ADFToPlainText(value)
+	}
+}
+
+// convertRichText replaces an ADF document with Markdown or plain text for
+// structured output. Other values, and ADF with the default format, are unchanged.
+func convertRichText(value interface{}, format string) interface{} {
+	doc, ok := value.(map[string]interface{})
+	if !ok || doc["type"] != "doc" {
+		return value
+	}
+
+	switch format {
+	case bodyFormatMarkdown:
+		return jira.ADFToMarkdown(doc)
+	case bodyFormatPlain:
+		return jira.ADFToPlainText(doc)
+	default:
+		return value
+	}
+}
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/config"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/output"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/secrets"
+	"github.com/spf13/cobra"
+)
+
+var profileConfirm bool
+
+// profileCmd is the parent command for profile operations
+var profileCmd = &cobra.Command{
+	Use:   "profile <subcommand>",
+	Short: "Manage named Jira profiles",
+	Long: `Manage named profiles for working with several Jira sites.
+
+Each profile in config.yaml has its own domain, email, keyring account,
+default project and field mappings. The profile is selected with --profile,
+then the JCFA_PROFILE environment variable, then current_profile in the config.
+The top-level settings are available as the "default" profile.
+
+Subcommands:
+  list    - List configured profiles
+  use     - Set the current profile
+  delete  - Delete a profile
+
+Create or update a profile with:
+  jcfa configure --profile sandbox`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// profileListCmd lists configured profiles
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured profiles",
+	Long: `List all profiles in the config file. The active profile is marked with *.
+
+Examples:
+  jcfa profile list
+  jcfa profile list --json`,
+	Args: cobra.NoArgs,
+	RunE: runProfileList,
+}
+
+// profileUseCmd sets the current profile
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the current profile",
+	Long: `Set the profile used when neither --profile nor JCFA_PROFILE is given.
+
+Examples:
+  jcfa profile use sandbox
+  jcfa profile use default`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileUse,
+}
+
+// profileDeleteCmd deletes a profile
+var profileDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a profile",
+	Long: `Delete a profile from the config file and remove its API token from the keyring.
+Requires --confirm flag for safety.
+
+Examples:
+  jcfa profile delete sandbox --confirm`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileDelete,
+}
+
+// profileInfo describes a profile in list output
+type profileInfo struct {
+	Name           string `json:"name"`
+	Active         bool   `json:"active"`
+	Domain         string `json:"domain"`
+	Email          string `json:"email"`
+	DefaultProject string `json:"defaultProject,omitempty"`
+	KeyringAccount string `json:"keyringAccount,omitempty"`
+}
+
+// profileColumns are the default columns for profile lists
+var profileColumns = []output.Column{
+	{Header: "", Value: func(r map[string]interface{}) interface{} {
+		if active, _ := r["active"].(bool); active {
+			return "*"
+		}
+		return ""
+	}},
+	{Header: "NAME", Path: "name"},
+	{Header: "DOMAIN", Path: "domain"},
+	{Header: "EMAIL", Path: "email"},
+	{Header: "PROJECT", Path: "defaultProject"},
+}
+
+func init() {
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileDeleteCmd)
+
+	profileDeleteCmd.Flags().BoolVar(&profileConfirm, "confirm", false, "Confirm deletion")
+
+	rootCmd.AddCommand(profileCmd)
+}
+
+func runProfileList(cmd *cobra.Command, args []string) error {
+	fileCfg, _, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	active := selectedProfile()
+	if active == "" {
+		active = fileCfg.CurrentProfile
+	}
+	if active == "" {
+		active = config.DefaultProfileName
+	}
+
+	var profiles []profileInfo
+	if fileCfg.Domain != "" {
+		profiles = append(profiles, profileInfo{
+			Name:           config.DefaultProfileName,
+			Active:         active == config.DefaultProfileName,
+			Domain:         fileCfg.Domain,
+			Email:          fileCfg.Email,
+			DefaultProject: fileCfg.DefaultProject,
+			KeyringAccount: fileCfg.KeyringAccount,
+		})
+	}
+	for _, name := range fileCfg.ProfileNames() {
+		p := fileCfg.Profiles[name]
+		profiles = append(profiles, profileInfo{
+			Name:           name,
+			Active:         active == name,
+			Domain:         p.Domain,
+			Email:          p.Email,
+			DefaultProject: p.DefaultProject,
+			KeyringAccount: p.KeyringAccount,
+		})
+	}
+
+	if !jsonOutput && len(profiles) == 0 {
+		fmt.Println("No profiles configured. Run 'jcfa configure --profile NAME' to add one.")
+		return nil
+	}
+
+	return outputItem(profiles, profileColumns)
+}
+
+func runProfileUse(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	fileCfg, configPath, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	if name == config.DefaultProfileName {
+		if fileCfg.Domain == "" {
+			return fmt.Errorf("no top-level settings to use as the default profile")
+		}
+		fileCfg.CurrentProfile = ""
+	} else {
+		if _, ok := fileCfg.Profiles[name]; !ok {
+			return fmt.Errorf("profile '%s' not found", name)
+		}
+		fileCfg.CurrentProfile = name
+	}
+
+	if err := fileCfg.SaveToPath(configPath); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if jsonOutput {
+		return outputJSON(map[string]string{
+			"status":  "success",
+			"profile": name,
+		})
+	}
+
+	fmt.Printf("✓ Now using profile '%s'\n", name)
+	if env := os.Getenv(config.ProfileEnvVar); env != "" && env != name {
+		fmt.Printf("Note: %s=%s overrides the current profile in this shell\n", config.ProfileEnvVar, env)
+	}
+
+	return nil
+}
+
+func runProfileDelete(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	if !profileConfirm {
+		return fmt.Errorf("deletion requires --confirm flag for safety")
+	}
+
+	if name == config.DefaultProfileName {
+		return fmt.Errorf("the default profile is the top-level configuration and can't be deleted")
+	}
+
+	fileCfg, configPath, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	profile, ok := fileCfg.Profiles[name]
+	if !ok {
+		return fmt.Errorf("profile '%s' not found", name)
+	}
+
+	delete(fileCfg.Profiles, name)
+	if fileCfg.CurrentProfile == name {
+		fileCfg.CurrentProfile = ""
+	}
+
+	if err := fileCfg.SaveToPath(configPath); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	// Remove the profile's own keyring entry; non-fatal since the profile itself is gone.
+	// Profiles without a keyring_account share the email entry, so it's left alone.
+	if profile.UseKeyring && profile.KeyringAccount != "" {
+		backend := secrets.Backend(profile.KeyringBackend)
+		if backend == "" {
+			backend = secrets.BackendAuto
+		}
+		if err := secrets.NewStore(backend).Delete(profile.KeyringAccount); err != nil && verbose {
+			fmt.Printf("Warning: failed to remove API token from keyring: %v\n", err)
+		}
+	}
+
+	if jsonOutput {
+		return outputJSON(map[string]string{
+			"status":  "success",
+			"message": fmt.Sprintf("Profile '%s' deleted", name),
+		})
+	}
+
+	fmt.Printf("✓ Deleted profile '%s'\n", name)
+
+	return nil
+}
+
+// loadConfigFile reads the config file (--config or the default path) without
+// applying a profile
+func loadConfigFile() (*config.Config, string, error) {
+	configPath := cfgFile
+	if configPath == "" {
+		var err error
+		configPath, err = config.GetConfigPath()
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	fileCfg, err := config.ReadFile(configPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load config: %w", err)
+	}
+
+	return fileCfg, configPath, nil
+}
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rankBefore      string
+	rankAfter       string
+	rankTopOfSprint bool
+)
+
+// RankResult represents the result of ranking issues
+type RankResult struct {
+	Success  int         `json:"success"`
+	Failed   int         `json:"failed"`
+	Position string      `json:"position"`
+	Ranked   []string    `json:"ranked"`
+	Errors   []BulkError `json:"errors"`
+}
+
+var rankCmd = &cobra.Command{
+	Use:   "rank <issue-key>...",
+	Short: "Rank issues before or after another issue",
+	Long: `Change the rank (board and backlog order) of issues, using the Jira Software
+rank. The issues are moved, in the order given, directly before or after
+another issue, or to the top of the sprint the first of them is in.
+
+Exactly one of --before, --after or --top-of-sprint is required. Jira can
+rank some issues and not others; failures are reported per issue and exit
+with code 2.
+
+Examples:
+  jcfa rank PROJ-5 --before PROJ-2
+  jcfa rank PROJ-5 PROJ-6 PROJ-7 --after PROJ-2
+  jcfa rank PROJ-5 PROJ-6 --top-of-sprint --json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runRank,
+}
+
+func init() {
+	rootCmd.AddCommand(rankCmd)
+
+	rankCmd.Flags().StringVar(&rankBefore, "before", "", "rank the issues directly before this issue")
+	rankCmd.Flags().StringVar(&rankAfter, "after", "", "rank the issues directly after this issue")
+	rankCmd.Flags().BoolVar(&rankTopOfSprint, "top-of-sprint", false, "rank the issues at the top of the first issue's sprint")
+}
+
+func runRank(cmd *cobra.Command, args []string) error {
+	issueKeys := make([]string, len(args))
+	for i, key := range args {
+		issueKeys[i] = strings.ToUpper(key)
+	}
+	before, after := strings.ToUpper(rankBefore), strings.ToUpper(rankAfter)
+
+	if err := validateRankArgs(issueKeys, before, after, rankTopOfSprint); err != nil {
+		return err
+	}
+
+	result := &RankResult{
+		Ranked: make([]string, 0, len(issueKeys)),
+		Errors: make([]BulkError, 0),
+	}
+
+	agileService := jira.NewAgileService(jiraClient)
+	toRank := issueKeys
+	switch {
+	case before != "":
+		result.Position = "before " + before
+	case after != "":
+		result.Position = "after " + after
+	default:
+		sprint, err := agileService.GetIssueSprint(issueKeys[0])
+		if err != nil {
+			return fmt.Errorf("failed to find sprint: %w", err)
+		}
+		result.Position = fmt.Sprintf("at the top of sprint '%s'", sprint.Name)
+
+		// The issues go before the first sprint issue that isn't being ranked,
+		// which is among the first len(issueKeys)+1
+		top, err := agileService.GetSprintIssues(sprint.ID, len(issueKeys)+1)
+		if err != nil {
+			return fmt.Errorf("failed to get sprint issues: %w", err)
+		}
+		before = firstOtherIssue(top.Issues, issueKeys)
+		if before == "" {
+			// The sprint holds only these issues: the first stays at the top
+			// and the rest follow it
+			result.Ranked = append(result.Ranked, issueKeys[0])
+			after, toRank = issueKeys[0], issueKeys[1:]
+		}
+	}
+
+	if len(toRank) > 0 {
+		entries, err := agileService.RankIssues(toRank, before, after)
+		collectRankResult(result, toRank, entries, err)
+	}
+	result.Success = len(result.Ranked)
+	result.Failed = len(result.Errors)
+
+	if isJSONFormat() {
+		if err := outputJSON(result); err != nil {
+			return err
+		}
+	} else {
+		printRankResult(result)
+	}
+
+	if result.Failed > 0 {
+		os.Exit(2)
+	}
+	return nil
+}
+
+// validateRankArgs checks that exactly one position is given and that no
+// issue is ranked relative to itself
+// Parameters:
+//   - issueKeys: The issues to rank
+//   - before: --before issue ("" = unset)
+//   - after: --after issue ("" = unset)
+//   - topOfSprint: --top-of-sprint
+func validateRankArgs(issueKeys []string, before, after string, topOfSprint bool) error {
+	positions := 0
+	for _, set := range []bool{before != "", after != "", topOfSprint} {
+		if set {
+			positions++
+		}
+	}
+	if positions != 1 {
+		msg := "exactly one of --before, --after or --top-of-sprint is required"
+		return client.NewValidationError(msg, map[string]string{"position": msg})
+	}
+
+	seen := make(map[string]bool, len(issueKeys))
+	for _, key := range issueKeys {
+		if key == before || key == after {
+			msg := fmt.Sprintf("%s can't be ranked relative to itself", key)
+			return client.NewValidationError(msg, map[string]string{"position": msg})
+		}
+		if seen[key] {
+			msg := fmt.Sprintf("%s is given more than once", key)
+			return client.NewValidationError(msg, map[string]string{"issues": msg})
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// firstOtherIssue returns the key of the first issue not among issueKeys
+// ("" if there is none)
+func firstOtherIssue(issues []models.Issue, issueKeys []string) string {
+	for _, issue := range issues {
+		other := true
+		for _, key := range issueKeys {
+			if issue.Key == key {
+				other = false
+				break
+			}
+		}
+		if other {
+			return issue.Key
+		}
+	}
+	return ""
+}
+
+// collectRankResult records the outcome of ranking each issue. Issues without
+// an outcome weren't ranked because a request failed with err.
+// Parameters:
+//   - result: The result to add to
+//   - issueKeys: The issues that were ranked
+//   - entries: Outcomes per issue, from RankIssues
+//   - err: The error RankIssues returned, if any
+func collectRankResult(result *RankResult, issueKeys []string, entries []models.RankEntry, err error) {
+	reported := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		reported[entry.IssueKey] = true
+		if entry.Status >= 200 && entry.Status < 300 {
+			result.Ranked = append(result.Ranked, entry.IssueKey)
+			continue
+		}
+		message := strings.Join(entry.Errors, "; ")
+		if message == "" {
+			message = fmt.Sprintf("rank failed with status %d", entry.Status)
+		}
+		result.Errors = append(result.Errors, BulkError{Key: entry.IssueKey, Error: message})
+	}
+
+	if err == nil {
+		return
+	}
+	for _, key := range issueKeys {
+		if !reported[key] {
+			result.Errors = append(result.Errors, BulkError{Key: key, Error: err.Error()})
+		}
+	}
+}
+
+// printRankResult prints the rank result in a human-readable format
+func printRankResult(result *RankResult) {
+	if result.Success > 0 {
+		fmt.Printf("✓ Ranked %d issue(s) %s: %s\n", result.Success, result.Position, strings.Join(result.Ranked, ", "))
+	}
+
+	if result.Failed > 0 {
+		if result.Success > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("✗ Failed to rank %d issue(s):\n", result.Failed)
+		for _, e := range result.Errors {
+			fmt.Printf("  %s: %s\n", e.Key, e.Error)
+		}
+	}
+}
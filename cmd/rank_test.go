@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+func TestValidateRankArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		keys        []string
+		before      string
+		after       string
+		topOfSprint bool
+		valid       bool
+	}{
+		{"before", []string{"PROJ-5"}, "PROJ-2", "", false, true},
+		{"after", []string{"PROJ-5", "PROJ-6"}, "", "PROJ-2", false, true},
+		{"top of sprint", []string{"PROJ-5"}, "", "", true, true},
+		{"no position", []string{"PROJ-5"}, "", "", false, false},
+		{"two positions", []string{"PROJ-5"}, "PROJ-2", "", true, false},
+		{"relative to itself", []string{"PROJ-5", "PROJ-2"}, "PROJ-2", "", false, false},
+		{"duplicate", []string{"PROJ-5", "PROJ-5"}, "", "PROJ-2", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRankArgs(tt.keys, tt.before, tt.after, tt.topOfSprint)
+			if (err == nil) != tt.valid {
+				t.Errorf("Expected valid=%v, got %v", tt.valid, err)
+			}
+		})
+	}
+}
+
+func TestFirstOtherIssue(t *testing.T) {
+	issues := []models.Issue{{Key: "PROJ-6"}, {Key: "PROJ-3"}, {Key: "PROJ-5"}}
+
+	if got := firstOtherIssue(issues, []string{"PROJ-5", "PROJ-6"}); got != "PROJ-3" {
+		t.Errorf("Expected PROJ-3, got %q", got)
+	}
+	if got := firstOtherIssue(issues, []string{"PROJ-3", "PROJ-5", "PROJ-6"}); got != "" {
+		t.Errorf("Expected no other issue, got %q", got)
+	}
+}
+
+func TestCollectRankResult(t *testing.T) {
+	result := &RankResult{Ranked: []string{}, Errors: []BulkError{}}
+	entries := []models.RankEntry{
+		{IssueKey: "PROJ-5", Status: 200},
+		{IssueKey: "PROJ-6", Status: 403, Errors: []string{"No permission"}},
+	}
+
+	// PROJ-7 was in a request that failed as a whole
+	collectRankResult(result, []string{"PROJ-5", "PROJ-6", "PROJ-7"}, entries, errors.New("rate limited"))
+
+	if strings.Join(result.Ranked, ",") != "PROJ-5" {
+		t.Errorf("Expected PROJ-5 ranked, got %v", result.Ranked)
+	}
+	if len(result.Errors) != 2 || result.Errors[0].Error != "No permission" || result.Errors[1].Key != "PROJ-7" || result.Errors[1].Error != "rate limited" {
+		t.Errorf("Expected failures for PROJ-6 and PROJ-7, got %+v", result.Errors)
+	}
+}
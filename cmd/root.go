@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/sanisideup/jira-cli-for-agents/pkg/allowlist"
@@ -13,10 +14,16 @@ import (
 
 var (
 	// Global flags
-	cfgFile    string
-	jsonOutput bool
-	verbose    bool
-	noColor    bool
+	cfgFile      string
+	profileName  string
+	jsonOutput   bool
+	outputFormat string
+	verbose      bool
+	noColor      bool
+	rpsLimit     float64
+
+	// strictMentions makes ambiguous @mentions an error (see also strict_mentions)
+	strictMentions bool
 
 	// Global variables
 	cfg              *config.Config
@@ -32,6 +39,14 @@ var rootCmd = &cobra.Command{
 It provides commands for managing issues, projects, and more.
 Designed for AI-assisted workflows and developer productivity.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Resolve --output/--json before anything writes to stdout
+		if err := applyOutputFlag(); err != nil {
+			return err
+		}
+		if rpsLimit < 0 {
+			return client.NewValidationError("--rps cannot be negative", nil)
+		}
+
 		// Initialize allowlist checker
 		allowlistChecker = allowlist.NewChecker()
 
@@ -49,22 +64,30 @@ Designed for AI-assisted workflows and developer productivity.`,
 		}
 
 		// Skip config loading for commands that don't need it
-		if cmd.Name() == "configure" || cmd.Name() == "version" || cmd.Name() == "help" || cmd.Name() == "template" || cmd.Name() == "allowlist" || (cmd.Parent() != nil && cmd.Parent().Name() == "allowlist") {
+		if cmd.Name() == "configure" || cmd.Name() == "version" || cmd.Name() == "help" || cmd.Name() == "template" || cmd.Name() == "allowlist" || (cmd.Parent() != nil && (cmd.Parent().Name() == "allowlist" || cmd.Parent().Name() == "profile")) {
 			return nil
 		}
 
-		// Load configuration
+		// Load configuration: config file (optional when JIRA_DOMAIN is set), then the
+		// selected profile (--profile, then JCFA_PROFILE, then current_profile),
+		// then JIRA_* environment overrides
 		var err error
-		if cfgFile != "" {
-			// Load from custom config file path
-			cfg, err = config.LoadFromPath(cfgFile)
-		} else {
-			// Load from default location (~/.jcfa/config.yaml)
-			cfg, err = config.Load()
+		cfg, err = config.Resolve(cfgFile, selectedProfile())
+		if err != nil {
+			return &client.ConfigError{Err: fmt.Errorf("failed to load config: %w\nRun 'jcfa configure' to set up your credentials", err)}
 		}
 
-		if err != nil {
-			return fmt.Errorf("failed to load config: %w\nRun 'jcfa configure' to set up your credentials", err)
+		// Verbose output goes to stderr, so it doesn't mix into --json output
+		var sourceReport io.Writer
+		if verbose {
+			sourceReport = os.Stderr
+		}
+		if err := cfg.ValidateWithSources(sourceReport); err != nil {
+			return &client.ConfigError{Err: fmt.Errorf("failed to load config: %w", err)}
+		}
+
+		if verbose && cfg.ActiveProfile != "" {
+			fmt.Fprintf(os.Stderr, "Using profile: %s (%s)\n", cfg.ActiveProfile, cfg.Domain)
 		}
 
 		// Retrieve API token from keyring if configured
@@ -74,15 +97,26 @@ Designed for AI-assisted workflows and developer productivity.`,
 				backend = secrets.BackendAuto
 			}
 			store := secrets.NewStore(backend)
-			creds, err := store.Retrieve(cfg.Email)
+			creds, err := store.Retrieve(cfg.GetKeyringAccount())
 			if err != nil {
-				return fmt.Errorf("failed to retrieve credentials from keyring: %w", err)
+				return &client.ConfigError{Err: fmt.Errorf("failed to retrieve credentials from keyring: %w", err)}
 			}
 			cfg.APIToken = creds.APIToken
 		}
 
-		// Initialize Jira client
-		jiraClient = client.New(cfg)
+		// Initialize Jira client; --rps overrides requests_per_second for
+		// this run only, so it's applied to a copy that is never saved
+		clientCfg := cfg
+		if rpsLimit > 0 {
+			limited := *cfg
+			limited.RequestsPerSecond = rpsLimit
+			clientCfg = &limited
+		}
+		jiraClient = client.New(clientCfg)
+		if verbose {
+			// stderr, so retry and quota messages don't mix into streamed output
+			jiraClient.Verbose = os.Stderr
+		}
 
 		return nil
 	},
@@ -92,13 +126,30 @@ Designed for AI-assisted workflows and developer productivity.`,
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 // Exit codes:
 //   - 0: Success
-//   - 1: Authentication failure
+//   - 1: Authentication failure (or unclassified error)
 //   - 2: Validation error
 //   - 3: API error
 //   - 4: Configuration error
+//   - 5: Not found
+//   - 6: Permission denied
+//   - 7: Rate limited
+//
+// With --json (or another structured --output), the error is written to
+// stderr as a JSON error envelope.
 func Execute() {
+	// Decided from the raw arguments so flag parsing errors are reported as JSON too
+	jsonErrors := argsRequestJSON(os.Args[1:])
+	if jsonErrors {
+		rootCmd.SilenceErrors = true
+		rootCmd.SilenceUsage = true
+	}
+
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+		if jsonErrors || jsonOutput {
+			writeErrorJSON(os.Stderr, err)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+		}
 
 		// Determine exit code based on error type
 		exitCode := getExitCode(err)
@@ -106,49 +157,30 @@ func Execute() {
 	}
 }
 
-// getExitCode determines the appropriate exit code based on the error type
-func getExitCode(err error) int {
-	errMsg := err.Error()
-
-	// Check for specific error types
-	if containsAny(errMsg, []string{"authentication", "auth", "credentials", "unauthorized", "401"}) {
-		return 1 // Auth failure
-	}
-
-	if containsAny(errMsg, []string{"validation", "invalid", "required field", "400"}) {
-		return 2 // Validation error
-	}
-
-	if containsAny(errMsg, []string{"API error", "500", "502", "503", "504"}) {
-		return 3 // API error
-	}
-
-	if containsAny(errMsg, []string{"config", "configuration"}) {
-		return 4 // Config error
+// selectedProfile returns the profile requested via --profile or JCFA_PROFILE
+// ("" lets the config's current_profile apply)
+func selectedProfile() string {
+	if profileName != "" {
+		return profileName
 	}
-
-	// Default error
-	return 1
-}
-
-// containsAny checks if the string contains any of the substrings
-func containsAny(s string, substrs []string) bool {
-	for _, substr := range substrs {
-		if len(s) >= len(substr) {
-			for i := 0; i <= len(s)-len(substr); i++ {
-				if s[i:i+len(substr)] == substr {
-					return true
-				}
-			}
-		}
-	}
-	return false
+	return os.Getenv(config.ProfileEnvVar)
 }
 
 func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.jcfa/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "config profile to use (default is $JCFA_PROFILE or current_profile)")
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "output in JSON format")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "output format: table, json, ndjson, yaml, csv, tsv or template")
+	rootCmd.PersistentFlags().StringSliceVar(&outputColumns, "columns", nil, "columns for table/csv/tsv output (e.g., key,status,assignee,customfield_10016)")
+	rootCmd.PersistentFlags().StringVar(&outputTemplate, "template", "", "Go template for --output template (e.g., '{{.Key}} {{.Fields.summary}}')")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output")
+	rootCmd.PersistentFlags().Float64Var(&rpsLimit, "rps", 0, "max API requests per second, shared by all jcfa processes (default is requests_per_second from config)")
+	rootCmd.PersistentFlags().BoolVar(&strictMentions, "strict-mentions", false, "fail when an @mention in Markdown matches several users (default is strict_mentions from config)")
+
+	// Unknown or malformed flags are validation errors (exit code 2)
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return client.NewValidationError(err.Error(), nil)
+	})
 }
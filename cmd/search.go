@@ -6,12 +6,16 @@ import (
 	"os"
 
 	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/output"
 	"github.com/spf13/cobra"
 )
 
 var (
 	searchLimit  int
 	searchFields []string
+	searchAll    bool
+	searchMax    int
 )
 
 var searchCmd = &cobra.Command{
@@ -23,7 +27,12 @@ Examples:
   jcfa search "project = PROJ AND status = Open"
   jcfa search "assignee = currentUser() ORDER BY updated DESC" --limit 20
   jcfa search "project = PROJ AND type = Story" --json
-  jcfa search "project = PROJ" --fields summary,status,customfield_10014`,
+  jcfa search "project = PROJ" --fields summary,status,customfield_10014
+  jcfa search "\"Epic Link\" = PROJ-100" --all
+  jcfa search "sprint in openSprints()" --all --max 500 --json
+  jcfa search "project = PROJ" --all --output ndjson | jq -c '.key'
+  jcfa search "project = PROJ" --output csv --columns key,status,assignee,customfield_10016
+  jcfa search "project = PROJ" --output template --template '{{.Key}} {{.Fields.summary}}'`,
 	Args: cobra.ExactArgs(1),
 	RunE: runSearch,
 }
@@ -32,6 +41,8 @@ func init() {
 	rootCmd.AddCommand(searchCmd)
 	searchCmd.Flags().IntVar(&searchLimit, "limit", 50, "maximum number of results to return")
 	searchCmd.Flags().StringSliceVar(&searchFields, "fields", nil, "comma-separated list of fields to return (e.g., summary,status,customfield_10014)")
+	searchCmd.Flags().BoolVar(&searchAll, "all", false, "fetch all pages of results (ignores --limit, capped by --max)")
+	searchCmd.Flags().IntVar(&searchMax, "max", jira.DefaultSearchAllMax, "hard cap on the number of issues fetched with --all")
 }
 
 func runSearch(cmd *cobra.Command, args []string) error {
@@ -40,14 +51,22 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	// Create search service
 	searchService := jira.NewSearchService(jiraClient)
 
+	// Paginate through every page, streaming output as pages arrive
+	if searchAll {
+		if err := streamSearchResults(searchService, jql, searchFields, searchMax); err != nil {
+			return fmt.Errorf("search failed: %w", err)
+		}
+		return nil
+	}
+
 	// Execute search (pass fields if specified, otherwise nil for all fields)
 	result, err := searchService.Search(jql, searchLimit, searchFields)
 	if err != nil {
 		return fmt.Errorf("search failed: %w", err)
 	}
 
-	// Output based on format
-	if jsonOutput {
+	// JSON keeps the full response shape (issues, total, paging)
+	if isJSONFormat() {
 		encoder := json.NewEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(result); err != nil {
@@ -59,87 +78,67 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	return outputSearchResults(result)
 }
 
-// outputSearchResults outputs search results in human-readable format
-func outputSearchResults(result interface{}) error {
-	// Type assert or convert to map
-	var data map[string]interface{}
-
-	jsonBytes, err := json.Marshal(result)
-	if err != nil {
-		return fmt.Errorf("failed to marshal results: %w", err)
-	}
+// outputSearchResults outputs the issues of a search result in the selected format.
+// The table view adds a count header; other formats render the issues only.
+func outputSearchResults(result *models.SearchResponse) error {
+	if currentFormat() == output.FormatTable {
+		if len(result.Issues) == 0 {
+			fmt.Println("No issues found")
+			return nil
+		}
 
-	if err := json.Unmarshal(jsonBytes, &data); err != nil {
-		return fmt.Errorf("failed to unmarshal results: %w", err)
-	}
+		// The /search/jql endpoint doesn't report a total; fall back to the page size
+		total := result.Total
+		if total == 0 {
+			total = len(result.Issues)
+		}
 
-	// Extract issues
-	issues, ok := data["issues"].([]interface{})
-	if !ok {
-		return fmt.Errorf("invalid search result format")
+		fmt.Printf("Found %d issues:\n", total)
+		fmt.Println()
 	}
 
-	total, _ := data["total"].(float64)
+	return outputList(result.Issues, issueColumns)
+}
 
-	if len(issues) == 0 {
-		fmt.Println("No issues found")
-		return nil
+// streamSearchResults fetches every page of a JQL search and writes each page
+// as soon as it arrives, so large result sets are never held in memory.
+func streamSearchResults(searchService *jira.SearchService, jql string, fields []string, maxIssues int) error {
+	formatter, err := newFormatter()
+	if err != nil {
+		return err
 	}
+	stream := formatter.NewStream(issueColumns)
 
-	fmt.Printf("Found %d issues:\n", int(total))
-	fmt.Println()
-
-	// Print table header
-	fmt.Printf("%-12s %-12s %-15s %s\n", "KEY", "TYPE", "STATUS", "SUMMARY")
-	fmt.Println("--------------------------------------------------------------------------------")
-
-	// Print each issue
-	for _, issueItem := range issues {
-		issue, ok := issueItem.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		key, _ := issue["key"].(string)
-		fields, ok := issue["fields"].(map[string]interface{})
-		if !ok {
-			continue
+	total, err := searchService.SearchAll(jql, fields, maxIssues, func(issues []models.Issue) error {
+		for _, issue := range issues {
+			if err := stream.Write(issue); err != nil {
+				return err
+			}
 		}
+		return stream.Flush()
+	})
 
-		// Extract fields
-		summary, _ := fields["summary"].(string)
-
-		var issueType string
-		if it, ok := fields["issuetype"].(map[string]interface{}); ok {
-			issueType, _ = it["name"].(string)
-		}
+	// Always close the stream so JSON output stays well-formed on partial results
+	if closeErr := stream.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
 
-		var status string
-		if st, ok := fields["status"].(map[string]interface{}); ok {
-			status, _ = st["name"].(string)
+	if formatter.Format() == output.FormatTable {
+		if total == 0 {
+			fmt.Println("No issues found")
+			return nil
 		}
 
-		// Truncate summary if too long
-		if len(summary) > 50 {
-			summary = summary[:47] + "..."
+		fmt.Println()
+		if maxIssues > 0 && total >= maxIssues {
+			fmt.Printf("Fetched %d issues (stopped at --max %d; more may exist)\n", total, maxIssues)
+		} else {
+			fmt.Printf("Fetched %d issues\n", total)
 		}
-
-		// Print row
-		fmt.Printf("%-12s %-12s %-15s %s\n",
-			truncate(key, 12),
-			truncate(issueType, 12),
-			truncate(status, 15),
-			summary,
-		)
 	}
 
 	return nil
 }
-
-// truncate truncates a string to the specified length
-func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-3] + "..."
-}
@@ -0,0 +1,351 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// defaultSprintLength is the length of a started sprint without --end
+	defaultSprintLength = 14 * 24 * time.Hour
+	// sprintDateLayout is how sprint dates are sent to the Agile API
+	sprintDateLayout = "2006-01-02T15:04:05.000Z07:00"
+)
+
+var (
+	sprintBoard string
+	sprintState string
+	sprintStart string
+	sprintEnd   string
+	sprintGoal  string
+)
+
+// sprintCmd is the parent command for sprints
+var sprintCmd = &cobra.Command{
+	Use:   "sprint",
+	Short: "Manage sprints",
+	Long: `Manage the sprints of a scrum board.
+
+Sprints are given by ID, or by name together with --board (ID or name).
+
+Subcommands:
+  list      - List the sprints of a board
+  active    - Show the active sprint of a board
+  create    - Create a sprint
+  start     - Start a sprint
+  close     - Close a sprint
+  add       - Move issues to a sprint`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var sprintListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the sprints of a board",
+	Long: `List the sprints of a scrum board, optionally only those in some states
+(future, active, closed; comma-separated).
+
+Examples:
+  jcfa sprint list --board 7
+  jcfa sprint list --board "PROJ board" --state future,active
+  jcfa sprint list --board 7 --json`,
+	Args: cobra.NoArgs,
+	RunE: runSprintList,
+}
+
+var sprintActiveCmd = &cobra.Command{
+	Use:   "active",
+	Short: "Show the active sprint of a board",
+	Long: `Show the active sprint of a scrum board (boards running parallel sprints
+can have several).
+
+Examples:
+  jcfa sprint active --board 7
+  jcfa sprint active --board 7 --json`,
+	Args: cobra.NoArgs,
+	RunE: runSprintActive,
+}
+
+var sprintCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a sprint",
+	Long: `Create a future sprint on a scrum board.
+
+Dates are YYYY-MM-DD or ISO 8601 date-times.
+
+Examples:
+  jcfa sprint create "Sprint 12" --board 7
+  jcfa sprint create "Sprint 12" --board 7 --start 2024-05-06 --end 2024-05-17 --goal "Ship search"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSprintCreate,
+}
+
+var sprintStartCmd = &cobra.Command{
+	Use:   "start <sprint>",
+	Short: "Start a sprint",
+	Long: `Start a future sprint. It starts now unless --start is given, and ends at
+--end, the sprint's planned end date, or two weeks after it starts.
+
+Examples:
+  jcfa sprint start 42
+  jcfa sprint start "Sprint 12" --board 7 --end 2024-05-17`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSprintStart,
+}
+
+var sprintCloseCmd = &cobra.Command{
+	Use:   "close <sprint>",
+	Short: "Close a sprint",
+	Long: `Close an active sprint.
+
+Examples:
+  jcfa sprint close 42
+  jcfa sprint close "Sprint 12" --board 7 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSprintClose,
+}
+
+var sprintAddCmd = &cobra.Command{
+	Use:   "add <sprint> <issue-key>...",
+	Short: "Move issues to a sprint",
+	Long: `Move issues to a future or active sprint, from the backlog or another sprint.
+
+Examples:
+  jcfa sprint add 42 PROJ-1 PROJ-2
+  jcfa sprint add "Sprint 12" PROJ-1 --board 7`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runSprintAdd,
+}
+
+func init() {
+	rootCmd.AddCommand(sprintCmd)
+	sprintCmd.AddCommand(sprintListCmd)
+	sprintCmd.AddCommand(sprintActiveCmd)
+	sprintCmd.AddCommand(sprintCreateCmd)
+	sprintCmd.AddCommand(sprintStartCmd)
+	sprintCmd.AddCommand(sprintCloseCmd)
+	sprintCmd.AddCommand(sprintAddCmd)
+
+	sprintCmd.PersistentFlags().StringVarP(&sprintBoard, "board", "b", "", "board ID or name")
+	sprintListCmd.Flags().StringVar(&sprintState, "state", "", "only sprints in these states: future, active, closed (comma-separated)")
+	for _, cmd := range []*cobra.Command{sprintCreateCmd, sprintStartCmd} {
+		cmd.Flags().StringVar(&sprintStart, "start", "", "start date (YYYY-MM-DD or ISO 8601)")
+		cmd.Flags().StringVar(&sprintEnd, "end", "", "end date (YYYY-MM-DD or ISO 8601)")
+		cmd.Flags().StringVar(&sprintGoal, "goal", "", "sprint goal")
+	}
+}
+
+func runSprintList(cmd *cobra.Command, args []string) error {
+	var states []string
+	if sprintState != "" {
+		states = strings.Split(sprintState, ",")
+	}
+	return listSprints(states, "No sprints found on board '%s'")
+}
+
+func runSprintActive(cmd *cobra.Command, args []string) error {
+	return listSprints([]string{"active"}, "No active sprint on board '%s'")
+}
+
+// listSprints prints the sprints of --board in some states
+// Parameters:
+//   - states: Sprint states to include (none = all)
+//   - emptyMsg: Message printed when there are none, given the board name
+func listSprints(states []string, emptyMsg string) error {
+	agileService := jira.NewAgileService(jiraClient)
+	board, err := resolveBoard(agileService, sprintBoard)
+	if err != nil {
+		return err
+	}
+
+	sprints, err := agileService.ListSprints(board.ID, states...)
+	if err != nil {
+		return fmt.Errorf("failed to list sprints: %w", err)
+	}
+
+	if isJSONFormat() {
+		return outputJSON(sprints)
+	}
+	if len(sprints) == 0 && useDetailView() {
+		fmt.Printf(emptyMsg+"\n", board.Name)
+		return nil
+	}
+	return outputList(sprints, sprintColumns)
+}
+
+func runSprintCreate(cmd *cobra.Command, args []string) error {
+	start, err := sprintDate("start", sprintStart)
+	if err != nil {
+		return err
+	}
+	end, err := sprintDate("end", sprintEnd)
+	if err != nil {
+		return err
+	}
+
+	agileService := jira.NewAgileService(jiraClient)
+	board, err := resolveBoard(agileService, sprintBoard)
+	if err != nil {
+		return err
+	}
+
+	sprint, err := agileService.CreateSprint(board.ID, args[0], formatSprintDate(start), formatSprintDate(end), sprintGoal)
+	if err != nil {
+		return fmt.Errorf("failed to create sprint: %w", err)
+	}
+
+	return printSprintResult(sprint, fmt.Sprintf("Created sprint '%s' (ID %d) on board '%s'", sprint.Name, sprint.ID, board.Name))
+}
+
+func runSprintStart(cmd *cobra.Command, args []string) error {
+	agileService := jira.NewAgileService(jiraClient)
+	sprint, err := findSprint(agileService, args[0])
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	if sprintStart != "" {
+		if start, err = sprintDate("start", sprintStart); err != nil {
+			return err
+		}
+	}
+
+	// --end, then the planned end date, then the default length
+	end, err := sprintDate("end", sprintEnd)
+	if err != nil {
+		return err
+	}
+	if end.IsZero() && sprint.EndDate != "" {
+		if planned, err := time.Parse(time.RFC3339, sprint.EndDate); err == nil && planned.After(start) {
+			end = planned
+		}
+	}
+	if end.IsZero() {
+		end = start.Add(defaultSprintLength)
+	}
+	if !end.After(start) {
+		return inputError(nil, "the sprint must end after it starts")
+	}
+
+	changes := map[string]interface{}{
+		"state":     "active",
+		"startDate": formatSprintDate(start),
+		"endDate":   formatSprintDate(end),
+	}
+	if sprintGoal != "" {
+		changes["goal"] = sprintGoal
+	}
+
+	updated, err := agileService.UpdateSprint(sprint.ID, changes)
+	if err != nil {
+		return fmt.Errorf("failed to start sprint: %w", err)
+	}
+
+	return printSprintResult(updated, fmt.Sprintf("Started sprint '%s' (ends %s)", updated.Name, end.Format("2006-01-02")))
+}
+
+func runSprintClose(cmd *cobra.Command, args []string) error {
+	agileService := jira.NewAgileService(jiraClient)
+	sprint, err := findSprint(agileService, args[0])
+	if err != nil {
+		return err
+	}
+
+	updated, err := agileService.UpdateSprint(sprint.ID, map[string]interface{}{"state": "closed"})
+	if err != nil {
+		return fmt.Errorf("failed to close sprint: %w", err)
+	}
+
+	return printSprintResult(updated, fmt.Sprintf("Closed sprint '%s'", updated.Name))
+}
+
+func runSprintAdd(cmd *cobra.Command, args []string) error {
+	agileService := jira.NewAgileService(jiraClient)
+	sprint, err := findSprint(agileService, args[0])
+	if err != nil {
+		return err
+	}
+
+	issueKeys := args[1:]
+	if err := agileService.MoveIssuesToSprint(sprint.ID, issueKeys); err != nil {
+		return fmt.Errorf("failed to move issues to sprint: %w", err)
+	}
+
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"status": "success",
+			"sprint": sprint,
+			"issues": issueKeys,
+		})
+	}
+
+	fmt.Printf("✓ Moved %d issue(s) to sprint '%s': %s\n", len(issueKeys), sprint.Name, strings.Join(issueKeys, ", "))
+	return nil
+}
+
+// findSprint finds a sprint by ID, or by name on --board
+func findSprint(agileService *jira.AgileService, nameOrID string) (*models.Sprint, error) {
+	boardID := 0
+	if sprintBoard != "" {
+		board, err := resolveBoard(agileService, sprintBoard)
+		if err != nil {
+			return nil, err
+		}
+		boardID = board.ID
+	}
+
+	sprint, err := agileService.FindSprint(boardID, nameOrID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sprint: %w", err)
+	}
+	return sprint, nil
+}
+
+// printSprintResult prints a created or updated sprint
+func printSprintResult(sprint *models.Sprint, message string) error {
+	if jsonOutput {
+		return outputJSON(map[string]interface{}{
+			"status":  "success",
+			"message": message,
+			"sprint":  sprint,
+		})
+	}
+
+	fmt.Printf("✓ %s\n", message)
+	return nil
+}
+
+// sprintDate parses a sprint date flag: YYYY-MM-DD (local midnight) or an
+// ISO 8601 date-time ("" = zero time)
+// Parameters:
+//   - flag: The flag name, for errors
+//   - value: The flag value
+func sprintDate(flag, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{time.RFC3339, sprintDateLayout, "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, inputError(nil, "invalid --%s date '%s': use YYYY-MM-DD or an ISO 8601 date-time", flag, value)
+}
+
+// formatSprintDate formats a sprint date for the Agile API ("" for zero)
+func formatSprintDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(sprintDateLayout)
+}
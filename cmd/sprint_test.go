@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSprintDate(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		{"", time.Time{}},
+		{"2024-05-06", time.Date(2024, 5, 6, 0, 0, 0, 0, time.Local)},
+		{"2024-05-06T09:30", time.Date(2024, 5, 6, 9, 30, 0, 0, time.Local)},
+		{"2024-05-06T09:30:00Z", time.Date(2024, 5, 6, 9, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := sprintDate("start", tt.value)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !got.Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := sprintDate("end", "next friday"); err == nil {
+		t.Errorf("Expected an error for an invalid date")
+	}
+}
+
+func TestFormatSprintDate(t *testing.T) {
+	if got := formatSprintDate(time.Time{}); got != "" {
+		t.Errorf("Expected no date for the zero time, got %q", got)
+	}
+	date := time.Date(2024, 5, 6, 9, 30, 0, 0, time.UTC)
+	if got := formatSprintDate(date); got != "2024-05-06T09:30:00.000Z" {
+		t.Errorf("Expected an ISO 8601 date-time, got %q", got)
+	}
+}
@@ -16,7 +16,7 @@ var templateCmd = &cobra.Command{
 	Long:  `Manage Jira issue templates for creating issues with predefined structures.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// Override parent's PersistentPreRunE to skip config loading
-		return nil
+		return applyOutputFlag()
 	},
 }
 
@@ -98,12 +98,7 @@ func runTemplateList(cmd *cobra.Command, args []string) error {
 	}
 
 	if jsonOutput {
-		data, err := json.MarshalIndent(templates, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-		return nil
+		return outputJSON(templates)
 	}
 
 	fmt.Println("Available templates:")
@@ -133,12 +128,7 @@ func runTemplateShow(cmd *cobra.Command, args []string) error {
 	}
 
 	if jsonOutput {
-		data, err := json.MarshalIndent(tmpl, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-		return nil
+		return outputJSON(tmpl)
 	}
 
 	fmt.Printf("Template: %s\n", templateName)
@@ -1,54 +1,488 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
 	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
 	"github.com/spf13/cobra"
 )
 
+var (
+	transitionFields   []string
+	transitionComment  string
+	transitionPath     bool
+	transitionDryRun   bool
+	transitionCategory string
+)
+
 var transitionCmd = &cobra.Command{
-	Use:   "transition <issue-key> \"<status>\"",
+	Use:   "transition <issue-key> [\"<status>\"]",
 	Short: "Transition an issue to a new status",
 	Long: `Transition a Jira issue to a new workflow status.
 
-The status name is case-insensitive. If the specified status is not available
-for the issue, the command will show available transitions.
+The status name is case-insensitive, and can also be the name of the
+transition itself (e.g. "Resolve"). Aliases from status_aliases in the config
+are tried in order, so one name can cover statuses that differ between
+projects:
+
+  status_aliases:
+    done: [Done, Closed, Resolved]
+
+--category transitions to any status in a status category instead: "todo",
+"in progress" or "done". If nothing matches, the command shows the available
+transitions and suggests the closest names.
+
+Workflows can require fields on the transition screen, such as a resolution
+or a fix version. Set them with --field, in the same name=value form as
+'jcfa update'; values are converted and checked against the allowed values
+of the screen. If a required field is missing, the error lists it along with
+its allowed values. --comment (Markdown) adds a comment with the transition.
+
+With --path, the target status doesn't have to be directly reachable: the
+issue type's workflow is fetched and the shortest chain of transitions to
+the target is taken, one step at a time. --field and --comment apply to the
+last step, but are checked before the first (and by --dry-run): their
+syntax, that the fields exist and, where Jira makes them known in advance,
+their values. If a step fails, the issue is left in the status it reached
+and the error shows how to get back to where it started. --dry-run shows
+the transition (or chain of transitions) without performing it.
 
 Examples:
   jcfa transition PROJ-123 "In Progress"
-  jcfa transition PROJ-123 "Done" --json`,
-	Args: cobra.ExactArgs(2),
+  jcfa transition PROJ-123 "Done" --json
+  jcfa transition PROJ-123 Done --field resolution=Fixed
+  jcfa transition PROJ-123 Done --field resolution=Fixed --field fixVersions=1.2 --comment "Fixed in 1.2"
+  jcfa transition PROJ-123 Done --path --dry-run
+  jcfa transition PROJ-123 Done --path --field resolution=Fixed
+  jcfa transition PROJ-123 --category done`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: runTransition,
 }
 
 func init() {
 	rootCmd.AddCommand(transitionCmd)
+	transitionCmd.Flags().StringArrayVarP(&transitionFields, "field", "f", []string{}, "field on the transition screen in format name=value (can be specified multiple times)")
+	transitionCmd.Flags().StringVar(&transitionComment, "comment", "", "comment (Markdown) to add with the transition")
+	transitionCmd.Flags().BoolVar(&transitionPath, "path", false, "take the shortest chain of transitions to the status if it isn't directly reachable")
+	transitionCmd.Flags().BoolVar(&transitionDryRun, "dry-run", false, "show the transition(s) without performing them")
+	transitionCmd.Flags().StringVar(&transitionCategory, "category", "", "transition to a status in this category (todo, in progress, done) instead of a named status")
 }
 
 func runTransition(cmd *cobra.Command, args []string) error {
 	issueKey := args[0]
-	targetStatus := args[1]
+	var status string
+	if len(args) > 1 {
+		status = args[1]
+	}
+	target, err := transitionTarget(status, transitionCategory)
+	if err != nil {
+		return err
+	}
 
 	if verbose {
-		fmt.Printf("Transitioning issue %s to '%s'\n", issueKey, targetStatus)
+		fmt.Printf("Transitioning issue %s to %s\n", issueKey, target)
+	}
+
+	if transitionPath {
+		return runTransitionPath(issueKey, target)
 	}
 
 	// Create search service
 	searchService := jira.NewSearchService(jiraClient)
 
+	// Find the transition, along with the fields on its screen
+	transitions, err := searchService.GetTransitions(issueKey)
+	if err != nil {
+		return fmt.Errorf("failed to get transitions: %w", err)
+	}
+	transition, err := jira.FindTransitionTo(transitions, target)
+	if err != nil {
+		return fmt.Errorf("failed to transition issue: %w", err)
+	}
+
+	fields, operations, err := transitionScreenValues(transition)
+	if err != nil {
+		return err
+	}
+
+	if err := jira.ValidateTransitionFields(transition, fields, operations); err != nil {
+		return fmt.Errorf("failed to transition issue: %w", err)
+	}
+
+	if transitionDryRun {
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{
+				"status":     "dry_run",
+				"issue":      issueKey,
+				"transition": transition.Name,
+				"to":         transition.To.Name,
+				"fields":     fields,
+			})
+		}
+		fmt.Printf("Would transition issue %s to '%s' via '%s'\n", issueKey, transition.To.Name, transition.Name)
+		return nil
+	}
+
 	// Transition the issue
-	if err := searchService.TransitionIssue(issueKey, targetStatus); err != nil {
+	if err := searchService.PerformTransition(issueKey, transition.ID, fields, operations); err != nil {
 		return fmt.Errorf("failed to transition issue: %w", err)
 	}
 
 	if jsonOutput {
 		return outputJSON(map[string]interface{}{
-			"status":  "success",
-			"message": fmt.Sprintf("Successfully transitioned issue %s to '%s'", issueKey, targetStatus),
+			"status":     "success",
+			"message":    fmt.Sprintf("Successfully transitioned issue %s to '%s'", issueKey, transition.To.Name),
+			"transition": transition.Name,
+			"fields":     fields,
 		})
 	}
 
-	fmt.Printf("✓ Successfully transitioned issue %s to '%s'\n", issueKey, targetStatus)
+	fmt.Printf("✓ Successfully transitioned issue %s to '%s'\n", issueKey, transition.To.Name)
+	return nil
+}
+
+// transitionTarget builds the target of a transition from a status name,
+// with its aliases from the config, or a status category
+func transitionTarget(status, category string) (jira.TransitionTarget, error) {
+	switch {
+	case status != "" && category != "":
+		return jira.TransitionTarget{}, inputError(nil, "specify either a status or --category, not both")
+	case category != "":
+		return jira.TransitionTarget{Category: category}, nil
+	case status == "":
+		return jira.TransitionTarget{}, inputError(nil, "a status or --category must be specified")
+	}
+
+	if cfg == nil {
+		return jira.TransitionTarget{Names: []string{status}}, nil
+	}
+	return jira.TransitionTarget{Names: cfg.StatusNames(status)}, nil
+}
+
+// transitionScreenValues parses --field and --comment against the fields on
+// a transition's screen
+func transitionScreenValues(transition *models.Transition) (map[string]interface{}, jira.FieldOperations, error) {
+	fields, operations, err := parseFieldUpdates(transitionFields, jira.TextFormatMarkdown, transition.Fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	if transitionComment != "" {
+		body, err := textToADF(transitionComment, jira.TextFormatMarkdown)
+		if err != nil {
+			return nil, nil, inputError(err, "invalid comment")
+		}
+		operations["comment"] = append(operations["comment"], map[string]interface{}{"add": map[string]interface{}{"body": body}})
+	}
+	return fields, operations, nil
+}
+
+// transitionPathReport describes a chain of transitions, as planned or
+// as far as it got
+type transitionPathReport struct {
+	Status   string               `json:"status"` // dry_run, unchanged, success or failed
+	Issue    string               `json:"issue"`
+	Workflow string               `json:"workflow"`
+	From     string               `json:"from"`
+	To       string               `json:"to"`
+	Steps    []transitionPathStep `json:"steps"`
+	Error    string               `json:"error,omitempty"`
+	Current  string               `json:"current,omitempty"`  // Status the issue was left in after a failure
+	Rollback []transitionPathStep `json:"rollback,omitempty"` // Transitions back to the original status
+}
+
+// transitionPathStep is a single transition in a chain
+type transitionPathStep struct {
+	Transition string `json:"transition"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Status     string `json:"status,omitempty"` // pending, done or failed
+
+	id   string
+	toID string
+}
+
+// runTransitionPath moves an issue to a status through the shortest chain
+// of transitions in its workflow
+func runTransitionPath(issueKey string, target jira.TransitionTarget) error {
+	searchService := jira.NewSearchService(jiraClient)
+
+	issue, err := searchService.GetIssue(issueKey)
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+	workflow, err := newWorkflowService().GetIssueWorkflow(issue)
+	if err != nil {
+		var permissionErr *client.PermissionError
+		if errors.As(err, &permissionErr) {
+			permissionErr.Hints = append(permissionErr.Hints, fmt.Sprintf("Without --path: jcfa transition %s <status>", issueKey))
+		}
+		return fmt.Errorf("failed to get workflow: %w", err)
+	}
+
+	status, _ := issue.Fields["status"].(map[string]interface{})
+	fromID, _ := status["id"].(string)
+	path, err := jira.FindTransitionPath(workflow, fromID, target)
+	if err != nil {
+		return fmt.Errorf("failed to transition issue: %w", err)
+	}
+
+	report := &transitionPathReport{
+		Status:   "dry_run",
+		Issue:    issueKey,
+		Workflow: workflow.Name,
+		From:     issueStatus(*issue),
+		To:       issueStatus(*issue),
+		Steps:    pathSteps(workflow, fromID, path),
+	}
+	if len(path) > 0 {
+		report.To = report.Steps[len(path)-1].To
+	}
+
+	if len(path) == 0 {
+		report.Status = "unchanged"
+		if jsonOutput {
+			return outputJSON(report)
+		}
+		fmt.Printf("✓ Issue %s is already in '%s'\n", issueKey, report.From)
+		return nil
+	}
+
+	// Check --field and --comment before anything moves, so a mistake can't
+	// leave the issue partway along the chain
+	if err := checkPathInput(searchService, issueKey, report.Steps); err != nil {
+		return fmt.Errorf("failed to transition issue: %w", err)
+	}
+
+	if transitionDryRun {
+		if jsonOutput {
+			return outputJSON(report)
+		}
+		fmt.Printf("Would transition issue %s from '%s' to '%s' in %d step(s):\n", issueKey, report.From, report.To, len(path))
+		for i, step := range report.Steps {
+			fmt.Printf("  %d. %s (%s → %s)\n", i+1, step.Transition, step.From, step.To)
+		}
+		return nil
+	}
+
+	for i := range report.Steps {
+		step := &report.Steps[i]
+		if err := performPathStep(searchService, issueKey, step, i == len(report.Steps)-1); err != nil {
+			step.Status = "failed"
+			return transitionPathFailed(report, workflow, fromID, i, err)
+		}
+		step.Status = "done"
+		if !jsonOutput {
+			fmt.Printf("✓ Step %d/%d: %s (%s → %s)\n", i+1, len(report.Steps), step.Transition, step.From, step.To)
+		}
+	}
+
+	report.Status = "success"
+	if jsonOutput {
+		return outputJSON(report)
+	}
+	fmt.Printf("✓ Successfully transitioned issue %s to '%s'\n", issueKey, report.To)
 	return nil
 }
+
+// pathSteps describes a chain of workflow transitions starting at a status
+func pathSteps(workflow *models.Workflow, fromID string, path []models.WorkflowTransition) []transitionPathStep {
+	steps := make([]transitionPathStep, len(path))
+	for i, t := range path {
+		steps[i] = transitionPathStep{
+			Transition: t.Name,
+			From:       jira.WorkflowStatusName(workflow, fromID),
+			To:         jira.WorkflowStatusName(workflow, t.To),
+			Status:     "pending",
+			id:         t.ID,
+			toID:       t.To,
+		}
+		fromID = t.To
+	}
+	return steps
+}
+
+// performPathStep performs one transition of a chain. The transition must
+// be available to the issue now (workflow conditions can hide it); --field
+// and --comment are only applied to the last step.
+func performPathStep(searchService *jira.SearchService, issueKey string, step *transitionPathStep, last bool) error {
+	transition, err := pathStepTransition(searchService, issueKey, step)
+	if err != nil {
+		return err
+	}
+
+	var fields map[string]interface{}
+	var operations jira.FieldOperations
+	if last {
+		if fields, operations, err = transitionScreenValues(transition); err != nil {
+			return err
+		}
+	}
+	if err := jira.ValidateTransitionFields(transition, fields, operations); err != nil {
+		return err
+	}
+
+	return searchService.PerformTransition(issueKey, transition.ID, fields, operations)
+}
+
+// pathStepTransition finds the transition of a chain step among those
+// available to the issue now, with the fields on its screen
+func pathStepTransition(searchService *jira.SearchService, issueKey string, step *transitionPathStep) (*models.Transition, error) {
+	transitions, err := searchService.GetTransitions(issueKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var transition *models.Transition
+	for i, t := range transitions {
+		if t.ID == step.id || (transition == nil && t.To.ID == step.toID) {
+			transition = &transitions[i]
+		}
+	}
+	if transition == nil {
+		available := make([]string, len(transitions))
+		for i, t := range transitions {
+			available[i] = t.Name
+		}
+		msg := fmt.Sprintf("transition '%s' is not available to %s (a workflow condition may prevent it)", step.Transition, issueKey)
+		return nil, client.NewAllowedValuesError("transition", msg, available)
+	}
+	return transition, nil
+}
+
+// checkPathInput checks --field and --comment before the first step of a
+// chain. A single step is checked against its transition screen. Otherwise
+// the last screen can't be fetched until the issue gets there, so fields are
+// checked against the Jira field list and the issue's edit metadata instead:
+// unknown fields, value types and, for fields on the edit screen, allowed
+// values. Required screen fields are still only known at the last step.
+func checkPathInput(searchService *jira.SearchService, issueKey string, steps []transitionPathStep) error {
+	if _, err := splitFieldUpdates(transitionFields); err != nil {
+		return err
+	}
+	if transitionComment != "" {
+		if _, err := textToADF(transitionComment, jira.TextFormatMarkdown); err != nil {
+			return inputError(err, "invalid comment")
+		}
+	}
+
+	if len(steps) == 1 {
+		transition, err := pathStepTransition(searchService, issueKey, &steps[0])
+		if err != nil {
+			return err
+		}
+		fields, operations, err := transitionScreenValues(transition)
+		if err != nil {
+			return err
+		}
+		return jira.ValidateTransitionFields(transition, fields, operations)
+	}
+	if len(transitionFields) == 0 {
+		return nil
+	}
+
+	meta, err := pathFieldMeta(issueKey)
+	if err != nil {
+		return err
+	}
+	fields, operations, err := parseFieldUpdates(transitionFields, jira.TextFormatMarkdown, meta)
+	if err != nil {
+		return err
+	}
+	return checkKnownFields(meta, fields, operations)
+}
+
+// pathFieldMeta returns metadata for every Jira field, with the allowed
+// values of the fields on the issue's edit screen
+func pathFieldMeta(issueKey string) (map[string]models.FieldMeta, error) {
+	allFields, err := jira.NewFieldService(jiraClient).ListFields("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fields: %w", err)
+	}
+
+	meta := make(map[string]models.FieldMeta, len(allFields))
+	for _, field := range allFields {
+		meta[field.ID] = models.FieldMeta{Name: field.Name, Schema: field.Schema}
+	}
+	// Without edit permission only the types are checked
+	if editMeta, err := jira.NewMetadataService(jiraClient).GetEditMetadata(issueKey); err == nil {
+		for fieldID, fieldMeta := range editMeta.Fields {
+			meta[fieldID] = fieldMeta
+		}
+	}
+	return meta, nil
+}
+
+// checkKnownFields reports fields that aren't in meta, from parseFieldUpdates
+// results
+func checkKnownFields(meta map[string]models.FieldMeta, fields map[string]interface{}, operations jira.FieldOperations) error {
+	var unknown []string
+	for fieldID := range fields {
+		if _, ok := meta[fieldID]; !ok {
+			unknown = append(unknown, fieldID)
+		}
+	}
+	for fieldID := range operations {
+		if _, ok := meta[fieldID]; !ok {
+			unknown = append(unknown, fieldID)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknown)
+	fieldErrors := make(map[string]string, len(unknown))
+	for _, fieldID := range unknown {
+		fieldErrors[fieldID] = fmt.Sprintf("unknown field '%s'", fieldID)
+	}
+	err := client.NewValidationError(fmt.Sprintf("unknown field(s): %s", strings.Join(unknown, ", ")), fieldErrors)
+	err.Hints = []string{"Run 'jcfa fields list' to see available fields"}
+	return err
+}
+
+// transitionPathFailed reports a chain of transitions that stopped at a
+// failed step, along with the transitions that would take the issue back
+// to its original status
+// Parameters:
+//   - report: The chain, with the failed step marked
+//   - workflow: The issue's workflow
+//   - fromID: ID of the status the issue started in
+//   - failed: Index of the failed step
+//   - err: The error from the failed step
+func transitionPathFailed(report *transitionPathReport, workflow *models.Workflow, fromID string, failed int, err error) error {
+	report.Status = "failed"
+	report.Error = err.Error()
+	report.Current = report.Steps[failed].From
+
+	var hint string
+	if failed > 0 {
+		currentID := report.Steps[failed-1].toID
+		if back, pathErr := jira.FindTransitionPath(workflow, currentID, jira.TransitionTarget{Names: []string{report.From}}); pathErr == nil {
+			report.Rollback = pathSteps(workflow, currentID, back)
+		}
+		hint = fmt.Sprintf("%s is now in '%s'; to roll back, run: jcfa transition %s \"%s\" --path", report.Issue, report.Current, report.Issue, report.From)
+	}
+
+	if jsonOutput {
+		if outErr := outputJSON(report); outErr != nil {
+			return outErr
+		}
+	} else {
+		step := report.Steps[failed]
+		fmt.Printf("✗ Step %d/%d: %s (%s → %s) failed\n", failed+1, len(report.Steps), step.Transition, step.From, step.To)
+	}
+
+	if hint != "" {
+		if apiErr, ok := client.AsAPIError(err); ok {
+			apiErr.Hints = append(apiErr.Hints, hint)
+		} else {
+			return fmt.Errorf("step %d/%d failed: %w (%s)", failed+1, len(report.Steps), err, hint)
+		}
+	}
+	return fmt.Errorf("step %d/%d failed: %w", failed+1, len(report.Steps), err)
+}
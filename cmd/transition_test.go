@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/config"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+func TestTransitionPathFailed(t *testing.T) {
+	workflow := &models.Workflow{
+		Name: "Software workflow",
+		Statuses: []models.Status{
+			{ID: "1", Name: "To Do"},
+			{ID: "2", Name: "In Progress"},
+			{ID: "3", Name: "Done"},
+		},
+		Transitions: []models.WorkflowTransition{
+			{ID: "11", Name: "Start", From: []string{"1"}, To: "2", Type: "directed"},
+			{ID: "21", Name: "Finish", From: []string{"2"}, To: "3", Type: "directed"},
+			{ID: "31", Name: "Stop", From: []string{"2"}, To: "1", Type: "directed"},
+		},
+	}
+	path, err := jira.FindTransitionPath(workflow, "1", jira.TransitionTarget{Names: []string{"Done"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	report := &transitionPathReport{Issue: "PROJ-1", From: "To Do", To: "Done", Steps: pathSteps(workflow, "1", path)}
+	report.Steps[0].Status = "done"
+	report.Steps[1].Status = "failed"
+
+	stepErr := client.NewValidationError("resolution is required", nil)
+	err = transitionPathFailed(report, workflow, "1", 1, stepErr)
+
+	if report.Status != "failed" || report.Current != "In Progress" {
+		t.Errorf("Expected a failed report leaving the issue In Progress, got %+v", report)
+	}
+	if len(report.Rollback) != 1 || report.Rollback[0].Transition != "Stop" {
+		t.Errorf("Expected the Stop transition to roll back, got %+v", report.Rollback)
+	}
+
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Hints) != 1 || !strings.Contains(validationErr.Hints[0], `jcfa transition PROJ-1 "To Do" --path`) {
+		t.Errorf("Expected the original error with a rollback hint, got %v", err)
+	}
+}
+
+func TestTransitionTarget(t *testing.T) {
+	defer func(saved *config.Config) { cfg = saved }(cfg)
+	cfg = &config.Config{StatusAliases: map[string][]string{"done": {"Done", "Closed"}}}
+
+	target, err := transitionTarget("done", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := strings.Join(target.Names, ","); got != "done,Done,Closed" {
+		t.Errorf("Expected the status and its aliases, got %s", got)
+	}
+
+	target, err = transitionTarget("", "done")
+	if err != nil || target.Category != "done" || len(target.Names) != 0 {
+		t.Errorf("Expected a category target, got %+v (%v)", target, err)
+	}
+
+	if _, err := transitionTarget("Done", "done"); err == nil {
+		t.Errorf("Expected an error for both a status and a category")
+	}
+	if _, err := transitionTarget("", ""); err == nil {
+		t.Errorf("Expected an error for neither a status nor a category")
+	}
+}
+
+func TestCheckPathInput(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/field":
+			json.NewEncoder(w).Encode([]models.Field{
+				{ID: "resolution", Name: "Resolution", Schema: models.FieldSchema{Type: "resolution", System: "resolution"}},
+				{ID: "customfield_10005", Name: "Story Points", Schema: models.FieldSchema{Type: "number"}},
+				{ID: "fixVersions", Name: "Fix versions", Schema: models.FieldSchema{Type: "array", Items: "version", System: "fixVersions"}},
+			})
+		case "/issue/PROJ-1/editmeta":
+			json.NewEncoder(w).Encode(models.EditMetaResponse{Fields: map[string]models.FieldMeta{
+				"fixVersions": {
+					Name:          "Fix versions",
+					Schema:        models.FieldSchema{Type: "array", Items: "version", System: "fixVersions"},
+					AllowedValues: []interface{}{map[string]interface{}{"id": "1", "name": "1.2"}},
+				},
+			}})
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	defer func(saved *client.Client, fields []string, comment string) {
+		jiraClient, transitionFields, transitionComment = saved, fields, comment
+	}(jiraClient, transitionFields, transitionComment)
+	jiraClient = &client.Client{BaseURL: server.URL, HTTPClient: resty.New().SetBaseURL(server.URL)}
+	searchService := jira.NewSearchService(jiraClient)
+	steps := []transitionPathStep{{Transition: "Start"}, {Transition: "Finish"}}
+
+	tests := []struct {
+		name   string
+		fields []string
+		valid  bool
+	}{
+		{"valid", []string{"resolution=Fixed", "Story Points=3", "fixVersions=1.2"}, true},
+		{"no value", []string{"resolution"}, false},
+		{"unknown field", []string{"resolutoin=Fixed"}, false},
+		{"wrong type", []string{"Story Points=three"}, false},
+		{"not an allowed value", []string{"fixVersions=9.9"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transitionFields, transitionComment = tt.fields, ""
+			err := checkPathInput(searchService, "PROJ-1", steps)
+			if (err == nil) != tt.valid {
+				t.Errorf("Expected valid=%v, got %v", tt.valid, err)
+			}
+			if err != nil && getExitCode(err) != exitValidation {
+				t.Errorf("Expected a validation error, got %v", err)
+			}
+		})
+	}
+}
@@ -1,16 +1,20 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
 	"github.com/spf13/cobra"
 )
 
 var (
 	updateFields []string
+	updateFormat string
+	updateDryRun bool
 )
 
 var updateCmd = &cobra.Command{
@@ -19,13 +23,53 @@ var updateCmd = &cobra.Command{
 	Long: `Update one or more fields on an existing Jira issue.
 
 Field values can be specified using the --field flag multiple times.
-Field names can be either field IDs (like "customfield_10016") or aliases
-configured in your field mappings.
+Field names can be field IDs (like "customfield_10016"), aliases configured
+in your field mappings, or field names as shown in Jira (like "Story Points").
+
+Values are converted using the issue's edit metadata, so each field gets the
+JSON shape its type needs:
+  select lists, radio buttons      Option name (checked against the allowed values)
+  multi-selects, labels,           Comma-separated list (an empty value clears it)
+  components, versions
+  cascading selects                "Parent > Child"
+  dates / datetimes                2024-05-01 / 2024-05-01T14:30 (local time) or RFC 3339
+  numbers                          8, 2.5
+  sprint                           Sprint ID
+  time tracking                    2d, or originalEstimate=2d,remainingEstimate=1d
+A value that isn't allowed is an error listing the allowed values.
+
+Before the update is sent, fields are checked against the edit metadata: each
+must be on the issue's edit screen, have the right type and an allowed value.
+Use --dry-run to print the resolved payload and validation result without
+updating the issue.
+
+"name=value" replaces a field's value. To change multi-value fields (labels,
+components, versions, multi-selects) in place, so values added by others
+aren't lost, use an operator:
+  name+=value    Add values (comma-separated)
+  name-=value    Remove values
+  name~=value    Edit the value (e.g., timetracking~=remainingEstimate=1d)
+
+User fields (assignee, reporter, user picker custom fields) accept an account
+ID, an email address, a display name or "me"; separate several users with
+commas for multi-user fields. An empty value unassigns.
+
+Rich text fields (description, environment) are converted from Markdown to
+Jira's rich text by default; use --format plain to send the text as-is, or
+--format adf to pass a raw ADF JSON document.
 
 Examples:
   jcfa update PROJ-123 --field summary="New title"
   jcfa update PROJ-123 --field story_points=8
-  jcfa update PROJ-123 --field summary="Updated" --field description="New desc"`,
+  jcfa update PROJ-123 --field priority=High --field components=Backend,API
+  jcfa update PROJ-123 --field "Region=EMEA > Germany" --field duedate=2024-06-30
+  jcfa update PROJ-123 --field assignee=alice@example.com
+  jcfa update PROJ-123 --field assignee=me
+  jcfa update PROJ-123 --field summary="Updated" --field description="New desc"
+  jcfa update PROJ-123 --field description="$(cat description.md)"
+  jcfa update PROJ-123 --field labels+=urgent --field labels-=triage
+  jcfa update PROJ-123 --field components-=Backend --field fixVersions+=1.2
+  jcfa update PROJ-123 --field priority=High --dry-run`,
 	Args: cobra.ExactArgs(1),
 	RunE: runUpdate,
 }
@@ -33,30 +77,53 @@ Examples:
 func init() {
 	rootCmd.AddCommand(updateCmd)
 	updateCmd.Flags().StringArrayVarP(&updateFields, "field", "f", []string{}, "field to update in format name=value (can be specified multiple times)")
+	updateCmd.Flags().StringVar(&updateFormat, "format", jira.TextFormatMarkdown, "format of rich text field values: markdown, plain or adf")
+	updateCmd.Flags().BoolVar(&updateDryRun, "dry-run", false, "print the resolved payload and validation result without updating the issue")
 }
 
 func runUpdate(cmd *cobra.Command, args []string) error {
 	issueKey := args[0]
 
 	if len(updateFields) == 0 {
-		return fmt.Errorf("at least one field must be specified using --field")
+		return inputError(nil, "at least one field must be specified using --field")
+	}
+	// Report syntax errors before fetching the metadata
+	if _, err := splitFieldUpdates(updateFields); err != nil {
+		return err
+	}
+
+	// Field schemas and allowed values determine how values are converted
+	metadataService := jira.NewMetadataService(jiraClient)
+	editMeta, err := metadataService.GetEditMetadata(issueKey)
+	if err != nil {
+		return fmt.Errorf("failed to get edit metadata: %w", err)
 	}
 
-	// Parse field values
-	fields, err := parseFieldUpdates(updateFields)
+	// Parse field values and update operations
+	fields, operations, err := parseFieldUpdates(updateFields, updateFormat, editMeta.Fields)
 	if err != nil {
 		return err
 	}
 
+	// Validate against the edit metadata (cached from above)
+	validationErr := metadataService.ValidateEditData(issueKey, fields, operations)
+
+	if updateDryRun {
+		return printUpdateDryRun(issueKey, updatePayload(fields, operations), validationErr)
+	}
+	if validationErr != nil {
+		return fmt.Errorf("validation failed: %w", validationErr)
+	}
+
 	if verbose {
-		fmt.Printf("Updating issue %s with fields: %v\n", issueKey, fields)
+		fmt.Printf("Updating issue %s with fields: %v, operations: %v\n", issueKey, fields, operations)
 	}
 
 	// Create search service
 	searchService := jira.NewSearchService(jiraClient)
 
 	// Update the issue
-	if err := searchService.UpdateIssue(issueKey, fields); err != nil {
+	if err := searchService.EditIssue(issueKey, fields, operations); err != nil {
 		return fmt.Errorf("failed to update issue: %w", err)
 	}
 
@@ -65,6 +132,7 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 			"status":  "success",
 			"message": fmt.Sprintf("Successfully updated issue %s", issueKey),
 			"fields":  fields,
+			"update":  operations,
 		})
 	}
 
@@ -72,22 +140,188 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// parseFieldUpdates parses field update strings in format "name=value"
-func parseFieldUpdates(fieldStrs []string) (map[string]interface{}, error) {
-	fields := make(map[string]interface{})
+// updatePayload builds the request body of an update, as sent by
+// jira.SearchService.EditIssue
+func updatePayload(fields map[string]interface{}, operations jira.FieldOperations) map[string]interface{} {
+	payload := map[string]interface{}{}
+	if len(fields) > 0 {
+		payload["fields"] = fields
+	}
+	if len(operations) > 0 {
+		payload["update"] = operations
+	}
+	return payload
+}
+
+// printUpdateDryRun prints the payload an update would send and whether it
+// passed validation; a validation failure is returned as the command's error
+func printUpdateDryRun(issueKey string, payload map[string]interface{}, validationErr error) error {
+	if jsonOutput {
+		if err := outputJSON(map[string]interface{}{
+			"status":  "dry_run",
+			"issue":   issueKey,
+			"payload": payload,
+			"valid":   validationErr == nil,
+		}); err != nil {
+			return err
+		}
+	} else {
+		data, err := json.MarshalIndent(payload, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format payload: %w", err)
+		}
+		fmt.Printf("Would update issue %s with:\n%s\n", issueKey, data)
+	}
+
+	if validationErr != nil {
+		return fmt.Errorf("validation failed: %w", validationErr)
+	}
+	if !jsonOutput {
+		fmt.Println("✓ Validation passed")
+	}
+	return nil
+}
+
+// fieldOperators maps the operator before '=' in a field update ("+=",
+// "-=", "~=") to the Jira update operation it applies
+var fieldOperators = map[string]string{
+	"+": "add",
+	"-": "remove",
+	"~": "edit",
+}
 
+// fieldUpdate is a field update string split into its parts
+type fieldUpdate struct {
+	name      string // Field name, alias or ID
+	operation string // Update operation ("" = set the value)
+	value     string
+}
+
+// splitFieldUpdates checks the syntax of field update strings (see
+// parseFieldUpdates) and splits them, without needing field metadata
+func splitFieldUpdates(fieldStrs []string) ([]fieldUpdate, error) {
+	updates := make([]fieldUpdate, 0, len(fieldStrs))
 	for _, fieldStr := range fieldStrs {
 		// Split on first '=' only
 		parts := strings.SplitN(fieldStr, "=", 2)
 		if len(parts) != 2 {
-			return nil, fmt.Errorf("invalid field format '%s': expected name=value", fieldStr)
+			return nil, inputError(nil, "invalid field format '%s': expected name=value", fieldStr)
+		}
+
+		update := fieldUpdate{
+			name:  strings.TrimSpace(parts[0]),
+			value: strings.TrimSpace(parts[1]),
 		}
 
-		fieldName := strings.TrimSpace(parts[0])
-		fieldValue := strings.TrimSpace(parts[1])
+		// "name+=value" and friends apply an update operation
+		if n := len(update.name); n > 1 {
+			if op, ok := fieldOperators[update.name[n-1:]]; ok {
+				update.operation = op
+				update.name = strings.TrimSpace(update.name[:n-1])
+			}
+		}
+		if update.name == "" || fieldOperators[update.name] != "" {
+			return nil, inputError(nil, "invalid field format '%s': missing field name", fieldStr)
+		}
+		if update.operation != "" && update.value == "" {
+			return nil, inputError(nil, "invalid field format '%s': nothing to %s", fieldStr, update.operation)
+		}
 
-		// Resolve field name to field ID (check mappings)
+		updates = append(updates, update)
+	}
+	return updates, nil
+}
+
+// parseFieldUpdates parses field update strings in format "name=value"
+// (replace the value) or "name+=value", "name-=value", "name~=value" (add,
+// remove or edit values through update operations)
+// Parameters:
+//   - fieldStrs: Field updates in format "name=value"
+//   - textFormat: Format of rich text values (see jira.ToADF)
+//   - meta: Field metadata by field ID (see jira.CoerceFieldValue); fields
+//     without metadata fall back to parseFieldValue
+//
+// Returns the new field values and the update operations by field ID.
+func parseFieldUpdates(fieldStrs []string, textFormat string, meta map[string]models.FieldMeta) (map[string]interface{}, jira.FieldOperations, error) {
+	fields := make(map[string]interface{})
+	operations := make(jira.FieldOperations)
+	var userService *jira.UserService
+
+	updates, err := splitFieldUpdates(fieldStrs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, update := range updates {
+		fieldName, fieldValue, operation := update.name, update.value, update.operation
+
+		// Resolve field name to field ID (check mappings, then field names)
 		fieldID := resolveFieldName(fieldName)
+		fieldMeta, hasMeta := meta[fieldID]
+		if !hasMeta {
+			if id, ok := fieldIDByName(fieldName, meta); ok {
+				fieldID, fieldMeta, hasMeta = id, meta[id], true
+			}
+		}
+
+		if operation != "" {
+			if userService == nil {
+				userService = newUserService()
+			}
+			values, err := operationValues(fieldID, fieldMeta, hasMeta, fieldValue, userService)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, value := range values {
+				operations[fieldID] = append(operations[fieldID], map[string]interface{}{operation: value})
+			}
+			continue
+		}
+
+		// Rich text fields take an ADF document; an empty value clears the field
+		if jira.IsRichTextField(fieldID) || (hasMeta && jira.IsRichTextSchema(fieldMeta.Schema)) {
+			if fieldValue == "" || fieldValue == "null" {
+				fields[fieldID] = nil
+				continue
+			}
+			doc, err := textToADF(fieldValue, textFormat)
+			if err != nil {
+				return nil, nil, inputError(err, "invalid value for %s", fieldName)
+			}
+			fields[fieldID] = doc
+			continue
+		}
+
+		// Other fields are converted according to their schema
+		if hasMeta {
+			if userService == nil {
+				userService = newUserService()
+			}
+			value, err := jira.CoerceFieldValue(fieldID, fieldMeta, fieldValue, userService.ResolveUser)
+			if err != nil {
+				return nil, nil, err
+			}
+			fields[fieldID] = value
+			continue
+		}
+
+		// User fields take account IDs, looked up from emails, names or "me"
+		kind, err := userFieldKind(fieldID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if kind != "" {
+			values := []string{fieldValue}
+			if kind == "array" {
+				values = strings.Split(fieldValue, ",")
+			}
+			user, err := userFieldValue(fieldID, kind, values)
+			if err != nil {
+				return nil, nil, inputError(err, "invalid value for %s", fieldName)
+			}
+			fields[fieldID] = user
+			continue
+		}
 
 		// Parse value based on field type
 		parsedValue := parseFieldValue(fieldID, fieldValue)
@@ -95,7 +329,32 @@ func parseFieldUpdates(fieldStrs []string) (map[string]interface{}, error) {
 		fields[fieldID] = parsedValue
 	}
 
-	return fields, nil
+	return fields, operations, nil
+}
+
+// operationValues converts the comma-separated values of an update operation
+// to the values Jira expects: each item of a multi-value field (e.g.,
+// {"name": "Backend"} for components), or the single value of other fields
+func operationValues(fieldID string, fieldMeta models.FieldMeta, hasMeta bool, value string, userService *jira.UserService) ([]interface{}, error) {
+	if !hasMeta {
+		// Rejected by validation, but shown as given in dry runs
+		var values []interface{}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		return values, nil
+	}
+
+	coerced, err := jira.CoerceFieldValue(fieldID, fieldMeta, value, userService.ResolveUser)
+	if err != nil {
+		return nil, err
+	}
+	if items, ok := coerced.([]interface{}); ok {
+		return items, nil
+	}
+	return []interface{}{coerced}, nil
 }
 
 // resolveFieldName resolves a field name or alias to a field ID
@@ -116,12 +375,23 @@ func resolveFieldName(name string) string {
 	return name
 }
 
+// fieldIDByName finds a field by its display name (e.g., "Story Points"),
+// ignoring case
+func fieldIDByName(name string, meta map[string]models.FieldMeta) (string, bool) {
+	for fieldID, fieldMeta := range meta {
+		if strings.EqualFold(fieldMeta.Name, name) {
+			return fieldID, true
+		}
+	}
+	return "", false
+}
+
 // isStandardField checks if a field name is a standard Jira field
 func isStandardField(name string) bool {
 	standardFields := []string{
 		"summary", "description", "assignee", "reporter", "priority",
 		"labels", "status", "issuetype", "project", "components",
-		"fixVersions", "affectedVersions", "duedate", "parent",
+		"fixVersions", "affectedVersions", "duedate", "parent", "environment",
 	}
 
 	for _, field := range standardFields {
@@ -133,16 +403,11 @@ func isStandardField(name string) bool {
 	return false
 }
 
-// parseFieldValue parses a field value string into the appropriate type
+// parseFieldValue parses a field value string into the appropriate type,
+// guessing from the field ID when there is no field metadata
 func parseFieldValue(fieldID, value string) interface{} {
 	// Handle special fields that need object format
 	switch fieldID {
-	case "assignee":
-		if value == "null" || value == "" {
-			return nil
-		}
-		return map[string]interface{}{"accountId": value}
-
 	case "priority":
 		return map[string]interface{}{"name": value}
 
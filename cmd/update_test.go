@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+// updateTestMeta is edit metadata for parseFieldUpdates tests
+var updateTestMeta = map[string]models.FieldMeta{
+	"summary": {Name: "Summary", Schema: models.FieldSchema{Type: "string", System: "summary"}},
+	"labels":  {Name: "Labels", Schema: models.FieldSchema{Type: "array", Items: "string", System: "labels"}},
+	"components": {
+		Name:   "Components",
+		Schema: models.FieldSchema{Type: "array", Items: "component", System: "components"},
+		AllowedValues: []interface{}{
+			map[string]interface{}{"id": "10", "name": "Backend"},
+			map[string]interface{}{"id": "11", "name": "API"},
+		},
+	},
+	"fixVersions": {
+		Name:   "Fix versions",
+		Schema: models.FieldSchema{Type: "array", Items: "version", System: "fixVersions"},
+		AllowedValues: []interface{}{
+			map[string]interface{}{"id": "20", "name": "1.2"},
+		},
+	},
+	"timetracking": {Name: "Time tracking", Schema: models.FieldSchema{Type: "timetracking", System: "timetracking"}},
+}
+
+func TestParseFieldUpdates(t *testing.T) {
+	tests := []struct {
+		name       string
+		fieldStrs  []string
+		fields     string
+		operations string
+	}{
+		{
+			name:       "set",
+			fieldStrs:  []string{"summary=New title", "Labels=a,b"},
+			fields:     `{"labels":["a","b"],"summary":"New title"}`,
+			operations: `{}`,
+		},
+		{
+			name:       "add and remove",
+			fieldStrs:  []string{"labels+=urgent,blocked", "labels-=triage", "components-=backend", "fixVersions+=1.2"},
+			fields:     `{}`,
+			operations: `{"components":[{"remove":{"name":"Backend"}}],"fixVersions":[{"add":{"name":"1.2"}}],"labels":[{"add":"urgent"},{"add":"blocked"},{"remove":"triage"}]}`,
+		},
+		{
+			name:       "edit",
+			fieldStrs:  []string{"timetracking~=remainingEstimate=1d"},
+			fields:     `{}`,
+			operations: `{"timetracking":[{"edit":{"remainingEstimate":"1d"}}]}`,
+		},
+		{
+			name:       "operators only apply before =",
+			fieldStrs:  []string{"summary=a+=b"},
+			fields:     `{"summary":"a+=b"}`,
+			operations: `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, operations, err := parseFieldUpdates(tt.fieldStrs, jira.TextFormatMarkdown, updateTestMeta)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got, _ := json.Marshal(fields); string(got) != tt.fields {
+				t.Errorf("Expected fields %s, got %s", tt.fields, got)
+			}
+			if got, _ := json.Marshal(operations); string(got) != tt.operations {
+				t.Errorf("Expected operations %s, got %s", tt.operations, got)
+			}
+		})
+	}
+}
+
+func TestParseFieldUpdates_Errors(t *testing.T) {
+	tests := []string{
+		"summary",
+		"+=x",
+		"labels+=",
+		"components+=Frontend",
+	}
+
+	for _, fieldStr := range tests {
+		t.Run(fieldStr, func(t *testing.T) {
+			if _, _, err := parseFieldUpdates([]string{fieldStr}, jira.TextFormatMarkdown, updateTestMeta); err == nil {
+				t.Errorf("Expected an error for %q", fieldStr)
+			}
+		})
+	}
+}
+
+func TestRunUpdate_SyntaxBeforeMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request for a malformed field, got %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	defer func(saved *client.Client, fields []string) { jiraClient, updateFields = saved, fields }(jiraClient, updateFields)
+	jiraClient = &client.Client{BaseURL: server.URL, HTTPClient: resty.New().SetBaseURL(server.URL)}
+	updateFields = []string{"summary=New title", "priority"}
+
+	if err := runUpdate(updateCmd, []string{"PROJ-1"}); getExitCode(err) != exitValidation {
+		t.Errorf("Expected a validation error, got %v", err)
+	}
+}
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/config"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	userSearchLimit int
+
+	// customUserFields maps custom field IDs holding users to their schema
+	// type ("user" or "array"); loaded from the field list on first use
+	customUserFields map[string]string
+)
+
+// userCmd is the parent command for user lookups
+var userCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Look up Jira users",
+	Long: `Look up Jira users and their account IDs.
+
+Jira Cloud identifies users by account ID. Commands that take a user (the
+assignee and reporter fields, user custom fields, list --assignee) also accept
+an email address, a display name or "me", and look the account ID up the same
+way as 'jcfa user get'. Lookups are cached in ~/.jcfa/users-<domain>.json.
+
+Subcommands:
+  search    - Find users by name or email
+  get       - Show a user by email, display name, account ID or "me"
+  me        - Show the authenticated user`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var userSearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Find users by name or email",
+	Long: `Find active users whose display name or email address matches the query.
+
+Email addresses are only shown for users whose privacy settings allow it.
+
+Examples:
+  jcfa user search alice
+  jcfa user search "Alice Smith" --json
+  jcfa user search alice --output tsv --columns accountId,displayName`,
+	Args: cobra.ExactArgs(1),
+	RunE: runUserSearch,
+}
+
+var userGetCmd = &cobra.Command{
+	Use:   "get <user>",
+	Short: "Show a user by email, display name, account ID or \"me\"",
+	Long: `Show a user, resolving an email address, display name or "me" to the
+account ID Jira needs.
+
+A name matching several users is an error listing the candidates.
+
+Examples:
+  jcfa user get alice@example.com
+  jcfa user get "Alice Smith"
+  jcfa user get 5b10a2844c20165700ede21a --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runUserGet,
+}
+
+var userMeCmd = &cobra.Command{
+	Use:   "me",
+	Short: "Show the authenticated user",
+	Long: `Show the user jcfa is authenticated as.
+
+Examples:
+  jcfa user me
+  jcfa user me --json`,
+	Args: cobra.NoArgs,
+	RunE: runUserMe,
+}
+
+func init() {
+	rootCmd.AddCommand(userCmd)
+	userCmd.AddCommand(userSearchCmd)
+	userCmd.AddCommand(userGetCmd)
+	userCmd.AddCommand(userMeCmd)
+
+	userSearchCmd.Flags().IntVarP(&userSearchLimit, "limit", "l", 20, "maximum number of users to return")
+}
+
+func runUserSearch(cmd *cobra.Command, args []string) error {
+	users, err := newUserService().SearchUsers(args[0], userSearchLimit)
+	if err != nil {
+		return fmt.Errorf("failed to search users: %w", err)
+	}
+
+	// Apps and deactivated accounts can't be assigned or mentioned
+	active := make([]models.User, 0, len(users))
+	for _, user := range users {
+		if user.Active && (user.AccountType == "" || user.AccountType == "atlassian") {
+			active = append(active, user)
+		}
+	}
+
+	if isJSONFormat() {
+		return outputJSON(active)
+	}
+	if len(active) == 0 && useDetailView() {
+		fmt.Printf("No users match '%s'\n", args[0])
+		return nil
+	}
+	return outputList(active, userColumns)
+}
+
+func runUserGet(cmd *cobra.Command, args []string) error {
+	userService := newUserService()
+
+	resolved, err := userService.ResolveUser("user", args[0])
+	if err != nil {
+		return err
+	}
+
+	user, err := userService.GetUser(resolved.AccountID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return printUser(user)
+}
+
+func runUserMe(cmd *cobra.Command, args []string) error {
+	user, err := newUserService().Myself()
+	if err != nil {
+		return err
+	}
+
+	return printUser(user)
+}
+
+// newUserService creates a UserService that caches lookups on disk for the
+// configured site (in memory only without a config directory)
+func newUserService() *jira.UserService {
+	service := jira.NewUserService(jiraClient)
+
+	cachePath := ""
+	if dir, err := config.GetConfigDir(); err == nil && cfg != nil {
+		cachePath = jira.UserCachePath(dir, cfg.Domain)
+	}
+	service.Cache = jira.NewUserCache(cachePath)
+	return service
+}
+
+// printUser prints a user in the selected output format
+func printUser(user *models.User) error {
+	if !useDetailView() {
+		return outputItem(user, userColumns)
+	}
+
+	fmt.Println(user.DisplayName)
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Printf("Account ID: %s\n", user.AccountID)
+	if user.EmailAddress != "" {
+		fmt.Printf("Email: %s\n", user.EmailAddress)
+	}
+	if user.AccountType != "" {
+		fmt.Printf("Type: %s\n", user.AccountType)
+	}
+	fmt.Printf("Active: %t\n", user.Active)
+	if user.TimeZone != "" {
+		fmt.Printf("Time zone: %s\n", user.TimeZone)
+	}
+	return nil
+}
+
+// userFieldKind reports whether a field holds users: "user" for a single
+// user (assignee, reporter, user pickers), "array" for multi-user pickers,
+// or "" for other fields
+func userFieldKind(fieldID string) (string, error) {
+	switch fieldID {
+	case "assignee", "reporter":
+		return "user", nil
+	}
+	if !strings.HasPrefix(fieldID, "customfield_") {
+		return "", nil
+	}
+
+	if customUserFields == nil {
+		fields, err := jira.NewFieldService(jiraClient).ListFields("")
+		if err != nil {
+			return "", fmt.Errorf("failed to look up field types: %w", err)
+		}
+		customUserFields = make(map[string]string)
+		for _, field := range fields {
+			if field.Schema.Type == "user" || (field.Schema.Type == "array" && field.Schema.Items == "user") {
+				customUserFields[field.ID] = field.Schema.Type
+			}
+		}
+	}
+	return customUserFields[fieldID], nil
+}
+
+// userFieldValue resolves user references (email, display name, "me" or
+// account ID) to the value Jira expects for a user field
+// Parameters:
+//   - fieldID: The field being set, used in errors
+//   - kind: "user" or "array" (see userFieldKind)
+//   - values: The user references; none (or "null") clears the field
+func userFieldValue(fieldID, kind string, values []string) (interface{}, error) {
+	var refs []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" && value != "null" {
+			refs = append(refs, value)
+		}
+	}
+
+	if kind != "array" {
+		if len(refs) == 0 {
+			return nil, nil
+		}
+		if len(refs) > 1 {
+			return nil, fmt.Errorf("%s takes a single user, got %d", fieldID, len(refs))
+		}
+	}
+
+	userService := newUserService()
+	users := make([]interface{}, 0, len(refs))
+	for _, ref := range refs {
+		user, err := userService.ResolveUser(fieldID, ref)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, map[string]interface{}{"accountId": user.AccountID})
+	}
+
+	if kind != "array" {
+		return users[0], nil
+	}
+	return users, nil
+}
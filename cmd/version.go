@@ -22,7 +22,11 @@ var versionCmd = &cobra.Command{
 	Long:  `Print the version number, build date, and git commit of jcfa (Jira CLI for Agents).`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if jsonOutput {
-			fmt.Printf(`{"version":"%s","buildDate":"%s","gitCommit":"%s"}`+"\n", Version, BuildDate, GitCommit)
+			outputJSON(map[string]string{
+				"version":   Version,
+				"buildDate": BuildDate,
+				"gitCommit": GitCommit,
+			})
 		} else {
 			fmt.Printf("jcfa version %s\n", Version)
 			fmt.Printf("Build date: %s\n", BuildDate)
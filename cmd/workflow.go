@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/config"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var workflowCmd = &cobra.Command{
+	Use:   "workflow <project-key> <issue-type>",
+	Short: "Show the statuses and transitions of an issue type's workflow",
+	Long: `Show the workflow an issue type uses in a project: its statuses, with their
+categories, and the transitions between them. Global transitions can be
+taken from any status.
+
+This is the workflow 'jcfa transition --path' plans with.
+
+Examples:
+  jcfa workflow PROJ Story
+  jcfa workflow PROJ Bug --json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runWorkflow,
+}
+
+func init() {
+	rootCmd.AddCommand(workflowCmd)
+}
+
+func runWorkflow(cmd *cobra.Command, args []string) error {
+	workflow, err := newWorkflowService().GetWorkflow(args[0], args[1])
+	if err != nil {
+		return fmt.Errorf("failed to get workflow: %w", err)
+	}
+
+	if jsonOutput {
+		return outputJSON(workflow)
+	}
+
+	fmt.Printf("Workflow: %s (%s)\n\n", workflow.Name, workflow.IssueType)
+	fmt.Println("Statuses:")
+	for _, status := range workflow.Statuses {
+		if status.StatusCategory.Name != "" {
+			fmt.Printf("  %s [%s]\n", status.Name, status.StatusCategory.Name)
+		} else {
+			fmt.Printf("  %s\n", status.Name)
+		}
+	}
+
+	fmt.Println("\nTransitions:")
+	for _, t := range workflow.Transitions {
+		if t.Type == "initial" {
+			continue
+		}
+		fmt.Printf("  %s: %s → %s\n", t.Name, workflowTransitionFrom(workflow, t), jira.WorkflowStatusName(workflow, t.To))
+	}
+	return nil
+}
+
+// newWorkflowService creates a WorkflowService that caches workflows on disk
+// for the configured site (in memory only without a config directory)
+func newWorkflowService() *jira.WorkflowService {
+	service := jira.NewWorkflowService(jiraClient)
+
+	if dir, err := config.GetConfigDir(); err == nil && cfg != nil {
+		service.Cache = jira.NewWorkflowCache(jira.WorkflowCachePath(dir, cfg.Domain))
+	}
+	return service
+}
+
+// workflowTransitionFrom describes the statuses a transition starts from
+func workflowTransitionFrom(workflow *models.Workflow, t models.WorkflowTransition) string {
+	if len(t.From) == 0 {
+		return "(any)"
+	}
+	names := make([]string, len(t.From))
+	for i, id := range t.From {
+		names[i] = jira.WorkflowStatusName(workflow, id)
+	}
+	return strings.Join(names, ", ")
+}
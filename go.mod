@@ -6,6 +6,8 @@ require (
 	github.com/go-resty/resty/v2 v2.11.0
 	github.com/schollz/progressbar/v3 v3.19.0
 	github.com/spf13/cobra v1.8.0
+	golang.org/x/crypto v0.32.0
+	golang.org/x/sys v0.29.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -14,7 +16,6 @@ require (
 	github.com/mitchellh/colorstring v0.0.0-20190213212951-d06e56a500db // indirect
 	github.com/rivo/uniseg v0.4.7 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
-	golang.org/x/net v0.20.0 // indirect
-	golang.org/x/sys v0.29.0 // indirect
+	golang.org/x/net v0.21.0 // indirect
 	golang.org/x/term v0.28.0 // indirect
 )
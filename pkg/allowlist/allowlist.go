@@ -28,12 +28,22 @@ var ReadOnlyCommands = []string{
 	"comments get",
 	"link list",
 	"link types",
+	"profile list",
+	"user search",
+	"user get",
+	"user me",
+	"workflow",
+	"board list",
+	"sprint list",
+	"sprint active",
+	"backlog",
 }
 
 // WriteCommands are commands that modify data
 var WriteCommands = []string{
 	"create",
 	"update",
+	"edit",
 	"transition",
 	"comment",
 	"comments add",
@@ -41,6 +51,14 @@ var WriteCommands = []string{
 	"comments delete",
 	"batch",
 	"batch create",
+	"bulk",
+	"bulk update",
+	"bulk transition",
+	"sprint create",
+	"sprint start",
+	"sprint close",
+	"sprint add",
+	"rank",
 	"link",
 	"link create",
 	"link delete",
@@ -48,6 +66,8 @@ var WriteCommands = []string{
 	"attachment delete",
 	"configure",
 	"template",
+	"profile use",
+	"profile delete",
 }
 
 // Checker validates commands against the allowlist
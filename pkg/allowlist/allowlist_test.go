@@ -205,16 +205,27 @@ func TestIsAllowed_ReadOnlyMode(t *testing.T) {
 		{"link list", true},
 		{"link types", true},
 		{"attachment list", true},
+		{"user search", true},
+		{"workflow", true},
+		{"board list", true},
+		{"sprint list", true},
+		{"backlog", true},
 
 		// Write commands should be blocked
 		{"create", false},
 		{"update", false},
+		{"edit", false},
 		{"delete", false},
 		{"transition", false},
 		{"comment", false},
 		{"comments add", false},
 		{"comments delete", false},
 		{"batch create", false},
+		{"bulk update", false},
+		{"bulk transition", false},
+		{"sprint create", false},
+		{"sprint add", false},
+		{"rank", false},
 		{"link create", false},
 		{"link delete", false},
 		{"attachment upload", false},
@@ -784,6 +795,15 @@ func TestReadOnlyCommandsAreValid(t *testing.T) {
 		"comments get":    true,
 		"link list":       true,
 		"link types":      true,
+		"profile list":    true,
+		"user search":     true,
+		"user get":        true,
+		"user me":         true,
+		"workflow":        true,
+		"board list":      true,
+		"sprint list":     true,
+		"sprint active":   true,
+		"backlog":         true,
 	}
 
 	for _, cmd := range ReadOnlyCommands {
@@ -802,6 +822,7 @@ func TestWriteCommandsAreValid(t *testing.T) {
 	expected := map[string]bool{
 		"create":            true,
 		"update":            true,
+		"edit":              true,
 		"transition":        true,
 		"comment":           true,
 		"comments add":      true,
@@ -809,6 +830,14 @@ func TestWriteCommandsAreValid(t *testing.T) {
 		"comments delete":   true,
 		"batch":             true,
 		"batch create":      true,
+		"bulk":              true,
+		"bulk update":       true,
+		"bulk transition":   true,
+		"sprint create":     true,
+		"sprint start":      true,
+		"sprint close":      true,
+		"sprint add":        true,
+		"rank":              true,
 		"link":              true,
 		"link create":       true,
 		"link delete":       true,
@@ -816,6 +845,8 @@ func TestWriteCommandsAreValid(t *testing.T) {
 		"attachment delete": true,
 		"configure":         true,
 		"template":          true,
+		"profile use":       true,
+		"profile delete":    true,
 	}
 
 	for _, cmd := range WriteCommands {
@@ -22,24 +22,13 @@ func (c *Client) ValidateCredentials() (*models.User, error) {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, formatError(resp.StatusCode(), &errorResp)
+		return nil, formatError(resp.StatusCode(), resp.Header(), &errorResp)
 	}
 
 	return &user, nil
 }
 
-// formatError formats an error response from the Jira API
-func formatError(statusCode int, errorResp *models.ErrorResponse) error {
-	if len(errorResp.ErrorMessages) > 0 {
-		return fmt.Errorf("API error (HTTP %d): %s", statusCode, errorResp.ErrorMessages[0])
-	}
-
-	if len(errorResp.Errors) > 0 {
-		// Get first error from map
-		for field, msg := range errorResp.Errors {
-			return fmt.Errorf("API error (HTTP %d): %s: %s", statusCode, field, msg)
-		}
-	}
-
-	return fmt.Errorf("API error (HTTP %d): unknown error", statusCode)
+// formatError converts an error response from the Jira API into a typed error
+func formatError(statusCode int, header http.Header, errorResp *models.ErrorResponse) error {
+	return NewAPIError(statusCode, header, errorResp)
 }
@@ -3,6 +3,9 @@ package client
 import (
 	"encoding/base64"
 	"fmt"
+	"io"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -15,14 +18,28 @@ type Client struct {
 	Email      string
 	APIToken   string
 	HTTPClient *resty.Client
+
+	// AgileBaseURL is the base URL of the Jira Software (Agile) API
+	// ("" = derived from BaseURL)
+	AgileBaseURL string
+
+	// Verbose receives retry and API quota messages (nil = silent)
+	Verbose io.Writer
+
+	// Limiter throttles outgoing requests (nil = unlimited)
+	Limiter *Limiter
+
+	mu        sync.Mutex
+	rateLimit *RateLimitStatus
 }
 
 // New creates a new Jira API client from config
 func New(cfg *config.Config) *Client {
 	client := &Client{
-		BaseURL:  cfg.GetBaseURL(),
-		Email:    cfg.Email,
-		APIToken: cfg.APIToken,
+		BaseURL:      cfg.GetBaseURL(),
+		AgileBaseURL: cfg.GetAgileBaseURL(),
+		Email:        cfg.Email,
+		APIToken:     cfg.APIToken,
 	}
 
 	// Initialize resty client
@@ -31,13 +48,25 @@ func New(cfg *config.Config) *Client {
 		SetHeader("Content-Type", "application/json").
 		SetHeader("Accept", "application/json").
 		SetTimeout(30 * time.Second).
-		SetRetryCount(3).
-		SetRetryWaitTime(1 * time.Second).
-		SetRetryMaxWaitTime(4 * time.Second).
-		AddRetryCondition(func(r *resty.Response, err error) bool {
-			// Retry on rate limits (429) and server errors (5xx)
-			return r.StatusCode() == 429 || r.StatusCode() >= 500
-		})
+		// Retries follow retryCondition and wait per retryWait (Retry-After or
+		// jittered backoff); MaxRetryAfter caps any single wait
+		SetRetryCount(MaxRetries).
+		SetRetryWaitTime(0).
+		SetRetryMaxWaitTime(MaxRetryAfter).
+		SetRetryAfter(client.retryWait).
+		AddRetryCondition(retryCondition).
+		OnBeforeRequest(client.waitForLimiter).
+		OnAfterResponse(client.recordRateLimit)
+
+	if cfg.RequestsPerSecond > 0 {
+		// Share the bucket with other jcfa processes talking to the same
+		// site; without a config directory, only this process is limited
+		statePath := ""
+		if dir, err := config.GetConfigDir(); err == nil {
+			statePath = LimiterStatePath(dir, cfg.Domain)
+		}
+		client.Limiter = NewLimiter(cfg.RequestsPerSecond, cfg.GetBurst(), statePath)
+	}
 
 	// Set authentication header
 	authHeader := client.getAuthHeader()
@@ -53,6 +82,42 @@ func (c *Client) getAuthHeader() string {
 	return fmt.Sprintf("Basic %s", encoded)
 }
 
+// logf writes a verbose message if Verbose is set
+func (c *Client) logf(format string, args ...interface{}) {
+	if c.Verbose != nil {
+		fmt.Fprintf(c.Verbose, format, args...)
+	}
+}
+
+// waitForLimiter delays a request (including each retry) until the limiter
+// allows it
+func (c *Client) waitForLimiter(_ *resty.Client, r *resty.Request) error {
+	if c.Limiter == nil {
+		return nil
+	}
+
+	waited, err := c.Limiter.Wait(r.Context())
+	if err != nil {
+		return fmt.Errorf("waiting for rate limiter: %w", err)
+	}
+	if waited > 0 {
+		c.logf("%s %s: rate limiter delayed request by %s\n", r.Method, r.URL, waited.Round(time.Millisecond))
+	}
+	return nil
+}
+
+// AgileURL returns the full URL of a Jira Software (Agile) API path, e.g.
+// AgileURL("/board"). Requests to it go through HTTPClient like any other,
+// sharing its authentication, retries and rate limiter; resty ignores the
+// base URL for absolute URLs.
+func (c *Client) AgileURL(path string) string {
+	base := c.AgileBaseURL
+	if base == "" {
+		base = strings.TrimSuffix(c.BaseURL, "/rest/api/3") + "/rest/agile/1.0"
+	}
+	return base + path
+}
+
 // GetRequest creates a new GET request
 func (c *Client) GetRequest() *resty.Request {
 	return c.HTTPClient.R()
@@ -0,0 +1,23 @@
+package client
+
+import "testing"
+
+func TestAgileURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		client   *Client
+		expected string
+	}{
+		{"configured", &Client{BaseURL: "https://x.atlassian.net/rest/api/3", AgileBaseURL: "https://x.atlassian.net/rest/agile/1.0"}, "https://x.atlassian.net/rest/agile/1.0/board"},
+		{"derived from the API base URL", &Client{BaseURL: "https://x.atlassian.net/rest/api/3"}, "https://x.atlassian.net/rest/agile/1.0/board"},
+		{"test server", &Client{BaseURL: "http://127.0.0.1:1234"}, "http://127.0.0.1:1234/rest/agile/1.0/board"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.client.AgileURL("/board"); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
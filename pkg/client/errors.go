@@ -0,0 +1,173 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+// APIError is an error response from the Jira API.
+// More specific errors (AuthError, NotFoundError, ...) embed it; use errors.As
+// to check for them.
+type APIError struct {
+	StatusCode  int               // HTTP status code (0 for errors detected before a request)
+	Message     string            // Human-readable message
+	FieldErrors map[string]string // Per-field error messages, keyed by field ID
+	Hints       []string          // Suggestions for fixing the error
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// AuthError is returned when the credentials are rejected (HTTP 401)
+type AuthError struct{ APIError }
+
+// PermissionError is returned when the user lacks permission (HTTP 403)
+type PermissionError struct{ APIError }
+
+// NotFoundError is returned when an issue, comment or other resource doesn't exist (HTTP 404)
+type NotFoundError struct{ APIError }
+
+// ValidationError is returned when request data is rejected (HTTP 400), either
+// by Jira or by client-side validation. FieldErrors holds per-field details and
+// AllowedValues the valid choices for a field, when known.
+type ValidationError struct {
+	APIError
+	AllowedValues map[string][]string // Valid values keyed by field ID
+}
+
+// RateLimitError is returned when Jira rate limits the request (HTTP 429)
+type RateLimitError struct {
+	APIError
+	RetryAfter time.Duration // How long to wait before retrying (0 if unknown)
+}
+
+// ConfigError is returned when the CLI configuration is missing or invalid
+type ConfigError struct {
+	Err error
+}
+
+// Error implements the error interface
+func (e *ConfigError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// NewAPIError creates a typed error from a Jira error response
+// Parameters:
+//   - statusCode: HTTP status code of the response
+//   - header: Response headers (used for Retry-After; may be nil)
+//   - errorResp: Decoded error body (may be nil)
+func NewAPIError(statusCode int, header http.Header, errorResp *models.ErrorResponse) error {
+	var messages []string
+	var fieldErrors map[string]string
+
+	if errorResp != nil {
+		messages = append(messages, errorResp.ErrorMessages...)
+
+		if len(errorResp.Errors) > 0 {
+			fieldErrors = make(map[string]string, len(errorResp.Errors))
+			fields := make([]string, 0, len(errorResp.Errors))
+			for field, msg := range errorResp.Errors {
+				fieldErrors[field] = msg
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			for _, field := range fields {
+				messages = append(messages, fmt.Sprintf("%s: %s", field, errorResp.Errors[field]))
+			}
+		}
+	}
+
+	detail := strings.Join(messages, "; ")
+	if detail == "" {
+		detail = http.StatusText(statusCode)
+		if detail == "" {
+			detail = "unknown error"
+		}
+	}
+
+	return newError(APIError{
+		StatusCode:  statusCode,
+		Message:     fmt.Sprintf("API error (HTTP %d): %s", statusCode, detail),
+		FieldErrors: fieldErrors,
+	}, header)
+}
+
+// NewError creates a typed error for an HTTP status with a custom message,
+// e.g. NewError(404, "issue 'PROJ-1' not found")
+func NewError(statusCode int, message string) error {
+	return newError(APIError{StatusCode: statusCode, Message: message}, nil)
+}
+
+// NewValidationError creates a validation error for data rejected before it was sent
+// Parameters:
+//   - message: Human-readable message
+//   - fieldErrors: Per-field error messages keyed by field ID (may be nil)
+func NewValidationError(message string, fieldErrors map[string]string) *ValidationError {
+	return &ValidationError{APIError: APIError{Message: message, FieldErrors: fieldErrors}}
+}
+
+// NewAllowedValuesError creates a validation error for a field whose value
+// isn't one of the allowed values
+// Parameters:
+//   - field: Field ID (e.g., "priority", or "status" for transitions)
+//   - message: Human-readable message
+//   - allowed: The valid values
+func NewAllowedValuesError(field, message string, allowed []string) *ValidationError {
+	err := NewValidationError(message, map[string]string{field: message})
+	err.AllowedValues = map[string][]string{field: allowed}
+	if len(allowed) > 0 {
+		err.Hints = []string{fmt.Sprintf("Use one of: %s", strings.Join(allowed, ", "))}
+	}
+	return err
+}
+
+// newError wraps base in the error type matching its status code
+func newError(base APIError, header http.Header) error {
+	switch base.StatusCode {
+	case http.StatusUnauthorized:
+		return &AuthError{base}
+	case http.StatusForbidden:
+		return &PermissionError{base}
+	case http.StatusNotFound:
+		return &NotFoundError{base}
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return &ValidationError{APIError: base}
+	case http.StatusTooManyRequests:
+		return &RateLimitError{APIError: base, RetryAfter: ParseRetryAfter(header.Get("Retry-After"))}
+	default:
+		return &base
+	}
+}
+
+// apiErrorer is implemented by APIError and every error type embedding it
+type apiErrorer interface {
+	apiError() *APIError
+}
+
+// apiError returns the embedded APIError
+func (e *APIError) apiError() *APIError {
+	return e
+}
+
+// AsAPIError finds the first API error (of any type) in err's chain and returns
+// its status code, message and field errors
+func AsAPIError(err error) (*APIError, bool) {
+	var target apiErrorer
+	if errors.As(err, &target) {
+		return target.apiError(), true
+	}
+	return nil, false
+}
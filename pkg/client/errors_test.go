@@ -0,0 +1,79 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+func TestNewAPIError_Types(t *testing.T) {
+	tests := []struct {
+		status int
+		check  func(error) bool
+	}{
+		{http.StatusUnauthorized, func(err error) bool { var e *AuthError; return errors.As(err, &e) }},
+		{http.StatusForbidden, func(err error) bool { var e *PermissionError; return errors.As(err, &e) }},
+		{http.StatusNotFound, func(err error) bool { var e *NotFoundError; return errors.As(err, &e) }},
+		{http.StatusBadRequest, func(err error) bool { var e *ValidationError; return errors.As(err, &e) }},
+		{http.StatusTooManyRequests, func(err error) bool { var e *RateLimitError; return errors.As(err, &e) }},
+		{http.StatusInternalServerError, func(err error) bool { var e *APIError; return errors.As(err, &e) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", NewAPIError(tt.status, nil, nil))
+			if !tt.check(err) {
+				t.Errorf("Unexpected error type %T for HTTP %d", errors.Unwrap(err), tt.status)
+			}
+
+			apiErr, ok := AsAPIError(err)
+			if !ok || apiErr.StatusCode != tt.status {
+				t.Errorf("AsAPIError() = %v, %v; expected status %d", apiErr, ok, tt.status)
+			}
+		})
+	}
+}
+
+func TestNewAPIError_FieldErrors(t *testing.T) {
+	err := NewAPIError(http.StatusBadRequest, nil, &models.ErrorResponse{
+		ErrorMessages: []string{"Invalid request"},
+		Errors: map[string]string{
+			"summary":  "Summary is required",
+			"priority": "Priority is invalid",
+		},
+	})
+
+	expected := "API error (HTTP 400): Invalid request; priority: Priority is invalid; summary: Summary is required"
+	if err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %T", err)
+	}
+	if validationErr.FieldErrors["summary"] != "Summary is required" {
+		t.Errorf("Expected summary field error, got %v", validationErr.FieldErrors)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got := ParseRetryAfter("120"); got != 2*time.Minute {
+		t.Errorf("Expected 2m, got %v", got)
+	}
+	if got := ParseRetryAfter(""); got != 0 {
+		t.Errorf("Expected 0 for empty header, got %v", got)
+	}
+	if got := ParseRetryAfter("soon"); got != 0 {
+		t.Errorf("Expected 0 for invalid header, got %v", got)
+	}
+
+	date := time.Now().Add(90 * time.Second).UTC().Format(http.TimeFormat)
+	if got := ParseRetryAfter(date); got < 80*time.Second || got > 90*time.Second {
+		t.Errorf("Expected about 90s for HTTP date, got %v", got)
+	}
+}
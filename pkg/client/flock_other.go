@@ -0,0 +1,19 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package client
+
+import (
+	"errors"
+	"os"
+)
+
+// lockFile reports that file locking isn't supported; the limiter then only
+// limits the current process
+func lockFile(f *os.File) error {
+	return errors.New("file locking not supported on this platform")
+}
+
+// unlockFile is a no-op where file locking isn't supported
+func unlockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RateLimitStatus is the API quota reported by Jira Cloud's X-RateLimit-* headers
+type RateLimitStatus struct {
+	Limit     int       // Requests allowed in the current window (X-RateLimit-Limit)
+	Remaining int       // Requests left in the current window (X-RateLimit-Remaining)
+	Reset     time.Time // When the window resets (X-RateLimit-Reset), zero if unknown
+	NearLimit bool      // Jira reports less than 20% of the quota left (X-RateLimit-NearLimit)
+	Reason    string    // Which limit applied to a 429 (RateLimit-Reason)
+}
+
+// String formats the status for verbose output
+func (s RateLimitStatus) String() string {
+	var parts []string
+	if s.Limit > 0 {
+		parts = append(parts, strconv.Itoa(s.Remaining)+"/"+strconv.Itoa(s.Limit)+" requests remaining")
+	}
+	if !s.Reset.IsZero() {
+		parts = append(parts, "resets at "+s.Reset.Local().Format(time.Kitchen))
+	}
+	if s.NearLimit {
+		parts = append(parts, "near limit")
+	}
+	if s.Reason != "" {
+		parts = append(parts, "reason: "+s.Reason)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// parseRateLimitHeaders reads the quota headers from a response.
+// Returns false if the response has none.
+func parseRateLimitHeaders(header http.Header) (RateLimitStatus, bool) {
+	var status RateLimitStatus
+	found := false
+
+	if v := header.Get("X-RateLimit-Limit"); v != "" {
+		status.Limit, _ = strconv.Atoi(v)
+		found = true
+	}
+	if v := header.Get("X-RateLimit-Remaining"); v != "" {
+		status.Remaining, _ = strconv.Atoi(v)
+		found = true
+	}
+	if v := header.Get("X-RateLimit-Reset"); v != "" {
+		// Jira sends an ISO 8601 timestamp
+		if reset, err := time.Parse(time.RFC3339, v); err == nil {
+			status.Reset = reset
+		}
+		found = true
+	}
+	if v := header.Get("X-RateLimit-NearLimit"); v != "" {
+		status.NearLimit, _ = strconv.ParseBool(v)
+		found = true
+	}
+	if v := header.Get("RateLimit-Reason"); v != "" {
+		status.Reason = v
+		found = true
+	}
+
+	return status, found
+}
+
+// recordRateLimit stores the quota from a response and reports it in verbose mode
+func (c *Client) recordRateLimit(_ *resty.Client, resp *resty.Response) error {
+	status, ok := parseRateLimitHeaders(resp.Header())
+	if !ok {
+		return nil
+	}
+
+	c.mu.Lock()
+	c.rateLimit = &status
+	c.mu.Unlock()
+
+	c.logf("API quota: %s\n", status)
+	return nil
+}
+
+// RateLimit returns the quota from the most recent response that reported
+// one. Returns false if Jira hasn't sent rate limit headers.
+func (c *Client) RateLimit() (RateLimitStatus, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.rateLimit == nil {
+		return RateLimitStatus{}, false
+	}
+	return *c.rateLimit, true
+}
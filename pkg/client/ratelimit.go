@@ -0,0 +1,177 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// Limiter is a token-bucket rate limiter for API requests. It is safe for
+// concurrent use; with a state file, the bucket is also shared by every jcfa
+// process on the host that uses the same file.
+type Limiter struct {
+	rate      float64 // Tokens added per second
+	burst     float64 // Bucket capacity
+	statePath string  // Shared state file ("" = this process only)
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// limiterState is the bucket as stored in the shared state file
+type limiterState struct {
+	Tokens float64 `json:"tokens"`
+	Last   int64   `json:"last"` // Unix nanoseconds of the last refill
+}
+
+// NewLimiter creates a token-bucket limiter
+// Parameters:
+//   - requestsPerSecond: Sustained request rate
+//   - burst: Requests allowed at once after an idle period (minimum 1)
+//   - statePath: File used to share the bucket between processes ("" = in-process only)
+func NewLimiter(requestsPerSecond float64, burst int, statePath string) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		rate:      requestsPerSecond,
+		burst:     float64(burst),
+		statePath: statePath,
+		tokens:    float64(burst),
+		last:      time.Now(),
+	}
+}
+
+// LimiterStatePath returns the shared state file for a Jira domain under dir,
+// so every process talking to the same site draws from one bucket
+func LimiterStatePath(dir, domain string) string {
+	name := regexp.MustCompile(`[^A-Za-z0-9.-]`).ReplaceAllString(domain, "_")
+	return filepath.Join(dir, fmt.Sprintf("ratelimit-%s.lock", name))
+}
+
+// String describes the limit, e.g. "5 requests/s (burst 10)"
+func (l *Limiter) String() string {
+	return fmt.Sprintf("%g requests/s (burst %.0f)", l.rate, l.burst)
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+// Returns how long it waited.
+func (l *Limiter) Wait(ctx context.Context) (time.Duration, error) {
+	var waited time.Duration
+	for {
+		wait := l.take()
+		if wait == 0 {
+			return waited, nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+			waited += wait
+		case <-ctx.Done():
+			timer.Stop()
+			return waited, ctx.Err()
+		}
+	}
+}
+
+// take consumes a token if one is available; otherwise it returns how long
+// until the next token is added
+func (l *Limiter) take() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.statePath != "" {
+		if wait, err := l.takeShared(); err == nil {
+			return wait
+		}
+		// The state file is unusable (e.g. read-only home directory);
+		// limit this process only
+	}
+
+	return l.takeLocal(time.Now())
+}
+
+// takeLocal applies the bucket algorithm to the in-memory state
+func (l *Limiter) takeLocal(now time.Time) time.Duration {
+	if elapsed := now.Sub(l.last).Seconds(); elapsed > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+elapsed*l.rate)
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// takeShared applies the bucket algorithm to the state file while holding
+// an exclusive lock on it
+func (l *Limiter) takeShared() (time.Duration, error) {
+	if err := os.MkdirAll(filepath.Dir(l.statePath), 0700); err != nil {
+		return 0, err
+	}
+
+	f, err := os.OpenFile(l.statePath, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	if err := lockFile(f); err != nil {
+		return 0, err
+	}
+	defer unlockFile(f)
+
+	// Read through the locked handle: Windows doesn't allow other handles to
+	// read a range locked with LockFileEx
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return 0, err
+	}
+
+	// Start from the shared bucket; a missing or corrupt file starts full.
+	// The in-memory bucket is restored if the state can't be written back,
+	// so the fallback to takeLocal carries on from where it was.
+	tokens, last := l.tokens, l.last
+	l.tokens, l.last = l.burst, time.Now()
+	var state limiterState
+	if json.Unmarshal(data, &state) == nil && state.Last > 0 {
+		l.tokens, l.last = math.Min(state.Tokens, l.burst), time.Unix(0, state.Last)
+	}
+
+	wait := l.takeLocal(time.Now())
+
+	if err := writeLimiterState(f, limiterState{Tokens: l.tokens, Last: l.last.UnixNano()}); err != nil {
+		l.tokens, l.last = tokens, last
+		return 0, err
+	}
+
+	return wait, nil
+}
+
+// writeLimiterState replaces the contents of the locked state file f
+func writeLimiterState(f *os.File, state limiterState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	_, err = f.WriteAt(data, 0)
+	return err
+}
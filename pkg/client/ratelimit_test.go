@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiterTakeLocal(t *testing.T) {
+	start := time.Now()
+	l := NewLimiter(2, 3, "")
+	l.last = start
+
+	// The bucket starts full, so a burst of 3 is allowed immediately
+	for i := 0; i < 3; i++ {
+		if wait := l.takeLocal(start); wait != 0 {
+			t.Fatalf("Expected request %d to pass immediately, got wait %s", i+1, wait)
+		}
+	}
+
+	// At 2 requests/s the next token arrives after 500ms
+	if wait := l.takeLocal(start); wait != 500*time.Millisecond {
+		t.Errorf("Expected wait of 500ms, got %s", wait)
+	}
+
+	if wait := l.takeLocal(start.Add(500 * time.Millisecond)); wait != 0 {
+		t.Errorf("Expected request to pass after refill, got wait %s", wait)
+	}
+
+	// Refilling never exceeds the burst
+	l.takeLocal(start.Add(time.Hour))
+	if l.tokens != 2 {
+		t.Errorf("Expected 2 tokens left after a long idle period, got %g", l.tokens)
+	}
+}
+
+func TestLimiterSharedState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratelimit.lock")
+
+	// Two limiters on the same file behave like two processes
+	first := NewLimiter(1, 2, path)
+	second := NewLimiter(1, 2, path)
+
+	if wait := first.take(); wait != 0 {
+		t.Fatalf("Expected first request to pass, got wait %s", wait)
+	}
+	if wait := second.take(); wait != 0 {
+		t.Fatalf("Expected second request to pass, got wait %s", wait)
+	}
+	if wait := first.take(); wait == 0 {
+		t.Errorf("Expected the shared bucket to be empty after 2 requests")
+	}
+}
+
+func TestLimiterWaitConcurrent(t *testing.T) {
+	l := NewLimiter(50, 1, filepath.Join(t.TempDir(), "ratelimit.lock"))
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := l.Wait(context.Background()); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// One request passes at once; the other 4 wait 20ms each
+	if elapsed := time.Since(start); elapsed < 70*time.Millisecond {
+		t.Errorf("Expected 5 requests at 50/s to take at least 80ms, took %s", elapsed)
+	}
+}
+
+func TestLimiterWaitCancelled(t *testing.T) {
+	l := NewLimiter(0.001, 1, "")
+	l.take()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestClientUsesLimiter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	c.Limiter = NewLimiter(0.001, 1, "")
+
+	if _, err := c.HTTPClient.R().Get("/myself"); err != nil {
+		t.Fatalf("Expected first request to succeed, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.HTTPClient.R().SetContext(ctx).Get("/myself"); err == nil {
+		t.Errorf("Expected second request to be held by the limiter until the deadline")
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected 1 request to reach the server, got %d", got)
+	}
+}
+
+func TestLimiterStatePath(t *testing.T) {
+	got := LimiterStatePath("/home/me/.jcfa", "example.atlassian.net:8443")
+	want := filepath.Join("/home/me/.jcfa", "ratelimit-example.atlassian.net_8443.lock")
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
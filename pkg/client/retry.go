@@ -1,10 +1,101 @@
 package client
 
 import (
+	"context"
 	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	// MaxRetries is the number of times a failed request is retried
+	MaxRetries = 3
+
+	// MaxRetryAfter is the longest Retry-After the client waits for. Longer waits
+	// aren't retried; the caller gets a RateLimitError with the wait instead.
+	MaxRetryAfter = 60 * time.Second
+)
+
+// retryMode overrides the default retry policy for a request
+type retryMode int
+
+const (
+	retryIdempotent retryMode = iota + 1 // Safe to repeat even though the method isn't idempotent
+	retryNever                           // Never repeat (e.g. body can't be replayed)
 )
 
+// retryModeKey is the request context key holding a retryMode
+type retryModeKey struct{}
+
+// MarkIdempotent marks a POST request as safe to retry, for read-only
+// endpoints that use POST (e.g. /search/jql)
+func MarkIdempotent(r *resty.Request) *resty.Request {
+	return r.SetContext(context.WithValue(r.Context(), retryModeKey{}, retryIdempotent))
+}
+
+// DisableRetry turns off retries for a request, e.g. a streamed upload whose
+// body can't be sent twice
+func DisableRetry(r *resty.Request) *resty.Request {
+	return r.SetContext(context.WithValue(r.Context(), retryModeKey{}, retryNever))
+}
+
+// isIdempotent reports whether sending the request again has no additional effect
+func isIdempotent(r *resty.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	mode, _ := r.Context().Value(retryModeKey{}).(retryMode)
+	return mode == retryIdempotent
+}
+
+// retryCondition decides whether a failed request is retried:
+//   - 429 responses for any method, since Jira rejects them before processing,
+//     unless Retry-After is longer than MaxRetryAfter
+//   - 5xx responses and network errors for idempotent requests only, so a
+//     POST that may have been applied (e.g. creating an issue) isn't repeated
+func retryCondition(resp *resty.Response, err error) bool {
+	if resp == nil || resp.Request == nil {
+		return false
+	}
+
+	if mode, _ := resp.Request.Context().Value(retryModeKey{}).(retryMode); mode == retryNever {
+		return false
+	}
+
+	if resp.StatusCode() == http.StatusTooManyRequests {
+		return ParseRetryAfter(resp.Header().Get("Retry-After")) <= MaxRetryAfter
+	}
+
+	if !isIdempotent(resp.Request) {
+		return false
+	}
+
+	return err != nil || ShouldRetry(resp.StatusCode())
+}
+
+// retryWait returns how long to wait before retrying: Retry-After when Jira
+// sends it, otherwise jittered exponential backoff
+func (c *Client) retryWait(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+	wait := ParseRetryAfter(resp.Header().Get("Retry-After"))
+	if wait == 0 {
+		wait = CalculateBackoff(resp.Request.Attempt)
+	}
+
+	reason := "request failed"
+	if resp.StatusCode() != 0 {
+		reason = "HTTP " + strconv.Itoa(resp.StatusCode())
+	}
+	c.logf("%s %s: %s, retrying in %s (retry %d of %d)\n",
+		resp.Request.Method, resp.Request.URL, reason, wait.Round(100*time.Millisecond), resp.Request.Attempt, MaxRetries)
+
+	return wait, nil
+}
+
 // CalculateBackoff calculates the backoff duration with jitter
 func CalculateBackoff(attempt int) time.Duration {
 	// Base backoff: 2^attempt seconds
@@ -25,3 +116,27 @@ func ShouldRetry(statusCode int) bool {
 	// Retry on rate limits (429) and server errors (5xx)
 	return statusCode == 429 || statusCode >= 500
 }
+
+// ParseRetryAfter parses a Retry-After header value, given either in seconds
+// or as an HTTP date. Returns 0 if the value is empty or invalid.
+func ParseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
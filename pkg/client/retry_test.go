@@ -0,0 +1,126 @@
+package client
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/config"
+)
+
+// newTestClient creates a client with the production retry policy pointed at a test server
+func newTestClient(serverURL string) *Client {
+	c := New(&config.Config{Domain: "example.atlassian.net", Email: "me@example.com", APIToken: "token"})
+	c.HTTPClient.SetBaseURL(serverURL)
+	return c
+}
+
+// makeResponse builds a response for a request with the given method and status
+func makeResponse(req *resty.Request, method string, status int, header http.Header) *resty.Response {
+	req.Method = method
+	if header == nil {
+		header = http.Header{}
+	}
+	return &resty.Response{Request: req, RawResponse: &http.Response{StatusCode: status, Header: header}}
+}
+
+func TestRetryCondition(t *testing.T) {
+	longWait := http.Header{}
+	longWait.Set("Retry-After", "3600")
+
+	tests := []struct {
+		name     string
+		req      *resty.Request
+		method   string
+		status   int
+		header   http.Header
+		expected bool
+	}{
+		{"GET server error", resty.New().R(), http.MethodGet, 503, nil, true},
+		{"PUT server error", resty.New().R(), http.MethodPut, 500, nil, true},
+		{"GET success", resty.New().R(), http.MethodGet, 200, nil, false},
+		{"GET not found", resty.New().R(), http.MethodGet, 404, nil, false},
+		{"POST server error", resty.New().R(), http.MethodPost, 503, nil, false},
+		{"POST rate limited", resty.New().R(), http.MethodPost, 429, nil, true},
+		{"idempotent POST server error", MarkIdempotent(resty.New().R()), http.MethodPost, 502, nil, true},
+		{"retry disabled", DisableRetry(resty.New().R()), http.MethodPost, 429, nil, false},
+		{"Retry-After too long", resty.New().R(), http.MethodGet, 429, longWait, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := makeResponse(tt.req, tt.method, tt.status, tt.header)
+			if got := retryCondition(resp, nil); got != tt.expected {
+				t.Errorf("Expected retry %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestClient_RetriesRateLimitedPOSTWithRetryAfter(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.Header().Set("X-RateLimit-Limit", "100")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("RateLimit-Reason", "jira-quota-global-based")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	var log bytes.Buffer
+	c.Verbose = &log
+
+	resp, err := c.PostRequest().SetBody(map[string]string{"a": "b"}).Post("/issue")
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if resp.StatusCode() != http.StatusCreated {
+		t.Errorf("Expected 201 after retry, got %d", resp.StatusCode())
+	}
+	if n := atomic.LoadInt32(&hits); n != 2 {
+		t.Errorf("Expected 2 requests, got %d", n)
+	}
+
+	status, ok := c.RateLimit()
+	if !ok || status.Remaining != 99 || status.Limit != 100 {
+		t.Errorf("Expected quota 99/100, got %+v (ok=%v)", status, ok)
+	}
+	if !strings.Contains(log.String(), "HTTP 429, retrying in 1s") {
+		t.Errorf("Expected retry message in verbose log, got:\n%s", log.String())
+	}
+	if !strings.Contains(log.String(), "0/100 requests remaining") {
+		t.Errorf("Expected quota message in verbose log, got:\n%s", log.String())
+	}
+}
+
+func TestClient_DoesNotRetryFailedPOST(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	resp, err := newTestClient(server.URL).PostRequest().Post("/issue")
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if resp.StatusCode() != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503, got %d", resp.StatusCode())
+	}
+	if n := atomic.LoadInt32(&hits); n != 1 {
+		t.Errorf("Expected a single request for a non-idempotent POST, got %d", n)
+	}
+}
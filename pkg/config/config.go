@@ -4,21 +4,62 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the Jira CLI configuration
 type Config struct {
-	Domain            string            `yaml:"domain"`                        // e.g., "yourcompany.atlassian.net"
-	Email             string            `yaml:"email"`                         // User email for API token
-	APIToken          string            `yaml:"api_token,omitempty"`           // Jira API token (deprecated: use keyring)
-	DefaultProject    string            `yaml:"default_project,omitempty"`     // Optional default project key
-	FieldMappings     map[string]string `yaml:"field_mappings,omitempty"`      // Custom field ID to name mappings
-	MaxAttachmentSize int64             `yaml:"max_attachment_size,omitempty"` // Max attachment size in MB (default: 10)
-	DownloadPath      string            `yaml:"download_path,omitempty"`       // Default download directory
-	KeyringBackend    string            `yaml:"keyring_backend,omitempty"`     // Credential storage: auto, keychain, file
-	UseKeyring        bool              `yaml:"use_keyring,omitempty"`         // Whether to use keyring for API token
+	Domain            string              `yaml:"domain"`                        // e.g., "yourcompany.atlassian.net"
+	Email             string              `yaml:"email"`                         // User email for API token
+	APIToken          string              `yaml:"api_token,omitempty"`           // Jira API token (deprecated: use keyring)
+	DefaultProject    string              `yaml:"default_project,omitempty"`     // Optional default project key
+	FieldMappings     map[string]string   `yaml:"field_mappings,omitempty"`      // Custom field ID to name mappings
+	MaxAttachmentSize int64               `yaml:"max_attachment_size,omitempty"` // Max attachment size in MB (default: 10)
+	DownloadPath      string              `yaml:"download_path,omitempty"`       // Default download directory
+	KeyringBackend    string              `yaml:"keyring_backend,omitempty"`     // Credential storage: auto, keychain, file
+	UseKeyring        bool                `yaml:"use_keyring,omitempty"`         // Whether to use keyring for API token
+	KeyringAccount    string              `yaml:"keyring_account,omitempty"`     // Keyring account name (default: email)
+	RequestsPerSecond float64             `yaml:"requests_per_second,omitempty"` // Client-side request rate limit (0 = unlimited)
+	Burst             int                 `yaml:"burst,omitempty"`               // Requests allowed at once (default: requests_per_second)
+	StrictMentions    bool                `yaml:"strict_mentions,omitempty"`     // Fail on @mentions matching several users (default: keep as text)
+	BulkThreshold     int                 `yaml:"bulk_threshold,omitempty"`      // Issues a bulk command may change without --yes (default: 10)
+	StatusAliases     map[string][]string `yaml:"status_aliases,omitempty"`      // Status names to try for a target, e.g. done: [Done, Closed, Resolved]
+
+	CurrentProfile string              `yaml:"current_profile,omitempty"` // Profile used when --profile/JCFA_PROFILE aren't set
+	Profiles       map[string]*Profile `yaml:"profiles,omitempty"`        // Named profiles (e.g., production, sandbox)
+
+	// ActiveProfile is the profile applied by WithProfile ("" = top-level settings)
+	ActiveProfile string `yaml:"-"`
+	// Sources maps setting names (domain, email, ...) to where their effective value came from
+	Sources map[string]string `yaml:"-"`
+
+	// path is the file this config was loaded from; Save writes back to it
+	path string
+	// file is the config as loaded, when this config is a profile view of it
+	file *Config
+	// base is the config before environment overrides, when this config is an env view of it
+	base *Config
+	// envMappings are the field mappings taken from JIRA_FIELD_MAPPINGS
+	envMappings map[string]string
+}
+
+// Profile holds the per-site settings of a named profile.
+// The site and credentials (domain, email, token and keyring settings) come
+// from the profile alone, so another site's token is never used; an unset
+// keyring_backend or default_project falls back to the top-level value, and
+// field_mappings are merged over the top-level mappings.
+type Profile struct {
+	Domain         string            `yaml:"domain"`
+	Email          string            `yaml:"email"`
+	APIToken       string            `yaml:"api_token,omitempty"`
+	KeyringAccount string            `yaml:"keyring_account,omitempty"`
+	KeyringBackend string            `yaml:"keyring_backend,omitempty"`
+	UseKeyring     bool              `yaml:"use_keyring,omitempty"`
+	DefaultProject string            `yaml:"default_project,omitempty"`
+	FieldMappings  map[string]string `yaml:"field_mappings,omitempty"`
 }
 
 const (
@@ -30,6 +71,12 @@ const (
 	ConfigFilePerms = 0600
 	// ConfigDirPerms is the directory permission for the config directory
 	ConfigDirPerms = 0700
+	// ProfileEnvVar selects a profile when --profile isn't given
+	ProfileEnvVar = "JCFA_PROFILE"
+	// DefaultProfileName refers to the top-level (non-profile) settings
+	DefaultProfileName = "default"
+	// DefaultBulkThreshold is how many issues a bulk command may change without --yes
+	DefaultBulkThreshold = 10
 )
 
 // GetConfigPath returns the full path to the config file
@@ -61,6 +108,24 @@ func Load() (*Config, error) {
 
 // LoadFromPath reads the config file from a specific path and returns a Config struct
 func LoadFromPath(configPath string) (*Config, error) {
+	config, err := ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// With profiles, the top-level settings may be empty; the selected
+	// profile is validated by WithProfile instead
+	if len(config.Profiles) == 0 {
+		if err := config.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid config: %w", err)
+		}
+	}
+
+	return config, nil
+}
+
+// ReadFile parses a config file without validating it
+func ReadFile(configPath string) (*Config, error) {
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -73,11 +138,7 @@ func LoadFromPath(configPath string) (*Config, error) {
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
-
-	// Validate required fields
-	if err := config.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid config: %w", err)
-	}
+	config.path = configPath
 
 	return &config, nil
 }
@@ -93,20 +154,149 @@ func LoadOrDefault() *Config {
 	return config
 }
 
-// Save writes the config to the config file
+// WithProfile returns the effective config for a profile.
+// An empty name selects CurrentProfile, falling back to the top-level settings;
+// "default" always selects the top-level settings. Saving the returned config
+// writes changes (e.g. field mappings) back into the profile.
+func (c *Config) WithProfile(name string) (*Config, error) {
+	effective, err := c.applyProfile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := effective.validateEffective(); err != nil {
+		return nil, err
+	}
+
+	return effective, nil
+}
+
+// applyProfile returns the config with the named profile applied, without validating it
+func (c *Config) applyProfile(name string) (*Config, error) {
+	if name == "" {
+		name = c.CurrentProfile
+	}
+
+	if name == "" || name == DefaultProfileName {
+		return c, nil
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile '%s' not found in config (available: %s)", name, strings.Join(c.ProfileNames(), ", "))
+	}
+
+	effective := *c
+	effective.Domain = profile.Domain
+	effective.Email = profile.Email
+	effective.APIToken = profile.APIToken
+	effective.KeyringAccount = profile.KeyringAccount
+	effective.UseKeyring = profile.UseKeyring
+	if profile.KeyringBackend != "" {
+		effective.KeyringBackend = profile.KeyringBackend
+	}
+	if profile.DefaultProject != "" {
+		effective.DefaultProject = profile.DefaultProject
+	}
+	effective.FieldMappings = mergeMappings(c.FieldMappings, profile.FieldMappings)
+	effective.ActiveProfile = name
+	effective.file = c
+
+	return &effective, nil
+}
+
+// validateEffective validates a config returned by applyProfile, naming the
+// profile in the error
+func (c *Config) validateEffective() error {
+	err := c.Validate()
+	switch {
+	case err == nil:
+		return nil
+	case c.ActiveProfile != "":
+		return fmt.Errorf("invalid profile '%s': %w", c.ActiveProfile, err)
+	case len(c.Profiles) > 0:
+		return fmt.Errorf("invalid config: %w (or select a profile with --profile or %s)", err, ProfileEnvVar)
+	default:
+		return fmt.Errorf("invalid config: %w", err)
+	}
+}
+
+// ProfileNames returns the configured profile names, sorted
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetKeyringAccount returns the account name used to store the API token in the keyring
+func (c *Config) GetKeyringAccount() string {
+	if c.KeyringAccount != "" {
+		return c.KeyringAccount
+	}
+	return c.Email
+}
+
+// mergeMappings overlays profile field mappings on the top-level mappings
+func mergeMappings(base, overlay map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(overlay))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overlay {
+		merged[k] = v
+	}
+	return merged
+}
+
+// Save writes the config to the file it was loaded from (default: ~/.jcfa/config.yaml).
+// For a profile view, field mappings are written back into that profile.
+// Values set through environment variables are never written.
 func (c *Config) Save() error {
+	if c.base != nil {
+		return c.saveEnvView()
+	}
+
+	if c.file != nil {
+		profile := c.file.Profiles[c.ActiveProfile]
+		if profile == nil {
+			profile = &Profile{}
+			if c.file.Profiles == nil {
+				c.file.Profiles = make(map[string]*Profile)
+			}
+			c.file.Profiles[c.ActiveProfile] = profile
+		}
+
+		// Only mappings that differ from the top-level ones belong to the profile
+		mappings := make(map[string]string)
+		for k, v := range c.FieldMappings {
+			if c.file.FieldMappings[k] != v {
+				mappings[k] = v
+			}
+		}
+		profile.FieldMappings = mappings
+
+		return c.file.Save()
+	}
+
 	// Validate before saving
-	if err := c.Validate(); err != nil {
+	if err := c.validateFile(); err != nil {
 		return fmt.Errorf("cannot save invalid config: %w", err)
 	}
 
-	// Ensure config directory exists
-	configDir, err := GetConfigDir()
-	if err != nil {
-		return err
+	configPath := c.path
+	if configPath == "" {
+		var err error
+		configPath, err = GetConfigPath()
+		if err != nil {
+			return err
+		}
 	}
 
-	if err := os.MkdirAll(configDir, ConfigDirPerms); err != nil {
+	// Ensure config directory exists
+	if err := os.MkdirAll(filepath.Dir(configPath), ConfigDirPerms); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
@@ -116,12 +306,6 @@ func (c *Config) Save() error {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	// Get config file path
-	configPath, err := GetConfigPath()
-	if err != nil {
-		return err
-	}
-
 	// Write config file with restricted permissions
 	if err := os.WriteFile(configPath, data, ConfigFilePerms); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
@@ -130,6 +314,43 @@ func (c *Config) Save() error {
 	return nil
 }
 
+// SaveToPath writes the config to a specific path; later Saves use the same path
+func (c *Config) SaveToPath(configPath string) error {
+	c.path = configPath
+	return c.Save()
+}
+
+// validateFile checks a whole config file: the top-level settings (unless the
+// file only uses profiles) and every profile
+func (c *Config) validateFile() error {
+	if len(c.Profiles) == 0 || c.Domain != "" || c.Email != "" {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range c.ProfileNames() {
+		p := c.Profiles[name]
+		if p.Domain == "" {
+			return fmt.Errorf("profile '%s': domain is required", name)
+		}
+		if p.Email == "" {
+			return fmt.Errorf("profile '%s': email is required", name)
+		}
+		if p.APIToken == "" && !p.UseKeyring {
+			return fmt.Errorf("profile '%s': api_token is required (or enable use_keyring)", name)
+		}
+	}
+
+	if c.CurrentProfile != "" && c.CurrentProfile != DefaultProfileName {
+		if _, ok := c.Profiles[c.CurrentProfile]; !ok {
+			return fmt.Errorf("current_profile '%s' does not exist", c.CurrentProfile)
+		}
+	}
+
+	return nil
+}
+
 // Validate checks if the config has all required fields
 func (c *Config) Validate() error {
 	if c.Domain == "" {
@@ -142,9 +363,56 @@ func (c *Config) Validate() error {
 	if c.APIToken == "" && !c.UseKeyring {
 		return fmt.Errorf("api_token is required (or enable use_keyring)")
 	}
+	if c.RequestsPerSecond < 0 {
+		return fmt.Errorf("requests_per_second cannot be negative")
+	}
+	if c.Burst < 0 {
+		return fmt.Errorf("burst cannot be negative")
+	}
+	if c.BulkThreshold < 0 {
+		return fmt.Errorf("bulk_threshold cannot be negative")
+	}
+	for alias, statuses := range c.StatusAliases {
+		if len(statuses) == 0 {
+			return fmt.Errorf("status_aliases.%s must list at least one status", alias)
+		}
+	}
 	return nil
 }
 
+// GetBurst returns the rate limiter burst size, defaulting to one second's
+// worth of requests (at least 1)
+func (c *Config) GetBurst() int {
+	if c.Burst > 0 {
+		return c.Burst
+	}
+	if burst := int(c.RequestsPerSecond); burst > 1 {
+		return burst
+	}
+	return 1
+}
+
+// GetBulkThreshold returns how many issues a bulk command may change
+// without --yes
+func (c *Config) GetBulkThreshold() int {
+	if c.BulkThreshold > 0 {
+		return c.BulkThreshold
+	}
+	return DefaultBulkThreshold
+}
+
+// StatusNames returns the status names to try for a transition target: the
+// name itself followed by its aliases (matched case-insensitively), if any
+func (c *Config) StatusNames(name string) []string {
+	names := []string{name}
+	for alias, statuses := range c.StatusAliases {
+		if strings.EqualFold(alias, name) {
+			names = append(names, statuses...)
+		}
+	}
+	return names
+}
+
 // GetAPIToken returns the API token, retrieving from keyring if configured
 func (c *Config) GetAPIToken() string {
 	// If UseKeyring is enabled and APIToken is empty, caller should use secrets package
@@ -156,3 +424,8 @@ func (c *Config) GetAPIToken() string {
 func (c *Config) GetBaseURL() string {
 	return fmt.Sprintf("https://%s/rest/api/3", c.Domain)
 }
+
+// GetAgileBaseURL returns the full Jira Software (Agile) API base URL
+func (c *Config) GetAgileBaseURL() string {
+	return fmt.Sprintf("https://%s/rest/agile/1.0", c.Domain)
+}
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const profilesYAML = `domain: prod.atlassian.net
+email: me@example.com
+api_token: prod-token
+field_mappings:
+  story_points: customfield_10016
+  epic_link: customfield_10014
+profiles:
+  sandbox:
+    domain: sandbox.atlassian.net
+    email: me@example.com
+    use_keyring: true
+    keyring_account: sandbox:me@example.com
+    default_project: SBX
+    field_mappings:
+      story_points: customfield_10200
+`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ConfigFileName)
+	if err := os.WriteFile(path, []byte(content), ConfigFilePerms); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestWithProfile_TopLevelByDefault(t *testing.T) {
+	cfg, err := LoadFromPath(writeConfig(t, profilesYAML))
+	if err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+
+	effective, err := cfg.WithProfile("")
+	if err != nil {
+		t.Fatalf("WithProfile() error = %v", err)
+	}
+	if effective.Domain != "prod.atlassian.net" {
+		t.Errorf("Expected top-level domain, got %s", effective.Domain)
+	}
+	if effective.ActiveProfile != "" {
+		t.Errorf("Expected no active profile, got %s", effective.ActiveProfile)
+	}
+	if effective.GetKeyringAccount() != "me@example.com" {
+		t.Errorf("Expected keyring account to default to email, got %s", effective.GetKeyringAccount())
+	}
+}
+
+func TestWithProfile_NamedProfile(t *testing.T) {
+	cfg, err := LoadFromPath(writeConfig(t, profilesYAML))
+	if err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+
+	effective, err := cfg.WithProfile("sandbox")
+	if err != nil {
+		t.Fatalf("WithProfile() error = %v", err)
+	}
+
+	if effective.Domain != "sandbox.atlassian.net" {
+		t.Errorf("Expected sandbox domain, got %s", effective.Domain)
+	}
+	if effective.APIToken != "" {
+		t.Errorf("Expected the top-level token not to leak into the profile, got %s", effective.APIToken)
+	}
+	if effective.GetKeyringAccount() != "sandbox:me@example.com" {
+		t.Errorf("Expected profile keyring account, got %s", effective.GetKeyringAccount())
+	}
+	if effective.DefaultProject != "SBX" {
+		t.Errorf("Expected default project SBX, got %s", effective.DefaultProject)
+	}
+	if effective.FieldMappings["story_points"] != "customfield_10200" {
+		t.Errorf("Expected profile mapping to override, got %s", effective.FieldMappings["story_points"])
+	}
+	if effective.FieldMappings["epic_link"] != "customfield_10014" {
+		t.Errorf("Expected top-level mapping to be inherited, got %s", effective.FieldMappings["epic_link"])
+	}
+}
+
+func TestWithProfile_CurrentProfileAndErrors(t *testing.T) {
+	cfg, err := LoadFromPath(writeConfig(t, "current_profile: sandbox\n"+profilesYAML))
+	if err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+
+	effective, err := cfg.WithProfile("")
+	if err != nil {
+		t.Fatalf("WithProfile() error = %v", err)
+	}
+	if effective.ActiveProfile != "sandbox" {
+		t.Errorf("Expected current_profile to apply, got %q", effective.ActiveProfile)
+	}
+
+	effective, err = cfg.WithProfile(DefaultProfileName)
+	if err != nil {
+		t.Fatalf("WithProfile(default) error = %v", err)
+	}
+	if effective.Domain != "prod.atlassian.net" {
+		t.Errorf("Expected 'default' to select top-level settings, got %s", effective.Domain)
+	}
+
+	if _, err := cfg.WithProfile("missing"); err == nil {
+		t.Error("Expected error for unknown profile")
+	}
+}
+
+func TestLoadFromPath_ProfilesOnly(t *testing.T) {
+	content := `current_profile: work
+profiles:
+  work:
+    domain: work.atlassian.net
+    email: me@example.com
+    api_token: token
+`
+	cfg, err := LoadFromPath(writeConfig(t, content))
+	if err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+
+	if _, err := cfg.WithProfile(DefaultProfileName); err == nil {
+		t.Error("Expected error selecting empty top-level settings")
+	}
+	if _, err := cfg.WithProfile(""); err != nil {
+		t.Errorf("WithProfile() error = %v", err)
+	}
+}
+
+func TestSave_ProfileMappingsWrittenToProfile(t *testing.T) {
+	path := writeConfig(t, profilesYAML)
+	cfg, err := LoadFromPath(path)
+	if err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+
+	effective, err := cfg.WithProfile("sandbox")
+	if err != nil {
+		t.Fatalf("WithProfile() error = %v", err)
+	}
+	effective.FieldMappings["team"] = "customfield_10300"
+	if err := effective.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	reloaded, err := LoadFromPath(path)
+	if err != nil {
+		t.Fatalf("LoadFromPath() after save error = %v", err)
+	}
+	if _, ok := reloaded.FieldMappings["team"]; ok {
+		t.Error("Profile mapping should not be saved at the top level")
+	}
+	mappings := reloaded.Profiles["sandbox"].FieldMappings
+	if mappings["team"] != "customfield_10300" || mappings["story_points"] != "customfield_10200" {
+		t.Errorf("Unexpected profile mappings after save: %v", mappings)
+	}
+	if _, ok := mappings["epic_link"]; ok {
+		t.Error("Inherited top-level mappings should not be copied into the profile")
+	}
+}
+
+func TestGetBulkThreshold(t *testing.T) {
+	if got := (&Config{}).GetBulkThreshold(); got != DefaultBulkThreshold {
+		t.Errorf("Expected default threshold %d, got %d", DefaultBulkThreshold, got)
+	}
+	if got := (&Config{BulkThreshold: 50}).GetBulkThreshold(); got != 50 {
+		t.Errorf("Expected threshold 50, got %d", got)
+	}
+}
+
+func TestGetBurst(t *testing.T) {
+	tests := []struct {
+		name  string
+		rps   float64
+		burst int
+		want  int
+	}{
+		{"explicit burst", 5, 10, 10},
+		{"defaults to rps", 5, 0, 5},
+		{"fractional rps", 0.5, 0, 1},
+		{"unlimited", 0, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{RequestsPerSecond: tt.rps, Burst: tt.burst}
+			if got := cfg.GetBurst(); got != tt.want {
+				t.Errorf("Expected burst %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestStatusNames(t *testing.T) {
+	cfg := &Config{StatusAliases: map[string][]string{"done": {"Done", "Closed", "Resolved"}}}
+
+	if got := strings.Join(cfg.StatusNames("DONE"), ","); got != "DONE,Done,Closed,Resolved" {
+		t.Errorf("Expected the name followed by its aliases, got %s", got)
+	}
+	if got := strings.Join(cfg.StatusNames("In Progress"), ","); got != "In Progress" {
+		t.Errorf("Expected just the name without an alias, got %s", got)
+	}
+}
@@ -0,0 +1,221 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Environment variables that override config file values.
+// Precedence (highest first): environment, selected profile, top-level config file.
+const (
+	// EnvDomain overrides domain
+	EnvDomain = "JIRA_DOMAIN"
+	// EnvEmail overrides email
+	EnvEmail = "JIRA_EMAIL"
+	// EnvAPIToken overrides api_token and takes precedence over the keyring
+	EnvAPIToken = "JIRA_API_TOKEN"
+	// EnvDefaultProject overrides default_project
+	EnvDefaultProject = "JIRA_DEFAULT_PROJECT"
+	// EnvFieldMappings is a JSON object of aliases merged over field_mappings,
+	// e.g. {"story_points":"customfield_10016"}
+	EnvFieldMappings = "JIRA_FIELD_MAPPINGS"
+)
+
+// Setting names used as keys in Config.Sources
+const (
+	SettingDomain         = "domain"
+	SettingEmail          = "email"
+	SettingAPIToken       = "api_token"
+	SettingDefaultProject = "default_project"
+	SettingFieldMappings  = "field_mappings"
+)
+
+// Value sources reported in Config.Sources
+const (
+	SourceFile    = "config file"
+	SourceKeyring = "keyring"
+	SourceUnset   = "not set"
+)
+
+// settingOrder is the order settings are reported in
+var settingOrder = []string{SettingDomain, SettingEmail, SettingAPIToken, SettingDefaultProject, SettingFieldMappings}
+
+// Resolve loads the effective configuration: the config file (optional when
+// JIRA_DOMAIN is set), then the selected profile, then environment overrides.
+// The result is not validated; call Validate or ValidateVerbose.
+// Parameters:
+//   - configPath: Config file path ("" for ~/.jcfa/config.yaml)
+//   - profile: Profile name ("" for current_profile, "default" for top-level settings)
+func Resolve(configPath, profile string) (*Config, error) {
+	if configPath == "" {
+		var err error
+		configPath, err = GetConfigPath()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fileCfg, err := ReadFile(configPath)
+	if err != nil {
+		// Without a config file the environment must provide at least the domain
+		if _, statErr := os.Stat(configPath); !os.IsNotExist(statErr) || os.Getenv(EnvDomain) == "" {
+			return nil, fmt.Errorf("%w (or set %s, %s and %s)", err, EnvDomain, EnvEmail, EnvAPIToken)
+		}
+		fileCfg = &Config{}
+	}
+
+	effective, err := fileCfg.applyProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	effective.recordSources()
+
+	return effective.WithEnv()
+}
+
+// recordSources records the file or profile each setting came from
+func (c *Config) recordSources() {
+	source := SourceFile
+	if c.ActiveProfile != "" {
+		source = fmt.Sprintf("profile '%s'", c.ActiveProfile)
+	}
+
+	c.Sources = make(map[string]string, len(settingOrder))
+	set := func(setting, value string) {
+		if value != "" {
+			c.Sources[setting] = source
+		}
+	}
+	set(SettingDomain, c.Domain)
+	set(SettingEmail, c.Email)
+	set(SettingAPIToken, c.APIToken)
+	set(SettingDefaultProject, c.DefaultProject)
+	if len(c.FieldMappings) > 0 {
+		c.Sources[SettingFieldMappings] = source
+	}
+	if c.APIToken == "" && c.UseKeyring {
+		c.Sources[SettingAPIToken] = SourceKeyring
+	}
+}
+
+// WithEnv returns the config with JIRA_* environment variables applied over it.
+// The config is returned unchanged when none are set. Saving the returned
+// config only writes field mapping changes back, never environment values.
+func (c *Config) WithEnv() (*Config, error) {
+	values := map[string]string{}
+	for _, name := range []string{EnvDomain, EnvEmail, EnvAPIToken, EnvDefaultProject, EnvFieldMappings} {
+		if v := strings.TrimSpace(os.Getenv(name)); v != "" {
+			values[name] = v
+		}
+	}
+	if len(values) == 0 {
+		return c, nil
+	}
+
+	effective := *c
+	effective.base = c
+	effective.Sources = make(map[string]string, len(settingOrder))
+	for k, v := range c.Sources {
+		effective.Sources[k] = v
+	}
+
+	override := func(field *string, setting, name string) {
+		if v, ok := values[name]; ok {
+			*field = v
+			effective.Sources[setting] = "env " + name
+		}
+	}
+	override(&effective.Domain, SettingDomain, EnvDomain)
+	override(&effective.Email, SettingEmail, EnvEmail)
+	override(&effective.APIToken, SettingAPIToken, EnvAPIToken)
+	override(&effective.DefaultProject, SettingDefaultProject, EnvDefaultProject)
+
+	// An explicit token wins over the keyring
+	if _, ok := values[EnvAPIToken]; ok {
+		effective.UseKeyring = false
+	}
+
+	if raw, ok := values[EnvFieldMappings]; ok {
+		var mappings map[string]string
+		if err := json.Unmarshal([]byte(raw), &mappings); err != nil {
+			return nil, fmt.Errorf("invalid %s: must be a JSON object of alias to field ID: %w", EnvFieldMappings, err)
+		}
+		effective.FieldMappings = mergeMappings(c.FieldMappings, mappings)
+		effective.envMappings = mappings
+
+		source := "env " + EnvFieldMappings
+		if prev, ok := c.Sources[SettingFieldMappings]; ok {
+			source = prev + " + " + source
+		}
+		effective.Sources[SettingFieldMappings] = source
+	}
+
+	return &effective, nil
+}
+
+// saveEnvView writes field mapping changes made on an env view back to the
+// underlying config, leaving out mappings that came from JIRA_FIELD_MAPPINGS
+func (c *Config) saveEnvView() error {
+	if c.base.path == "" {
+		return fmt.Errorf("no config file to save to (configuration comes from environment variables); add the mapping to %s instead", EnvFieldMappings)
+	}
+
+	if c.base.FieldMappings == nil {
+		c.base.FieldMappings = make(map[string]string)
+	}
+	for k, v := range c.FieldMappings {
+		if env, ok := c.envMappings[k]; ok && env == v {
+			continue
+		}
+		c.base.FieldMappings[k] = v
+	}
+
+	return c.base.Save()
+}
+
+// ValidateWithSources validates the effective config (after Resolve or
+// WithProfile). When w is non-nil, the value and source of each setting are
+// written to it first, e.g. for --verbose. The API token is never printed.
+func (c *Config) ValidateWithSources(w io.Writer) error {
+	if w != nil {
+		fmt.Fprintln(w, "Configuration:")
+		for _, setting := range settingOrder {
+			source, ok := c.Sources[setting]
+			if !ok {
+				source = SourceUnset
+			}
+			fmt.Fprintf(w, "  %-16s %-40s (%s)\n", setting+":", c.describe(setting), source)
+		}
+	}
+
+	return c.validateEffective()
+}
+
+// describe returns a printable value for a setting
+func (c *Config) describe(setting string) string {
+	switch setting {
+	case SettingDomain:
+		return c.Domain
+	case SettingEmail:
+		return c.Email
+	case SettingAPIToken:
+		if c.APIToken != "" {
+			return "********"
+		}
+		return ""
+	case SettingDefaultProject:
+		return c.DefaultProject
+	case SettingFieldMappings:
+		aliases := make([]string, 0, len(c.FieldMappings))
+		for alias := range c.FieldMappings {
+			aliases = append(aliases, alias)
+		}
+		sort.Strings(aliases)
+		return strings.Join(aliases, ", ")
+	}
+	return ""
+}
@@ -0,0 +1,191 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{EnvDomain, EnvEmail, EnvAPIToken, EnvDefaultProject, EnvFieldMappings} {
+		t.Setenv(name, "")
+	}
+}
+
+func TestResolve_EnvOnly(t *testing.T) {
+	clearEnv(t)
+	t.Setenv(EnvDomain, "ci.atlassian.net")
+	t.Setenv(EnvEmail, "bot@example.com")
+	t.Setenv(EnvAPIToken, "env-token")
+	t.Setenv(EnvFieldMappings, `{"story_points":"customfield_10016"}`)
+
+	cfg, err := Resolve(filepath.Join(t.TempDir(), "missing.yaml"), "")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if err := cfg.ValidateWithSources(nil); err != nil {
+		t.Fatalf("ValidateWithSources() error = %v", err)
+	}
+
+	if cfg.Domain != "ci.atlassian.net" || cfg.APIToken != "env-token" {
+		t.Errorf("Expected env values, got domain=%s token=%s", cfg.Domain, cfg.APIToken)
+	}
+	if cfg.FieldMappings["story_points"] != "customfield_10016" {
+		t.Errorf("Expected env field mapping, got %v", cfg.FieldMappings)
+	}
+	if cfg.Sources[SettingDomain] != "env JIRA_DOMAIN" {
+		t.Errorf("Expected domain source env JIRA_DOMAIN, got %s", cfg.Sources[SettingDomain])
+	}
+
+	if err := cfg.Save(); err == nil {
+		t.Error("Expected error saving config without a config file")
+	}
+}
+
+func TestResolve_MissingFileWithoutEnv(t *testing.T) {
+	clearEnv(t)
+
+	_, err := Resolve(filepath.Join(t.TempDir(), "missing.yaml"), "")
+	if err == nil || !strings.Contains(err.Error(), EnvDomain) {
+		t.Errorf("Expected not found error mentioning %s, got %v", EnvDomain, err)
+	}
+}
+
+func TestResolve_Precedence(t *testing.T) {
+	tests := []struct {
+		name        string
+		profile     string
+		env         map[string]string
+		wantDomain  string
+		wantProject string
+		wantKeyring bool
+		wantSource  map[string]string
+	}{
+		{
+			name:       "file only",
+			wantDomain: "prod.atlassian.net",
+			wantSource: map[string]string{SettingDomain: SourceFile, SettingAPIToken: SourceFile},
+		},
+		{
+			name:        "env over file",
+			env:         map[string]string{EnvDomain: "other.atlassian.net", EnvDefaultProject: "ENV"},
+			wantDomain:  "other.atlassian.net",
+			wantProject: "ENV",
+			wantSource:  map[string]string{SettingDomain: "env JIRA_DOMAIN", SettingEmail: SourceFile},
+		},
+		{
+			name:        "profile",
+			profile:     "sandbox",
+			wantDomain:  "sandbox.atlassian.net",
+			wantProject: "SBX",
+			wantKeyring: true,
+			wantSource:  map[string]string{SettingDomain: "profile 'sandbox'", SettingAPIToken: SourceKeyring},
+		},
+		{
+			name:        "env token over profile keyring",
+			profile:     "sandbox",
+			env:         map[string]string{EnvAPIToken: "env-token"},
+			wantDomain:  "sandbox.atlassian.net",
+			wantProject: "SBX",
+			wantSource:  map[string]string{SettingDomain: "profile 'sandbox'", SettingAPIToken: "env JIRA_API_TOKEN"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			cfg, err := Resolve(writeConfig(t, profilesYAML), tt.profile)
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if err := cfg.ValidateWithSources(nil); err != nil {
+				t.Fatalf("ValidateWithSources() error = %v", err)
+			}
+
+			if cfg.Domain != tt.wantDomain {
+				t.Errorf("Expected domain %s, got %s", tt.wantDomain, cfg.Domain)
+			}
+			if cfg.DefaultProject != tt.wantProject {
+				t.Errorf("Expected default project %q, got %q", tt.wantProject, cfg.DefaultProject)
+			}
+			if cfg.UseKeyring != tt.wantKeyring {
+				t.Errorf("Expected use_keyring %v, got %v", tt.wantKeyring, cfg.UseKeyring)
+			}
+			for setting, want := range tt.wantSource {
+				if got := cfg.Sources[setting]; got != want {
+					t.Errorf("Expected %s source %q, got %q", setting, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestResolve_InvalidFieldMappings(t *testing.T) {
+	clearEnv(t)
+	t.Setenv(EnvFieldMappings, "story_points=customfield_10016")
+
+	if _, err := Resolve(writeConfig(t, profilesYAML), ""); err == nil {
+		t.Error("Expected error for invalid JIRA_FIELD_MAPPINGS")
+	}
+}
+
+func TestSave_EnvMappingsNotPersisted(t *testing.T) {
+	clearEnv(t)
+	t.Setenv(EnvAPIToken, "env-token")
+	t.Setenv(EnvFieldMappings, `{"team":"customfield_10300"}`)
+
+	path := writeConfig(t, profilesYAML)
+	cfg, err := Resolve(path, "")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	cfg.FieldMappings["sprint"] = "customfield_10020"
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	if !strings.Contains(content, "sprint: customfield_10020") {
+		t.Error("Expected new mapping to be saved")
+	}
+	if strings.Contains(content, "customfield_10300") || strings.Contains(content, "env-token") {
+		t.Errorf("Environment values should not be saved:\n%s", content)
+	}
+}
+
+func TestValidateWithSources_Report(t *testing.T) {
+	clearEnv(t)
+	t.Setenv(EnvEmail, "bot@example.com")
+
+	cfg, err := Resolve(writeConfig(t, profilesYAML), "")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := cfg.ValidateWithSources(&buf); err != nil {
+		t.Fatalf("ValidateWithSources() error = %v", err)
+	}
+
+	report := buf.String()
+	for _, want := range []string{"(env JIRA_EMAIL)", "prod.atlassian.net", "(config file)"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected report to contain %q:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "prod-token") {
+		t.Errorf("Report must not contain the API token:\n%s", report)
+	}
+}
@@ -2,7 +2,10 @@ package jira
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // ADFToPlainText converts Atlassian Document Format (ADF) to plain text.
@@ -21,6 +24,11 @@ import (
 //   - mediaSingle / mediaGroup: Images and media (displayed as placeholders)
 //   - rule: Horizontal rule
 //   - table, tableRow, tableHeader, tableCell: Table structures
+//   - inlineCard, mention, emoji, status, date: Inline nodes
+//   - taskList / taskItem: Tasks ([ ] / [x])
+//   - panel, expand: Content shown without decoration (expands with their title)
+//
+// Use ADFToMarkdown to keep formatting, links and structure.
 //
 // Example ADF input:
 //
@@ -145,6 +153,32 @@ func processADFNode(result *strings.Builder, node map[string]interface{}, depth
 			}
 		}
 
+	case "status":
+		// Handle status lozenges
+		if attrs, ok := node["attrs"].(map[string]interface{}); ok {
+			if text, ok := attrs["text"].(string); ok {
+				result.WriteString(fmt.Sprintf("[%s]", strings.ToUpper(text)))
+			}
+		}
+
+	case "date":
+		if attrs, ok := node["attrs"].(map[string]interface{}); ok {
+			result.WriteString(formatADFDate(attrs))
+		}
+
+	case "taskList", "decisionList":
+		processListItems(result, node, depth, false)
+
+	case "expand", "nestedExpand":
+		// Show the expand title above its content
+		if attrs, ok := node["attrs"].(map[string]interface{}); ok {
+			if title, ok := attrs["title"].(string); ok && title != "" {
+				result.WriteString(title)
+				result.WriteString("\n")
+			}
+		}
+		processChildren(result, node, depth)
+
 	default:
 		// For unknown types, try to process children if they exist
 		processChildren(result, node, depth)
@@ -176,11 +210,23 @@ func processListItems(result *strings.Builder, node map[string]interface{}, dept
 
 	for i, item := range content {
 		if itemMap, ok := item.(map[string]interface{}); ok {
+			// Task lists nest by containing another task list
+			if itemMap["type"] == "taskList" {
+				processADFNode(result, itemMap, depth+1, 0)
+				continue
+			}
+
 			// Create list marker
 			var marker string
-			if ordered {
+			switch {
+			case ordered:
 				marker = fmt.Sprintf("%d. ", i+1)
-			} else {
+			case itemMap["type"] == "taskItem":
+				marker = "[ ] "
+				if attrs, ok := itemMap["attrs"].(map[string]interface{}); ok && attrs["state"] == "DONE" {
+					marker = "[x] "
+				}
+			default:
 				marker = "• "
 			}
 
@@ -297,3 +343,518 @@ func processTableRow(result *strings.Builder, node map[string]interface{}) {
 	result.WriteString(strings.Join(cells, " | "))
 	result.WriteString(" |\n")
 }
+
+// ADFToMarkdown converts Atlassian Document Format (ADF) to GitHub-flavoured Markdown,
+// keeping the structure that ADFToPlainText drops.
+//
+// Supported node types, in addition to those of ADFToPlainText:
+//   - text marks: strong, em, strike, code, link, underline, subsup
+//   - mention (@Name, or @[Full Name]), emoji, inlineCard (<url>), status ([TEXT]), date
+//   - panel (GitHub alert blockquote, e.g. "> [!NOTE]")
+//   - expand / nestedExpand (<details> with the title as summary)
+//   - taskList / taskItem (- [ ] / - [x]), decisionList
+//   - tables as GFM tables, nested lists by indentation
+//
+// Markdown produced here converts back to equivalent ADF with MarkdownToADF,
+// apart from nodes Markdown can't express (panels, expands); mentions convert
+// back with MarkdownToADFWithMentions.
+func ADFToMarkdown(adf interface{}) string {
+	if adf == nil {
+		return ""
+	}
+
+	// Handle string input (already plain text)
+	if str, ok := adf.(string); ok {
+		return str
+	}
+
+	adfMap, ok := adf.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	return strings.TrimRight(markdownBlock(adfMap), "\n")
+}
+
+// markdownBlocks renders block nodes separated by blank lines
+func markdownBlocks(node map[string]interface{}) string {
+	var blocks []string
+	for _, child := range adfChildren(node) {
+		if block := markdownBlock(child); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// markdownBlock renders a single block node
+func markdownBlock(node map[string]interface{}) string {
+	nodeType, _ := node["type"].(string)
+	attrs, _ := node["attrs"].(map[string]interface{})
+
+	switch nodeType {
+	case "doc", "blockCard", "embedCard", "bodiedExtension", "layoutSection", "layoutColumn":
+		if url, ok := attrs["url"].(string); ok {
+			return "<" + url + ">"
+		}
+		return markdownBlocks(node)
+
+	case "paragraph":
+		return escapeLineStart(markdownInline(adfChildren(node)))
+
+	case "heading":
+		level, _ := attrs["level"].(float64)
+		if level < 1 {
+			level = 1
+		}
+		return strings.Repeat("#", int(level)) + " " + markdownInline(adfChildren(node))
+
+	case "codeBlock":
+		code := plainTextOf(node)
+		fence := "```"
+		for strings.Contains(code, fence) {
+			fence += "`"
+		}
+		language, _ := attrs["language"].(string)
+		return fence + language + "\n" + code + "\n" + fence
+
+	case "blockquote":
+		return prefixLines(markdownBlocks(node), "> ")
+
+	case "panel":
+		return prefixLines(panelAlert(attrs)+"\n"+markdownBlocks(node), "> ")
+
+	case "expand", "nestedExpand":
+		title, _ := attrs["title"].(string)
+		return "<details>\n<summary>" + title + "</summary>\n\n" + markdownBlocks(node) + "\n\n</details>"
+
+	case "bulletList", "orderedList":
+		return markdownList(node, nodeType == "orderedList")
+
+	case "taskList", "decisionList":
+		return markdownTaskList(node)
+
+	case "rule":
+		return "---"
+
+	case "table":
+		return markdownTable(node)
+
+	case "mediaSingle", "mediaGroup":
+		var media []string
+		for _, child := range adfChildren(node) {
+			media = append(media, markdownMedia(child))
+		}
+		return strings.Join(media, "\n")
+
+	case "media":
+		return markdownMedia(node)
+
+	default:
+		// Unknown blocks: render block children, or inline content as a paragraph
+		children := adfChildren(node)
+		if len(children) > 0 && isInlineNode(children[0]) {
+			return markdownInline(children)
+		}
+		return markdownBlocks(node)
+	}
+}
+
+// markdownList renders a bullet or ordered list; item content is indented
+// under the marker so nested blocks stay in the item
+func markdownList(node map[string]interface{}, ordered bool) string {
+	start := 1
+	if attrs, ok := node["attrs"].(map[string]interface{}); ok {
+		if order, ok := attrs["order"].(float64); ok {
+			start = int(order)
+		}
+	}
+
+	var items []string
+	for i, item := range adfChildren(node) {
+		marker := "- "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", start+i)
+		}
+
+		var parts []string
+		for _, child := range adfChildren(item) {
+			if part := markdownBlock(child); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		items = append(items, marker+indentLines(strings.Join(parts, "\n"), len(marker)))
+	}
+	return strings.Join(items, "\n")
+}
+
+// markdownTaskList renders a taskList (or decisionList) as a task list;
+// a nested taskList follows the item it belongs to
+func markdownTaskList(node map[string]interface{}) string {
+	var lines []string
+	for _, item := range adfChildren(node) {
+		itemType, _ := item["type"].(string)
+		attrs, _ := item["attrs"].(map[string]interface{})
+
+		switch itemType {
+		case "taskItem":
+			checkbox := "[ ]"
+			if attrs["state"] == "DONE" {
+				checkbox = "[x]"
+			}
+			lines = append(lines, "- "+checkbox+" "+indentLines(markdownInline(adfChildren(item)), 6))
+		case "decisionItem":
+			lines = append(lines, "- "+indentLines(markdownInline(adfChildren(item)), 2))
+		default:
+			lines = append(lines, indentLines("  "+markdownBlock(item), 2))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// markdownTable renders a table as a GFM table; the first row is the header
+func markdownTable(node map[string]interface{}) string {
+	var rows [][]string
+	width := 0
+	for _, row := range adfChildren(node) {
+		var cells []string
+		for _, cell := range adfChildren(row) {
+			text := markdownBlocks(cell)
+			text = strings.ReplaceAll(text, "|", `\|`)
+			text = strings.ReplaceAll(text, "\n\n", "<br>")
+			text = strings.ReplaceAll(text, "\\\n", "<br>")
+			text = strings.ReplaceAll(text, "\n", "<br>")
+			cells = append(cells, text)
+		}
+		if len(cells) > width {
+			width = len(cells)
+		}
+		rows = append(rows, cells)
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+
+	formatRow := func(cells []string) string {
+		for len(cells) < width {
+			cells = append(cells, "")
+		}
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+
+	separator := make([]string, width)
+	for i := range separator {
+		separator[i] = "---"
+	}
+
+	lines := []string{formatRow(rows[0]), formatRow(separator)}
+	for _, row := range rows[1:] {
+		lines = append(lines, formatRow(row))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// markdownMedia renders an image or file placeholder
+func markdownMedia(node map[string]interface{}) string {
+	attrs, _ := node["attrs"].(map[string]interface{})
+	alt, _ := attrs["alt"].(string)
+	if url, ok := attrs["url"].(string); ok && url != "" {
+		return "![" + alt + "](" + url + ")"
+	}
+	if alt != "" {
+		return fmt.Sprintf("[File: %s]", alt)
+	}
+	return "[Media]"
+}
+
+// panelAlert returns the GitHub alert marker for a panel type
+func panelAlert(attrs map[string]interface{}) string {
+	switch attrs["panelType"] {
+	case "warning":
+		return "[!WARNING]"
+	case "error":
+		return "[!CAUTION]"
+	case "success":
+		return "[!TIP]"
+	case "note":
+		return "[!IMPORTANT]"
+	default:
+		return "[!NOTE]"
+	}
+}
+
+// markdownMarkOrder is the nesting order of marks, outermost first
+var markdownMarkOrder = []string{"link", "strong", "em", "strike", "underline", "subsup"}
+
+// markdownInline renders inline nodes, opening and closing marks only where
+// they change between adjacent text nodes
+func markdownInline(nodes []map[string]interface{}) string {
+	var out strings.Builder
+	var open []markdownMark
+	pending := "" // Trailing whitespace, written after any closing marks
+
+	closeTo := func(n int) {
+		for i := len(open) - 1; i >= n; i-- {
+			out.WriteString(open[i].close)
+		}
+		open = open[:n]
+		out.WriteString(pending)
+		pending = ""
+	}
+
+	for _, node := range nodes {
+		if node["type"] != "text" {
+			closeTo(0)
+			out.WriteString(markdownInlineNode(node))
+			continue
+		}
+
+		text, _ := node["text"].(string)
+		marks, code := markdownMarks(node)
+		if strings.TrimSpace(text) == "" {
+			marks = nil
+		}
+
+		common := 0
+		for common < len(open) && common < len(marks) && open[common] == marks[common] {
+			common++
+		}
+		closeTo(common)
+
+		if code {
+			ticks := "`"
+			for strings.Contains(text, ticks) {
+				ticks += "`"
+			}
+			text = ticks + text + ticks
+		} else {
+			text = escapeMarkdown(text)
+		}
+
+		// Marks can't open or close next to whitespace
+		body := strings.TrimLeft(text, " ")
+		if len(marks) > common {
+			out.WriteString(text[:len(text)-len(body)])
+		} else {
+			body = text
+		}
+		for _, m := range marks[common:] {
+			out.WriteString(m.open)
+		}
+		open = marks
+
+		trimmed := strings.TrimRight(body, " ")
+		out.WriteString(trimmed)
+		pending = body[len(trimmed):]
+	}
+	closeTo(0)
+
+	return out.String()
+}
+
+// markdownMark is the Markdown syntax for an ADF mark
+type markdownMark struct {
+	open, close string
+}
+
+// markdownMarks returns the marks of a text node in nesting order, and whether it's code
+func markdownMarks(node map[string]interface{}) ([]markdownMark, bool) {
+	found := map[string]markdownMark{}
+	code := false
+
+	marks, _ := node["marks"].([]interface{})
+	for _, m := range marks {
+		mark, _ := m.(map[string]interface{})
+		attrs, _ := mark["attrs"].(map[string]interface{})
+		switch mark["type"] {
+		case "strong":
+			found["strong"] = markdownMark{"**", "**"}
+		case "em":
+			found["em"] = markdownMark{"*", "*"}
+		case "strike":
+			found["strike"] = markdownMark{"~~", "~~"}
+		case "underline":
+			found["underline"] = markdownMark{"<u>", "</u>"}
+		case "subsup":
+			tag := "sub"
+			if attrs["type"] == "sup" {
+				tag = "sup"
+			}
+			found["subsup"] = markdownMark{"<" + tag + ">", "</" + tag + ">"}
+		case "link":
+			href, _ := attrs["href"].(string)
+			found["link"] = markdownMark{"[", "](" + href + ")"}
+		case "code":
+			code = true
+		}
+	}
+
+	var result []markdownMark
+	for _, name := range markdownMarkOrder {
+		if m, ok := found[name]; ok {
+			result = append(result, m)
+		}
+	}
+	return result, code
+}
+
+// markdownInlineNode renders an inline node other than text
+func markdownInlineNode(node map[string]interface{}) string {
+	attrs, _ := node["attrs"].(map[string]interface{})
+
+	switch node["type"] {
+	case "hardBreak":
+		return "\\\n"
+
+	case "mention":
+		text, _ := attrs["text"].(string)
+		if text == "" {
+			text, _ = attrs["id"].(string)
+		}
+		// Names with spaces are bracketed, the form MarkdownToADFWithMentions reads back
+		text = strings.TrimPrefix(text, "@")
+		if strings.ContainsAny(text, " \t") {
+			return "@[" + text + "]"
+		}
+		return "@" + text
+
+	case "emoji":
+		if text, ok := attrs["text"].(string); ok && text != "" {
+			return text
+		}
+		shortName, _ := attrs["shortName"].(string)
+		return shortName
+
+	case "inlineCard":
+		if url, ok := attrs["url"].(string); ok {
+			return "<" + url + ">"
+		}
+
+	case "status":
+		text, _ := attrs["text"].(string)
+		return `\[` + escapeMarkdown(strings.ToUpper(text)) + `\]`
+
+	case "date":
+		return formatADFDate(attrs)
+
+	case "mediaInline":
+		return markdownMedia(node)
+	}
+
+	return markdownInline(adfChildren(node))
+}
+
+// markdownEscaper escapes characters that would otherwise start Markdown syntax
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+	"~~", `\~\~`,
+	"<", `\<`,
+)
+
+// escapeMarkdown escapes text so it renders literally. Underscores are only
+// escaped at word boundaries, since intraword ones never start emphasis.
+func escapeMarkdown(text string) string {
+	text = markdownEscaper.Replace(text)
+	if !strings.Contains(text, "_") {
+		return text
+	}
+
+	var out strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] == '_' {
+			before := i > 0 && isWordChar(text[i-1])
+			after := i+1 < len(text) && isWordChar(text[i+1])
+			if !before || !after {
+				out.WriteByte('\\')
+			}
+		}
+		out.WriteByte(text[i])
+	}
+	return out.String()
+}
+
+// markdownBlockStartRe matches paragraph text that would be read as a heading, list, quote or rule
+var markdownBlockStartRe = regexp.MustCompile(`^(#{1,6}(\s|$)|[-+>]\s|\d{1,9}[.)]\s|---)`)
+
+// escapeLineStart escapes text at the start of a paragraph that would
+// otherwise be read as a heading, list, quote or rule
+func escapeLineStart(text string) string {
+	if markdownBlockStartRe.MatchString(text) {
+		return `\` + text
+	}
+	return text
+}
+
+// adfChildren returns the child nodes of an ADF node
+func adfChildren(node map[string]interface{}) []map[string]interface{} {
+	content, _ := node["content"].([]interface{})
+	children := make([]map[string]interface{}, 0, len(content))
+	for _, item := range content {
+		if child, ok := item.(map[string]interface{}); ok {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// isInlineNode reports whether an ADF node is inline content
+func isInlineNode(node map[string]interface{}) bool {
+	switch node["type"] {
+	case "text", "hardBreak", "mention", "emoji", "inlineCard", "status", "date", "mediaInline":
+		return true
+	}
+	return false
+}
+
+// plainTextOf concatenates the text of a node's text children
+func plainTextOf(node map[string]interface{}) string {
+	var result strings.Builder
+	for _, child := range adfChildren(node) {
+		text, _ := child["text"].(string)
+		result.WriteString(text)
+	}
+	return result.String()
+}
+
+// prefixLines prefixes every line of text, e.g. with "> "
+func prefixLines(text, prefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = strings.TrimRight(prefix, " ")
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indentLines indents every line after the first by n spaces
+func indentLines(text string, n int) string {
+	lines := strings.Split(text, "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = strings.Repeat(" ", n) + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatADFDate formats the timestamp (milliseconds since the epoch) of a date node
+func formatADFDate(attrs map[string]interface{}) string {
+	var millis int64
+	switch ts := attrs["timestamp"].(type) {
+	case string:
+		millis, _ = strconv.ParseInt(ts, 10, 64)
+	case float64:
+		millis = int64(ts)
+	}
+	if millis == 0 {
+		return ""
+	}
+	return time.UnixMilli(millis).UTC().Format("2006-01-02")
+}
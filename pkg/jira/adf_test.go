@@ -1,6 +1,7 @@
 package jira
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 )
@@ -637,3 +638,111 @@ func TestADFToPlainText_InvalidInput(t *testing.T) {
 		})
 	}
 }
+
+func TestADFToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "marks",
+			content:  `[{"type":"paragraph","content":[{"type":"text","text":"bold","marks":[{"type":"strong"}]},{"type":"text","text":" and "},{"type":"text","text":"code","marks":[{"type":"code"}]},{"type":"text","text":" and "},{"type":"text","text":"gone","marks":[{"type":"strike"}]}]}]`,
+			expected: "**bold** and `code` and ~~gone~~",
+		},
+		{
+			name:     "link",
+			content:  `[{"type":"paragraph","content":[{"type":"text","text":"docs","marks":[{"type":"link","attrs":{"href":"https://example.com"}}]}]}]`,
+			expected: "[docs](https://example.com)",
+		},
+		{
+			name:     "heading and escaping",
+			content:  `[{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Title"}]},{"type":"paragraph","content":[{"type":"text","text":"a *literal* snake_case"}]}]`,
+			expected: "## Title\n\na \\*literal\\* snake_case",
+		},
+		{
+			name:     "nested list",
+			content:  `[{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"one"}]},{"type":"orderedList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"sub"}]}]}]}]},{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"two"}]}]}]}]`,
+			expected: "- one\n  1. sub\n- two",
+		},
+		{
+			name:     "task list",
+			content:  `[{"type":"taskList","attrs":{"localId":"1"},"content":[{"type":"taskItem","attrs":{"localId":"2","state":"DONE"},"content":[{"type":"text","text":"done"}]},{"type":"taskItem","attrs":{"localId":"3","state":"TODO"},"content":[{"type":"text","text":"todo"}]}]}]`,
+			expected: "- [x] done\n- [ ] todo",
+		},
+		{
+			name:     "code block",
+			content:  `[{"type":"codeBlock","attrs":{"language":"go"},"content":[{"type":"text","text":"x := 1"}]}]`,
+			expected: "```go\nx := 1\n```",
+		},
+		{
+			name:     "table",
+			content:  `[{"type":"table","content":[{"type":"tableRow","content":[{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"A"}]}]},{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"B"}]}]}]},{"type":"tableRow","content":[{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"1"}]}]},{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"a|b"}]}]}]}]}]`,
+			expected: "| A | B |\n| --- | --- |\n| 1 | a\\|b |",
+		},
+		{
+			name:     "panel",
+			content:  `[{"type":"panel","attrs":{"panelType":"warning"},"content":[{"type":"paragraph","content":[{"type":"text","text":"Careful"}]}]}]`,
+			expected: "> [!WARNING]\n> Careful",
+		},
+		{
+			name:     "expand",
+			content:  `[{"type":"expand","attrs":{"title":"Details"},"content":[{"type":"paragraph","content":[{"type":"text","text":"Hidden"}]}]}]`,
+			expected: "<details>\n<summary>Details</summary>\n\nHidden\n\n</details>",
+		},
+		{
+			name:     "inline nodes",
+			content:  `[{"type":"paragraph","content":[{"type":"mention","attrs":{"id":"abc","text":"@Jane Doe"}},{"type":"text","text":" "},{"type":"emoji","attrs":{"shortName":":smile:","text":"😄"}},{"type":"text","text":" "},{"type":"status","attrs":{"text":"In Progress","color":"blue"}},{"type":"text","text":" "},{"type":"inlineCard","attrs":{"url":"https://example.com/x"}},{"type":"text","text":" "},{"type":"date","attrs":{"timestamp":"1700000000000"}}]}]`,
+			expected: "@[Jane Doe] 😄 \\[IN PROGRESS\\] <https://example.com/x> 2023-11-14",
+		},
+		{
+			name:     "hard break",
+			content:  `[{"type":"paragraph","content":[{"type":"text","text":"one"},{"type":"hardBreak"},{"type":"text","text":"two"}]}]`,
+			expected: "one\\\ntwo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc map[string]interface{}
+			if err := json.Unmarshal([]byte(`{"type":"doc","version":1,"content":`+tt.content+`}`), &doc); err != nil {
+				t.Fatalf("Invalid test ADF: %v", err)
+			}
+
+			if got := ADFToMarkdown(doc); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestADFToMarkdown_NilAndString(t *testing.T) {
+	if got := ADFToMarkdown(nil); got != "" {
+		t.Errorf("Expected empty string for nil input, got: %q", got)
+	}
+	if got := ADFToMarkdown("plain"); got != "plain" {
+		t.Errorf("Expected %q, got: %q", "plain", got)
+	}
+}
+
+func TestADFToMarkdown_RoundTrip(t *testing.T) {
+	markdowns := []string{
+		"# Summary\n\nFixed the **login** bug in `auth.go`, see [PR](https://example.com/pr/1).",
+		"- one\n  - nested\n- two\n\n1. first\n2. second",
+		"- [ ] todo\n- [x] done",
+		"> quoted *text*\n\n---\n\n```sh\nmake test\n```",
+		"| Name | Value |\n| --- | --- |\n| a | **b** |",
+	}
+
+	for _, markdown := range markdowns {
+		doc := MarkdownToADF(markdown)
+		got := ADFToMarkdown(doc)
+		if got != markdown {
+			t.Errorf("Expected round trip of %q, got %q", markdown, got)
+			continue
+		}
+		if mustJSON(t, MarkdownToADF(got)) != mustJSON(t, doc) {
+			t.Errorf("Expected %q to convert back to the same ADF", markdown)
+		}
+	}
+}
@@ -0,0 +1,492 @@
+package jira
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+const (
+	// agilePageSize is the page size for board and sprint lists
+	agilePageSize = 50
+	// maxSprintIssues is the maximum number of issues moved to a sprint per request
+	maxSprintIssues = 50
+	// maxRankIssues is the maximum number of issues ranked per request
+	maxRankIssues = 50
+)
+
+// AgileService handles boards, sprints and the backlog through the Jira
+// Software (Agile) API
+type AgileService struct {
+	client *client.Client
+}
+
+// NewAgileService creates a new AgileService
+func NewAgileService(c *client.Client) *AgileService {
+	return &AgileService{client: c}
+}
+
+// ListBoards lists the boards visible to the user, following every page
+// Parameters:
+//   - projectKey: Only boards of this project ("" = all)
+//   - boardType: Only boards of this type: scrum, kanban or simple ("" = all)
+//   - name: Only boards whose name contains this ("" = all)
+func (s *AgileService) ListBoards(projectKey, boardType, name string) ([]models.Board, error) {
+	params := map[string]string{"maxResults": strconv.Itoa(agilePageSize)}
+	if projectKey != "" {
+		params["projectKeyOrId"] = projectKey
+	}
+	if boardType != "" {
+		params["type"] = boardType
+	}
+	if name != "" {
+		params["name"] = name
+	}
+
+	boards := make([]models.Board, 0)
+	for {
+		params["startAt"] = strconv.Itoa(len(boards))
+
+		var page models.BoardsResponse
+		var errorResp models.ErrorResponse
+
+		resp, err := s.client.HTTPClient.R().
+			SetQueryParams(params).
+			SetResult(&page).
+			SetError(&errorResp).
+			Get(s.client.AgileURL("/board"))
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to list boards: %w", err)
+		}
+
+		if resp.IsError() {
+			return nil, formatErrorResponse(resp, &errorResp)
+		}
+
+		boards = append(boards, page.Values...)
+		if page.IsLast || len(page.Values) == 0 {
+			return boards, nil
+		}
+	}
+}
+
+// GetBoard retrieves a board by ID
+func (s *AgileService) GetBoard(boardID int) (*models.Board, error) {
+	var board models.Board
+	var errorResp models.ErrorResponse
+
+	resp, err := s.client.HTTPClient.R().
+		SetResult(&board).
+		SetError(&errorResp).
+		Get(s.client.AgileURL(fmt.Sprintf("/board/%d", boardID)))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board %d: %w", boardID, err)
+	}
+
+	if resp.IsError() {
+		if resp.StatusCode() == 404 {
+			return nil, client.NewError(resp.StatusCode(), fmt.Sprintf("board %d not found", boardID))
+		}
+		return nil, formatErrorResponse(resp, &errorResp)
+	}
+
+	return &board, nil
+}
+
+// FindBoard finds a board by ID or by name (case-insensitive)
+func (s *AgileService) FindBoard(nameOrID string) (*models.Board, error) {
+	if strings.TrimSpace(nameOrID) == "" {
+		return nil, client.NewValidationError("board cannot be empty", map[string]string{"board": "board cannot be empty"})
+	}
+	if id, err := strconv.Atoi(nameOrID); err == nil {
+		return s.GetBoard(id)
+	}
+
+	boards, err := s.ListBoards("", "", nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(boards))
+	for i, board := range boards {
+		names[i] = board.Name
+		if strings.EqualFold(board.Name, nameOrID) {
+			return &boards[i], nil
+		}
+	}
+
+	msg := fmt.Sprintf("board '%s' not found", nameOrID)
+	return nil, client.NewAllowedValuesError("board", msg, names)
+}
+
+// ListSprints lists the sprints of a board, following every page
+// Parameters:
+//   - boardID: The board (must be a scrum board)
+//   - states: Only sprints in these states: future, active or closed (none = all)
+func (s *AgileService) ListSprints(boardID int, states ...string) ([]models.Sprint, error) {
+	params := map[string]string{"maxResults": strconv.Itoa(agilePageSize)}
+	if len(states) > 0 {
+		params["state"] = strings.Join(states, ",")
+	}
+
+	sprints := make([]models.Sprint, 0)
+	for {
+		params["startAt"] = strconv.Itoa(len(sprints))
+
+		var page models.SprintsResponse
+		var errorResp models.ErrorResponse
+
+		resp, err := s.client.HTTPClient.R().
+			SetQueryParams(params).
+			SetResult(&page).
+			SetError(&errorResp).
+			Get(s.client.AgileURL(fmt.Sprintf("/board/%d/sprint", boardID)))
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to list sprints: %w", err)
+		}
+
+		if resp.IsError() {
+			if resp.StatusCode() == 404 {
+				return nil, client.NewError(resp.StatusCode(), fmt.Sprintf("board %d not found", boardID))
+			}
+			return nil, formatErrorResponse(resp, &errorResp)
+		}
+
+		sprints = append(sprints, page.Values...)
+		if page.IsLast || len(page.Values) == 0 {
+			return sprints, nil
+		}
+	}
+}
+
+// GetSprint retrieves a sprint by ID
+func (s *AgileService) GetSprint(sprintID int) (*models.Sprint, error) {
+	var sprint models.Sprint
+	var errorResp models.ErrorResponse
+
+	resp, err := s.client.HTTPClient.R().
+		SetResult(&sprint).
+		SetError(&errorResp).
+		Get(s.client.AgileURL(fmt.Sprintf("/sprint/%d", sprintID)))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sprint %d: %w", sprintID, err)
+	}
+
+	if resp.IsError() {
+		if resp.StatusCode() == 404 {
+			return nil, client.NewError(resp.StatusCode(), fmt.Sprintf("sprint %d not found", sprintID))
+		}
+		return nil, formatErrorResponse(resp, &errorResp)
+	}
+
+	return &sprint, nil
+}
+
+// FindSprint finds a sprint by ID, or by name (case-insensitive) among the
+// sprints of a board
+// Parameters:
+//   - boardID: The board to look up names on (0 = IDs only)
+//   - nameOrID: Sprint ID or name
+func (s *AgileService) FindSprint(boardID int, nameOrID string) (*models.Sprint, error) {
+	if id, err := strconv.Atoi(nameOrID); err == nil {
+		return s.GetSprint(id)
+	}
+	if boardID == 0 {
+		msg := fmt.Sprintf("sprint '%s' is not an ID; a board is needed to look it up by name", nameOrID)
+		err := client.NewValidationError(msg, map[string]string{"board": msg})
+		err.Hints = []string{"Pass --board, or use the sprint ID from 'jcfa sprint list'"}
+		return nil, err
+	}
+
+	sprints, err := s.ListSprints(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(sprints))
+	for i, sprint := range sprints {
+		names[i] = sprint.Name
+		if strings.EqualFold(sprint.Name, nameOrID) {
+			return &sprints[i], nil
+		}
+	}
+
+	msg := fmt.Sprintf("sprint '%s' not found on board %d", nameOrID, boardID)
+	return nil, client.NewAllowedValuesError("sprint", msg, names)
+}
+
+// CreateSprint creates a future sprint on a board
+// Parameters:
+//   - boardID: The board the sprint belongs to
+//   - name: Sprint name
+//   - startDate: Planned start (ISO 8601; "" = unset)
+//   - endDate: Planned end (ISO 8601; "" = unset)
+//   - goal: Sprint goal ("" = none)
+func (s *AgileService) CreateSprint(boardID int, name, startDate, endDate, goal string) (*models.Sprint, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, client.NewValidationError("sprint name cannot be empty", map[string]string{"name": "sprint name cannot be empty"})
+	}
+
+	body := map[string]interface{}{
+		"name":          name,
+		"originBoardId": boardID,
+	}
+	if startDate != "" {
+		body["startDate"] = startDate
+	}
+	if endDate != "" {
+		body["endDate"] = endDate
+	}
+	if goal != "" {
+		body["goal"] = goal
+	}
+
+	var sprint models.Sprint
+	var errorResp models.ErrorResponse
+
+	resp, err := s.client.HTTPClient.R().
+		SetBody(body).
+		SetResult(&sprint).
+		SetError(&errorResp).
+		Post(s.client.AgileURL("/sprint"))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sprint: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, formatErrorResponse(resp, &errorResp)
+	}
+
+	return &sprint, nil
+}
+
+// UpdateSprint changes some of a sprint's fields; setting state starts
+// ("active") or closes ("closed") it
+// Parameters:
+//   - sprintID: The sprint to update
+//   - changes: Fields to change (name, state, startDate, endDate, goal)
+func (s *AgileService) UpdateSprint(sprintID int, changes map[string]interface{}) (*models.Sprint, error) {
+	var sprint models.Sprint
+	var errorResp models.ErrorResponse
+
+	resp, err := s.client.HTTPClient.R().
+		SetBody(changes).
+		SetResult(&sprint).
+		SetError(&errorResp).
+		Post(s.client.AgileURL(fmt.Sprintf("/sprint/%d", sprintID)))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to update sprint %d: %w", sprintID, err)
+	}
+
+	if resp.IsError() {
+		if resp.StatusCode() == 404 {
+			return nil, client.NewError(resp.StatusCode(), fmt.Sprintf("sprint %d not found", sprintID))
+		}
+		return nil, formatErrorResponse(resp, &errorResp)
+	}
+
+	return &sprint, nil
+}
+
+// MoveIssuesToSprint moves issues to a sprint, from the backlog or another
+// sprint, in requests of up to 50 issues
+// Parameters:
+//   - sprintID: The sprint to move the issues to (must be future or active)
+//   - issueKeys: Keys of the issues to move
+func (s *AgileService) MoveIssuesToSprint(sprintID int, issueKeys []string) error {
+	if len(issueKeys) == 0 {
+		return fmt.Errorf("no issues to move")
+	}
+
+	for start := 0; start < len(issueKeys); start += maxSprintIssues {
+		end := start + maxSprintIssues
+		if end > len(issueKeys) {
+			end = len(issueKeys)
+		}
+
+		var errorResp models.ErrorResponse
+
+		resp, err := s.client.HTTPClient.R().
+			SetBody(map[string]interface{}{"issues": issueKeys[start:end]}).
+			SetError(&errorResp).
+			Post(s.client.AgileURL(fmt.Sprintf("/sprint/%d/issue", sprintID)))
+
+		if err != nil {
+			return fmt.Errorf("failed to move issues to sprint %d: %w", sprintID, err)
+		}
+
+		if resp.IsError() {
+			return formatErrorResponse(resp, &errorResp)
+		}
+	}
+
+	return nil
+}
+
+// GetBacklog retrieves the issues in a board's backlog, in rank order
+// Parameters:
+//   - boardID: The board
+//   - maxResults: Maximum number of issues to return
+func (s *AgileService) GetBacklog(boardID, maxResults int) (*models.SearchResponse, error) {
+	var result models.SearchResponse
+	var errorResp models.ErrorResponse
+
+	resp, err := s.client.HTTPClient.R().
+		SetQueryParams(map[string]string{
+			"maxResults": strconv.Itoa(maxResults),
+			"fields":     "summary,status,issuetype,assignee,priority",
+		}).
+		SetResult(&result).
+		SetError(&errorResp).
+		Get(s.client.AgileURL(fmt.Sprintf("/board/%d/backlog", boardID)))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backlog of board %d: %w", boardID, err)
+	}
+
+	if resp.IsError() {
+		if resp.StatusCode() == 404 {
+			return nil, client.NewError(resp.StatusCode(), fmt.Sprintf("board %d not found", boardID))
+		}
+		return nil, formatErrorResponse(resp, &errorResp)
+	}
+
+	return &result, nil
+}
+
+// GetIssueSprint retrieves the open (active or future) sprint an issue is in
+func (s *AgileService) GetIssueSprint(issueKey string) (*models.Sprint, error) {
+	var issue struct {
+		Fields struct {
+			Sprint *models.Sprint `json:"sprint"`
+		} `json:"fields"`
+	}
+	var errorResp models.ErrorResponse
+
+	resp, err := s.client.HTTPClient.R().
+		SetQueryParam("fields", "sprint").
+		SetResult(&issue).
+		SetError(&errorResp).
+		Get(s.client.AgileURL(fmt.Sprintf("/issue/%s", issueKey)))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sprint of %s: %w", issueKey, err)
+	}
+
+	if resp.IsError() {
+		if resp.StatusCode() == 404 {
+			return nil, client.NewError(resp.StatusCode(), fmt.Sprintf("issue '%s' not found", issueKey))
+		}
+		return nil, formatErrorResponse(resp, &errorResp)
+	}
+
+	if issue.Fields.Sprint == nil {
+		msg := fmt.Sprintf("issue %s is not in an open sprint", issueKey)
+		return nil, client.NewValidationError(msg, map[string]string{"sprint": msg})
+	}
+	return issue.Fields.Sprint, nil
+}
+
+// GetSprintIssues retrieves the issues in a sprint, in rank order
+// Parameters:
+//   - sprintID: The sprint
+//   - maxResults: Maximum number of issues to return
+func (s *AgileService) GetSprintIssues(sprintID, maxResults int) (*models.SearchResponse, error) {
+	var result models.SearchResponse
+	var errorResp models.ErrorResponse
+
+	resp, err := s.client.HTTPClient.R().
+		SetQueryParams(map[string]string{
+			"jql":        "ORDER BY Rank ASC",
+			"maxResults": strconv.Itoa(maxResults),
+			"fields":     "summary,status",
+		}).
+		SetResult(&result).
+		SetError(&errorResp).
+		Get(s.client.AgileURL(fmt.Sprintf("/sprint/%d/issue", sprintID)))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issues of sprint %d: %w", sprintID, err)
+	}
+
+	if resp.IsError() {
+		if resp.StatusCode() == 404 {
+			return nil, client.NewError(resp.StatusCode(), fmt.Sprintf("sprint %d not found", sprintID))
+		}
+		return nil, formatErrorResponse(resp, &errorResp)
+	}
+
+	return &result, nil
+}
+
+// RankIssues moves issues, in the given order, directly before or after
+// another issue, in requests of up to 50 issues. It returns the outcome for
+// each issue ranked so far; Jira can rank some issues of a request and not
+// others. A request that fails as a whole stops ranking and returns its
+// error along with the earlier outcomes.
+// Parameters:
+//   - issueKeys: The issues to rank
+//   - beforeKey: Rank the issues before this one ("" = use afterKey)
+//   - afterKey: Rank the issues after this one
+func (s *AgileService) RankIssues(issueKeys []string, beforeKey, afterKey string) ([]models.RankEntry, error) {
+	if (beforeKey == "") == (afterKey == "") {
+		return nil, fmt.Errorf("exactly one of the before and after issues must be given")
+	}
+
+	entries := make([]models.RankEntry, 0, len(issueKeys))
+	for start := 0; start < len(issueKeys); start += maxRankIssues {
+		end := start + maxRankIssues
+		if end > len(issueKeys) {
+			end = len(issueKeys)
+		}
+		chunk := issueKeys[start:end]
+
+		body := map[string]interface{}{"issues": chunk}
+		if beforeKey != "" {
+			// Later chunks land between the earlier ones and beforeKey
+			body["rankBeforeIssue"] = beforeKey
+		} else {
+			// Later chunks follow the last issue of the previous one
+			body["rankAfterIssue"] = afterKey
+			afterKey = chunk[len(chunk)-1]
+		}
+
+		var result models.RankResponse
+		var errorResp models.ErrorResponse
+
+		resp, err := s.client.HTTPClient.R().
+			SetBody(body).
+			SetResult(&result).
+			SetError(&errorResp).
+			Put(s.client.AgileURL("/issue/rank"))
+
+		if err != nil {
+			return entries, fmt.Errorf("failed to rank issues: %w", err)
+		}
+
+		if resp.IsError() {
+			return entries, formatErrorResponse(resp, &errorResp)
+		}
+
+		// 204: every issue was ranked; 207: outcomes per issue
+		if resp.StatusCode() != http.StatusMultiStatus {
+			for _, key := range chunk {
+				entries = append(entries, models.RankEntry{IssueKey: key, Status: resp.StatusCode()})
+			}
+			continue
+		}
+		entries = append(entries, result.Entries...)
+	}
+
+	return entries, nil
+}
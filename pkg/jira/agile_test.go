@@ -0,0 +1,208 @@
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+func TestListBoards_FollowsPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/agile/1.0/board" {
+			t.Errorf("Expected the Agile board endpoint, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("projectKeyOrId"); got != "PROJ" {
+			t.Errorf("Expected projectKeyOrId=PROJ, got %q", got)
+		}
+
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		page := models.BoardsResponse{StartAt: startAt, IsLast: startAt > 0}
+		page.Values = []models.Board{{ID: startAt + 1, Name: "Board " + strconv.Itoa(startAt+1)}}
+		writeJSON(w, page)
+	}))
+	defer server.Close()
+
+	svc := NewAgileService(newTestClient(server.URL))
+	boards, err := svc.ListBoards("PROJ", "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(boards) != 2 || boards[1].ID != 2 {
+		t.Errorf("Expected two pages of boards, got %+v", boards)
+	}
+}
+
+func TestFindSprint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/agile/1.0/sprint/42":
+			writeJSON(w, models.Sprint{ID: 42, Name: "Sprint 12", State: "future"})
+		case "/rest/agile/1.0/board/7/sprint":
+			writeJSON(w, models.SprintsResponse{IsLast: true, Values: []models.Sprint{
+				{ID: 41, Name: "Sprint 11", State: "active"},
+				{ID: 42, Name: "Sprint 12", State: "future"},
+			}})
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	svc := NewAgileService(newTestClient(server.URL))
+
+	sprint, err := svc.FindSprint(0, "42")
+	if err != nil || sprint.Name != "Sprint 12" {
+		t.Errorf("Expected sprint 42 by ID, got %+v (%v)", sprint, err)
+	}
+
+	sprint, err = svc.FindSprint(7, "sprint 11")
+	if err != nil || sprint.ID != 41 {
+		t.Errorf("Expected sprint 41 by name, got %+v (%v)", sprint, err)
+	}
+
+	var validationErr *client.ValidationError
+	if _, err := svc.FindSprint(7, "Sprint 13"); !errors.As(err, &validationErr) || len(validationErr.AllowedValues["sprint"]) != 2 {
+		t.Errorf("Expected the board's sprints for an unknown name, got %v", err)
+	}
+	if _, err := svc.FindSprint(0, "Sprint 12"); !errors.As(err, &validationErr) || len(validationErr.Hints) == 0 {
+		t.Errorf("Expected a hint to pass --board for a name without a board, got %v", err)
+	}
+}
+
+func TestMoveIssuesToSprint_Batches(t *testing.T) {
+	var batches []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/agile/1.0/sprint/42/issue" {
+			t.Errorf("Unexpected %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Issues []string `json:"issues"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		batches = append(batches, len(body.Issues))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	keys := make([]string, 120)
+	for i := range keys {
+		keys[i] = "PROJ-" + strconv.Itoa(i+1)
+	}
+
+	svc := NewAgileService(newTestClient(server.URL))
+	if err := svc.MoveIssuesToSprint(42, keys); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(batches) != 3 || batches[0] != 50 || batches[2] != 20 {
+		t.Errorf("Expected batches of 50, 50 and 20, got %v", batches)
+	}
+}
+
+func TestCreateSprint_Body(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		got, _ := json.Marshal(body)
+		expected := `{"goal":"Ship search","name":"Sprint 12","originBoardId":7}`
+		if string(got) != expected {
+			t.Errorf("Expected body %s, got %s", expected, got)
+		}
+		writeJSON(w, models.Sprint{ID: 43, Name: "Sprint 12", State: "future"})
+	}))
+	defer server.Close()
+
+	svc := NewAgileService(newTestClient(server.URL))
+	sprint, err := svc.CreateSprint(7, "Sprint 12", "", "", "Ship search")
+	if err != nil || sprint.ID != 43 {
+		t.Errorf("Expected the created sprint, got %+v (%v)", sprint, err)
+	}
+}
+
+func TestRankIssues_PartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/rest/agile/1.0/issue/rank" {
+			t.Errorf("Unexpected %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["rankBeforeIssue"] != "PROJ-2" || body["rankAfterIssue"] != nil {
+			t.Errorf("Expected the issues ranked before PROJ-2, got %v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMultiStatus)
+		json.NewEncoder(w).Encode(models.RankResponse{Entries: []models.RankEntry{
+			{IssueID: 10005, IssueKey: "PROJ-5", Status: 200},
+			{IssueID: 10006, IssueKey: "PROJ-6", Status: 403, Errors: []string{"You don't have permission to rank PROJ-6"}},
+		}})
+	}))
+	defer server.Close()
+
+	svc := NewAgileService(newTestClient(server.URL))
+	entries, err := svc.RankIssues([]string{"PROJ-5", "PROJ-6"}, "PROJ-2", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Status != 200 || entries[1].Status != 403 || len(entries[1].Errors) != 1 {
+		t.Errorf("Expected PROJ-5 ranked and PROJ-6 failed, got %+v", entries)
+	}
+}
+
+func TestRankIssues_AfterBatches(t *testing.T) {
+	var anchors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Issues []string `json:"issues"`
+			After  string   `json:"rankAfterIssue"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		anchors = append(anchors, body.After+":"+strconv.Itoa(len(body.Issues)))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	keys := make([]string, 60)
+	for i := range keys {
+		keys[i] = "PROJ-" + strconv.Itoa(i+10)
+	}
+
+	svc := NewAgileService(newTestClient(server.URL))
+	entries, err := svc.RankIssues(keys, "", "PROJ-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(entries) != 60 || entries[59].IssueKey != "PROJ-69" || entries[59].Status != http.StatusNoContent {
+		t.Errorf("Expected an outcome for every issue, got %d", len(entries))
+	}
+	// The second batch follows the last issue of the first
+	if len(anchors) != 2 || anchors[0] != "PROJ-1:50" || anchors[1] != "PROJ-59:10" {
+		t.Errorf("Expected batches after PROJ-1 and PROJ-59, got %v", anchors)
+	}
+
+	if _, err := svc.RankIssues(keys, "PROJ-1", "PROJ-2"); err == nil {
+		t.Errorf("Expected an error with both before and after issues")
+	}
+}
+
+func TestGetIssueSprint_NotInSprint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/agile/1.0/issue/PROJ-5" || r.URL.Query().Get("fields") != "sprint" {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+		writeJSON(w, map[string]interface{}{"key": "PROJ-5", "fields": map[string]interface{}{}})
+	}))
+	defer server.Close()
+
+	svc := NewAgileService(newTestClient(server.URL))
+	var validationErr *client.ValidationError
+	if _, err := svc.GetIssueSprint("PROJ-5"); !errors.As(err, &validationErr) {
+		t.Errorf("Expected a validation error for an issue in no sprint, got %v", err)
+	}
+}
@@ -46,9 +46,9 @@ func (s *AttachmentService) ListAttachments(issueKey string) ([]models.Attachmen
 
 	if resp.IsError() {
 		if resp.StatusCode() == 404 {
-			return nil, fmt.Errorf("issue '%s' not found", issueKey)
+			return nil, client.NewError(resp.StatusCode(), fmt.Sprintf("issue '%s' not found", issueKey))
 		}
-		return nil, fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return nil, formatErrorResponse(resp, &errorResp)
 	}
 
 	// Extract attachments from fields
@@ -119,7 +119,8 @@ func (s *AttachmentService) UploadAttachment(issueKey, filePath string, showProg
 	var result []models.Attachment
 	var errorResp models.ErrorResponse
 
-	resp, err := s.client.HTTPClient.R().
+	// The file is streamed, so the request body can't be replayed on retry
+	resp, err := client.DisableRetry(s.client.HTTPClient.R()).
 		SetHeader("X-Atlassian-Token", "no-check").
 		SetFileReader("file", filepath.Base(filePath), reader).
 		SetResult(&result).
@@ -131,7 +132,7 @@ func (s *AttachmentService) UploadAttachment(issueKey, filePath string, showProg
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return nil, formatErrorResponse(resp, &errorResp)
 	}
 
 	if len(result) == 0 {
@@ -187,7 +188,7 @@ func (s *AttachmentService) DownloadAttachment(attachment *models.Attachment, ou
 	defer resp.RawBody().Close()
 
 	if resp.IsError() {
-		return fmt.Errorf("download failed with status: %s", resp.Status())
+		return client.NewError(resp.StatusCode(), fmt.Sprintf("download failed with status: %s", resp.Status()))
 	}
 
 	// Copy with or without progress bar
@@ -229,12 +230,12 @@ func (s *AttachmentService) DeleteAttachment(attachmentID string) error {
 
 	if resp.IsError() {
 		if resp.StatusCode() == 404 {
-			return fmt.Errorf("attachment '%s' not found", attachmentID)
+			return client.NewError(resp.StatusCode(), fmt.Sprintf("attachment '%s' not found", attachmentID))
 		}
 		if resp.StatusCode() == 403 {
-			return fmt.Errorf("you don't have permission to delete this attachment")
+			return client.NewError(resp.StatusCode(), "you don't have permission to delete this attachment")
 		}
-		return fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return formatErrorResponse(resp, &errorResp)
 	}
 
 	return nil
@@ -0,0 +1,294 @@
+package jira
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+const (
+	// sprintFieldType is the custom type of the Sprint field, which is an
+	// array in the schema but is set to a single sprint ID
+	sprintFieldType = "com.pyxis.greenhopper.jira:gh-sprint"
+
+	// textAreaFieldType is the custom type of multi-line text fields, which
+	// hold rich text (ADF) like the description
+	textAreaFieldType = "com.atlassian.jira.plugin.system.customfieldtypes:textarea"
+
+	// labelsFieldType is the custom type of label fields
+	labelsFieldType = "com.atlassian.jira.plugin.system.customfieldtypes:labels"
+
+	// jiraDateTimeLayout is the datetime format Jira expects
+	jiraDateTimeLayout = "2006-01-02T15:04:05.000-0700"
+)
+
+// dateTimeLayouts are the datetime formats accepted on the command line
+var dateTimeLayouts = []string{
+	jiraDateTimeLayout,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// UserLookup resolves a user reference (account ID, email, display name or
+// "me") for a user field; UserService.ResolveUser satisfies it
+type UserLookup func(field, value string) (*models.User, error)
+
+// IsRichTextSchema checks if a field's schema holds rich text (ADF): the
+// description and environment fields and multi-line text custom fields
+func IsRichTextSchema(schema models.FieldSchema) bool {
+	return IsRichTextField(schema.System) || schema.Custom == textAreaFieldType
+}
+
+// CoerceFieldValue converts a value given on the command line into the JSON
+// shape Jira expects for a field, using the schema and allowed values from
+// create or edit metadata. Values outside the allowed values are an error
+// listing them.
+// Parameters:
+//   - fieldID: The field being set, used in errors
+//   - meta: The field's metadata
+//   - value: The value; "" or "null" clears the field. Multi-value fields
+//     take a comma-separated list, cascading selects "Parent > Child".
+//   - lookupUser: Resolves user references for user fields
+func CoerceFieldValue(fieldID string, meta models.FieldMeta, value string, lookupUser UserLookup) (interface{}, error) {
+	value = strings.TrimSpace(value)
+	empty := value == "" || value == "null"
+
+	if meta.Schema.Custom == sprintFieldType {
+		if empty {
+			return nil, nil
+		}
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fieldError(fieldID, "field '%s' (%s) expects a sprint ID, got '%s'", meta.Name, fieldID, value)
+		}
+		return id, nil
+	}
+
+	if meta.Schema.Type == "array" {
+		items := make([]interface{}, 0)
+		if empty {
+			return items, nil
+		}
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part == "" {
+				continue
+			}
+			item, err := coerceItem(fieldID, meta, meta.Schema.Items, part, lookupUser)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+
+	if empty {
+		return nil, nil
+	}
+	return coerceItem(fieldID, meta, meta.Schema.Type, value, lookupUser)
+}
+
+// coerceItem converts a single value of a schema type (the field type, or
+// the item type of a multi-value field)
+func coerceItem(fieldID string, meta models.FieldMeta, schemaType, value string, lookupUser UserLookup) (interface{}, error) {
+	switch schemaType {
+	case "string":
+		if IsRichTextSchema(meta.Schema) {
+			return PlainTextToADF(value), nil
+		}
+		if (meta.Schema.System == "labels" || meta.Schema.Custom == labelsFieldType) && strings.ContainsAny(value, " \t") {
+			return nil, fieldError(fieldID, "field '%s' (%s): label '%s' cannot contain spaces", meta.Name, fieldID, value)
+		}
+		return value, nil
+
+	case "number":
+		num, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fieldError(fieldID, "field '%s' (%s) expects a number, got '%s'", meta.Name, fieldID, value)
+		}
+		return num, nil
+
+	case "date":
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return nil, fieldError(fieldID, "field '%s' (%s) expects a date (YYYY-MM-DD), got '%s'", meta.Name, fieldID, value)
+		}
+		return value, nil
+
+	case "datetime":
+		return coerceDateTime(fieldID, meta, value)
+
+	case "user":
+		if lookupUser == nil {
+			if !IsAccountID(value) {
+				return nil, fieldError(fieldID, "field '%s' (%s) expects an account ID, got '%s'", meta.Name, fieldID, value)
+			}
+			return map[string]interface{}{"accountId": value}, nil
+		}
+		user, err := lookupUser(fieldID, value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"accountId": user.AccountID}, nil
+
+	case "option":
+		return allowedValue(fieldID, meta, meta.AllowedValues, value, "value")
+
+	case "option-with-child":
+		return cascadingValue(fieldID, meta, value)
+
+	case "project":
+		return allowedValue(fieldID, meta, meta.AllowedValues, value, "key")
+
+	case "priority", "resolution", "issuetype", "status", "securitylevel", "component", "version", "group":
+		return allowedValue(fieldID, meta, meta.AllowedValues, value, "name")
+
+	case "issuelink":
+		// The parent field
+		return map[string]interface{}{"key": value}, nil
+
+	case "timetracking":
+		return timeTrackingValue(fieldID, meta, value)
+
+	case "attachment", "issuelinks", "comments-page", "worklog", "watches", "votes", "progress":
+		return nil, fieldError(fieldID, "field '%s' (%s) cannot be set with update", meta.Name, fieldID)
+
+	default:
+		if len(meta.AllowedValues) > 0 {
+			return allowedValue(fieldID, meta, meta.AllowedValues, value, "name")
+		}
+		return value, nil
+	}
+}
+
+// coerceDateTime converts a datetime in one of dateTimeLayouts, or a date
+// (midnight local time), to Jira's datetime format
+func coerceDateTime(fieldID string, meta models.FieldMeta, value string) (interface{}, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t.Format(jiraDateTimeLayout), nil
+	}
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t.Format(jiraDateTimeLayout), nil
+		}
+	}
+	return nil, fieldError(fieldID, "field '%s' (%s) expects a datetime (e.g., 2024-05-01T14:30 or 2024-05-01T14:30:00+02:00), got '%s'", meta.Name, fieldID, value)
+}
+
+// allowedValue matches a value against a field's allowed values by ID or
+// (case-insensitively) by value, key or name, and returns the matching value
+// identified by attr (e.g., {"value": "Red"} for select lists, or {"id": ...}
+// when the value was given as an ID). Without allowed values the value is
+// passed through as {attr: value}.
+func allowedValue(fieldID string, meta models.FieldMeta, allowed []interface{}, value, attr string) (interface{}, error) {
+	if len(allowed) == 0 {
+		return map[string]interface{}{attr: value}, nil
+	}
+
+	for _, option := range allowed {
+		optionMap, ok := option.(map[string]interface{})
+		if !ok || optionMap["disabled"] == true {
+			continue
+		}
+		if id, ok := optionMap["id"].(string); ok && id == value {
+			return map[string]interface{}{"id": id}, nil
+		}
+		for _, key := range []string{"value", "key", "name"} {
+			label, ok := optionMap[key].(string)
+			if !ok || !strings.EqualFold(label, value) {
+				continue
+			}
+			if canonical, ok := optionMap[attr].(string); ok {
+				return map[string]interface{}{attr: canonical}, nil
+			}
+			if id, ok := optionMap["id"].(string); ok {
+				return map[string]interface{}{"id": id}, nil
+			}
+			return map[string]interface{}{attr: label}, nil
+		}
+	}
+
+	labels := allowedLabels(allowed)
+	msg := fmt.Sprintf("field '%s' (%s) value '%s' not in allowed values: %v", meta.Name, fieldID, value, labels)
+	return nil, client.NewAllowedValuesError(fieldID, msg, labels)
+}
+
+// cascadingValue converts "Parent > Child" (or just "Parent") for a
+// cascading select field
+func cascadingValue(fieldID string, meta models.FieldMeta, value string) (interface{}, error) {
+	parts := strings.SplitN(value, ">", 2)
+	parentName := strings.TrimSpace(parts[0])
+
+	parent, err := allowedValue(fieldID, meta, meta.AllowedValues, parentName, "value")
+	if err != nil {
+		return nil, err
+	}
+	result := parent.(map[string]interface{})
+	if len(parts) == 1 {
+		return result, nil
+	}
+
+	// Children are listed under the matching parent option
+	var children []interface{}
+	for _, option := range meta.AllowedValues {
+		optionMap, ok := option.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if optionMap["id"] == result["id"] || optionMap["value"] == result["value"] {
+			children, _ = optionMap["children"].([]interface{})
+			break
+		}
+	}
+
+	child, err := allowedValue(fieldID, meta, children, strings.TrimSpace(parts[1]), "value")
+	if err != nil {
+		return nil, err
+	}
+	result["child"] = child
+	return result, nil
+}
+
+// timeTrackingValue converts "2d" (the original estimate) or
+// "originalEstimate=2d,remainingEstimate=1d" for the time tracking field
+func timeTrackingValue(fieldID string, meta models.FieldMeta, value string) (interface{}, error) {
+	if !strings.Contains(value, "=") {
+		return map[string]interface{}{"originalEstimate": value}, nil
+	}
+
+	result := make(map[string]interface{})
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		key := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || (key != "originalEstimate" && key != "remainingEstimate") {
+			return nil, fieldError(fieldID, "field '%s' (%s) expects an estimate (e.g., 2d) or originalEstimate=2d,remainingEstimate=1d, got '%s'", meta.Name, fieldID, value)
+		}
+		result[key] = strings.TrimSpace(parts[1])
+	}
+	return result, nil
+}
+
+// allowedLabels returns the display labels of allowed values (their value,
+// key or name), skipping disabled options
+func allowedLabels(allowed []interface{}) []string {
+	labels := make([]string, 0, len(allowed))
+	for _, option := range allowed {
+		optionMap, ok := option.(map[string]interface{})
+		if !ok || optionMap["disabled"] == true {
+			continue
+		}
+		for _, key := range []string{"value", "key", "name", "id"} {
+			if label, ok := optionMap[key].(string); ok {
+				labels = append(labels, label)
+				break
+			}
+		}
+	}
+	return labels
+}
@@ -0,0 +1,235 @@
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+// coerceTestFields is edit metadata covering the schema types
+var coerceTestFields = map[string]models.FieldMeta{
+	"summary":  {Name: "Summary", Schema: models.FieldSchema{Type: "string", System: "summary"}},
+	"labels":   {Name: "Labels", Schema: models.FieldSchema{Type: "array", Items: "string", System: "labels"}},
+	"duedate":  {Name: "Due date", Schema: models.FieldSchema{Type: "date", System: "duedate"}},
+	"parent":   {Name: "Parent", Schema: models.FieldSchema{Type: "issuelink", System: "parent"}},
+	"assignee": {Name: "Assignee", Schema: models.FieldSchema{Type: "user", System: "assignee"}},
+	"priority": {
+		Name:   "Priority",
+		Schema: models.FieldSchema{Type: "priority", System: "priority"},
+		AllowedValues: []interface{}{
+			map[string]interface{}{"id": "1", "name": "High"},
+			map[string]interface{}{"id": "2", "name": "Low"},
+		},
+	},
+	"components": {
+		Name:   "Components",
+		Schema: models.FieldSchema{Type: "array", Items: "component", System: "components"},
+		AllowedValues: []interface{}{
+			map[string]interface{}{"id": "10", "name": "Backend"},
+			map[string]interface{}{"id": "11", "name": "API"},
+		},
+	},
+	"timetracking": {Name: "Time tracking", Schema: models.FieldSchema{Type: "timetracking", System: "timetracking"}},
+	"customfield_10001": {
+		Name:   "Color",
+		Schema: models.FieldSchema{Type: "option", Custom: "com.atlassian.jira.plugin.system.customfieldtypes:select"},
+		AllowedValues: []interface{}{
+			map[string]interface{}{"id": "100", "value": "Red"},
+			map[string]interface{}{"id": "101", "value": "Blue"},
+			map[string]interface{}{"id": "102", "value": "Green", "disabled": true},
+		},
+	},
+	"customfield_10002": {
+		Name:   "Platforms",
+		Schema: models.FieldSchema{Type: "array", Items: "option", Custom: "com.atlassian.jira.plugin.system.customfieldtypes:multiselect"},
+		AllowedValues: []interface{}{
+			map[string]interface{}{"id": "200", "value": "iOS"},
+			map[string]interface{}{"id": "201", "value": "Android"},
+		},
+	},
+	"customfield_10003": {
+		Name:   "Region",
+		Schema: models.FieldSchema{Type: "option-with-child", Custom: "com.atlassian.jira.plugin.system.customfieldtypes:cascadingselect"},
+		AllowedValues: []interface{}{
+			map[string]interface{}{"id": "300", "value": "EMEA", "children": []interface{}{
+				map[string]interface{}{"id": "301", "value": "Germany"},
+				map[string]interface{}{"id": "302", "value": "France"},
+			}},
+		},
+	},
+	"customfield_10004": {Name: "Story Points", Schema: models.FieldSchema{Type: "number", Custom: "com.atlassian.jira.plugin.system.customfieldtypes:float"}},
+	"customfield_10005": {Name: "Sprint", Schema: models.FieldSchema{Type: "array", Items: "json", Custom: sprintFieldType}},
+	"customfield_10006": {Name: "Reviewers", Schema: models.FieldSchema{Type: "array", Items: "user", Custom: "com.atlassian.jira.plugin.system.customfieldtypes:multiuserpicker"}},
+	"customfield_10007": {Name: "Go-live", Schema: models.FieldSchema{Type: "datetime", Custom: "com.atlassian.jira.plugin.system.customfieldtypes:datetime"}},
+}
+
+func TestCoerceFieldValue(t *testing.T) {
+	lookupUser := func(field, value string) (*models.User, error) {
+		return &models.User{AccountID: "id-" + value}, nil
+	}
+	tests := []struct {
+		field    string
+		value    string
+		expected string
+	}{
+		{"summary", "New title", `"New title"`},
+		{"summary", "", `null`},
+		{"labels", "a, b,,c", `["a","b","c"]`},
+		{"labels", "", `[]`},
+		{"duedate", "2024-06-30", `"2024-06-30"`},
+		{"parent", "PROJ-1", `{"key":"PROJ-1"}`},
+		{"assignee", "alice", `{"accountId":"id-alice"}`},
+		{"priority", "high", `{"name":"High"}`},
+		{"priority", "2", `{"id":"2"}`},
+		{"components", "backend,API", `[{"name":"Backend"},{"name":"API"}]`},
+		{"timetracking", "2d", `{"originalEstimate":"2d"}`},
+		{"timetracking", "originalEstimate=2d,remainingEstimate=1d", `{"originalEstimate":"2d","remainingEstimate":"1d"}`},
+		{"customfield_10001", "red", `{"value":"Red"}`},
+		{"customfield_10002", "iOS,Android", `[{"value":"iOS"},{"value":"Android"}]`},
+		{"customfield_10003", "EMEA > germany", `{"child":{"value":"Germany"},"value":"EMEA"}`},
+		{"customfield_10003", "EMEA", `{"value":"EMEA"}`},
+		{"customfield_10004", "8", `8`},
+		{"customfield_10004", "2.5", `2.5`},
+		{"customfield_10005", "42", `42`},
+		{"customfield_10006", "alice,bob", `[{"accountId":"id-alice"},{"accountId":"id-bob"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field+"="+tt.value, func(t *testing.T) {
+			got, err := CoerceFieldValue(tt.field, coerceTestFields[tt.field], tt.value, lookupUser)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			data, _ := json.Marshal(got)
+			if string(data) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, data)
+			}
+		})
+	}
+}
+
+func TestCoerceFieldValue_DateTime(t *testing.T) {
+	meta := coerceTestFields["customfield_10007"]
+
+	got, err := CoerceFieldValue("customfield_10007", meta, "2024-05-01T14:30:00Z", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got != "2024-05-01T14:30:00.000+0000" {
+		t.Errorf("Expected Jira datetime format, got %v", got)
+	}
+
+	// Times without a zone are local
+	got, err = CoerceFieldValue("customfield_10007", meta, "2024-05-01 14:30", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := time.Date(2024, 5, 1, 14, 30, 0, 0, time.Local).Format(jiraDateTimeLayout)
+	if got != expected {
+		t.Errorf("Expected %s, got %v", expected, got)
+	}
+}
+
+func TestCoerceFieldValue_Errors(t *testing.T) {
+	tests := []struct {
+		field   string
+		value   string
+		allowed []string
+	}{
+		{"priority", "Urgent", []string{"High", "Low"}},
+		{"components", "Backend,Frontend", []string{"Backend", "API"}},
+		{"customfield_10001", "Green", []string{"Red", "Blue"}},
+		{"customfield_10003", "EMEA > Spain", []string{"Germany", "France"}},
+		{"customfield_10003", "APAC", []string{"EMEA"}},
+		{"customfield_10004", "eight", nil},
+		{"customfield_10005", "Sprint 4", nil},
+		{"customfield_10007", "tomorrow", nil},
+		{"duedate", "30/06/2024", nil},
+		{"labels", "needs review", nil},
+		{"assignee", "alice", nil},
+		{"timetracking", "spent=1d", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field+"="+tt.value, func(t *testing.T) {
+			_, err := CoerceFieldValue(tt.field, coerceTestFields[tt.field], tt.value, nil)
+
+			var validationErr *client.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected a validation error, got %v", err)
+			}
+			if validationErr.FieldErrors[tt.field] == "" {
+				t.Errorf("Expected an error for field %s, got %v", tt.field, validationErr.FieldErrors)
+			}
+			allowed := validationErr.AllowedValues[tt.field]
+			if len(allowed) != len(tt.allowed) {
+				t.Fatalf("Expected allowed values %v, got %v", tt.allowed, allowed)
+			}
+			for i := range allowed {
+				if allowed[i] != tt.allowed[i] {
+					t.Errorf("Expected allowed values %v, got %v", tt.allowed, allowed)
+				}
+			}
+		})
+	}
+}
+
+func TestIsRichTextSchema(t *testing.T) {
+	tests := []struct {
+		schema   models.FieldSchema
+		expected bool
+	}{
+		{models.FieldSchema{Type: "string", System: "description"}, true},
+		{models.FieldSchema{Type: "string", System: "environment"}, true},
+		{models.FieldSchema{Type: "string", Custom: textAreaFieldType}, true},
+		{models.FieldSchema{Type: "string", System: "summary"}, false},
+		{models.FieldSchema{Type: "string", Custom: "com.atlassian.jira.plugin.system.customfieldtypes:textfield"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsRichTextSchema(tt.schema); got != tt.expected {
+			t.Errorf("IsRichTextSchema(%+v) = %v, expected %v", tt.schema, got, tt.expected)
+		}
+	}
+}
+
+func TestGetEditMetadata(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/issue/PROJ-1/editmeta" {
+			w.WriteHeader(http.StatusNotFound)
+			writeJSON(w, models.ErrorResponse{ErrorMessages: []string{"Issue does not exist"}})
+			return
+		}
+		writeJSON(w, models.EditMetaResponse{Fields: map[string]models.FieldMeta{
+			"priority": coerceTestFields["priority"],
+		}})
+	}))
+	defer server.Close()
+
+	svc := NewMetadataService(newTestClient(server.URL))
+	for i := 0; i < 2; i++ {
+		meta, err := svc.GetEditMetadata("PROJ-1")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(meta.Fields["priority"].AllowedValues) != 2 {
+			t.Errorf("Expected priority with 2 allowed values, got %+v", meta.Fields)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected edit metadata to be cached, got %d requests", requests)
+	}
+
+	_, err := svc.GetEditMetadata("PROJ-404")
+	if apiErr, ok := client.AsAPIError(err); !ok || apiErr.StatusCode != 404 {
+		t.Errorf("Expected a 404 error, got %v", err)
+	}
+}
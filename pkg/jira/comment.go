@@ -20,34 +20,32 @@ func NewCommentService(client *client.Client) *CommentService {
 // AddComment adds a comment to an issue
 // Parameters:
 //   - issueKey: The issue key (e.g., "PROJ-123")
-//   - text: The comment text (will be converted to ADF format)
+//   - text: The comment text as Markdown (converted to ADF format)
 // Returns the created comment
 func (s *CommentService) AddComment(issueKey, text string) (*models.Comment, error) {
+	if text == "" {
+		return nil, fmt.Errorf("comment text cannot be empty")
+	}
+
+	return s.AddCommentADF(issueKey, MarkdownToADF(text))
+}
+
+// AddCommentADF adds a comment with an ADF body to an issue
+// Parameters:
+//   - issueKey: The issue key (e.g., "PROJ-123")
+//   - doc: The comment body as an ADF document (see ToADF)
+// Returns the created comment
+func (s *CommentService) AddCommentADF(issueKey string, doc map[string]interface{}) (*models.Comment, error) {
 	if issueKey == "" {
 		return nil, fmt.Errorf("issue key cannot be empty")
 	}
 
-	if text == "" {
+	if isEmptyADF(doc) {
 		return nil, fmt.Errorf("comment text cannot be empty")
 	}
 
-	// Convert plain text to ADF (Atlassian Document Format)
 	body := map[string]interface{}{
-		"body": map[string]interface{}{
-			"type":    "doc",
-			"version": 1,
-			"content": []map[string]interface{}{
-				{
-					"type": "paragraph",
-					"content": []map[string]interface{}{
-						{
-							"type": "text",
-							"text": text,
-						},
-					},
-				},
-			},
-		},
+		"body": doc,
 	}
 
 	var comment models.Comment
@@ -64,7 +62,7 @@ func (s *CommentService) AddComment(issueKey, text string) (*models.Comment, err
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return nil, formatErrorResponse(resp, &errorResp)
 	}
 
 	return &comment, nil
@@ -103,14 +101,71 @@ func (s *CommentService) ListComments(issueKey string, orderBy string) (*models.
 
 	if resp.IsError() {
 		if resp.StatusCode() == 404 {
-			return nil, fmt.Errorf("issue '%s' not found", issueKey)
+			return nil, client.NewError(resp.StatusCode(), fmt.Sprintf("issue '%s' not found", issueKey))
 		}
-		return nil, fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return nil, formatErrorResponse(resp, &errorResp)
 	}
 
 	return &result, nil
 }
 
+// commentPageSize is the page size used when paging through comments
+const commentPageSize = 100
+
+// ListCommentsPaged retrieves all comments for an issue one page at a time
+// Parameters:
+//   - issueKey: The issue key (e.g., "PROJ-123")
+//   - orderBy: Sort order ("created" or "-created" for descending)
+//   - onPage: Callback invoked with each page of comments; returning an error stops paging
+func (s *CommentService) ListCommentsPaged(issueKey string, orderBy string, onPage func(comments []models.Comment) error) error {
+	if issueKey == "" {
+		return fmt.Errorf("issue key cannot be empty")
+	}
+
+	// Default to ascending by created date
+	if orderBy == "" {
+		orderBy = "created"
+	}
+
+	startAt := 0
+	for {
+		var result models.CommentsResponse
+		var errorResp models.ErrorResponse
+
+		resp, err := s.client.HTTPClient.R().
+			SetQueryParams(map[string]string{
+				"orderBy":    orderBy,
+				"startAt":    fmt.Sprintf("%d", startAt),
+				"maxResults": fmt.Sprintf("%d", commentPageSize),
+			}).
+			SetResult(&result).
+			SetError(&errorResp).
+			Get(fmt.Sprintf("/issue/%s/comment", issueKey))
+
+		if err != nil {
+			return fmt.Errorf("failed to list comments for %s: %w", issueKey, err)
+		}
+
+		if resp.IsError() {
+			if resp.StatusCode() == 404 {
+				return client.NewError(resp.StatusCode(), fmt.Sprintf("issue '%s' not found", issueKey))
+			}
+			return formatErrorResponse(resp, &errorResp)
+		}
+
+		if len(result.Comments) > 0 {
+			if err := onPage(result.Comments); err != nil {
+				return err
+			}
+		}
+
+		startAt += len(result.Comments)
+		if len(result.Comments) == 0 || startAt >= result.Total {
+			return nil
+		}
+	}
+}
+
 // GetComment retrieves a specific comment by ID
 // Parameters:
 //   - issueKey: The issue key (e.g., "PROJ-123")
@@ -139,9 +194,9 @@ func (s *CommentService) GetComment(issueKey, commentID string) (*models.Comment
 
 	if resp.IsError() {
 		if resp.StatusCode() == 404 {
-			return nil, fmt.Errorf("comment '%s' not found on issue '%s'", commentID, issueKey)
+			return nil, client.NewError(resp.StatusCode(), fmt.Sprintf("comment '%s' not found on issue '%s'", commentID, issueKey))
 		}
-		return nil, fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return nil, formatErrorResponse(resp, &errorResp)
 	}
 
 	return &comment, nil
@@ -151,9 +206,23 @@ func (s *CommentService) GetComment(issueKey, commentID string) (*models.Comment
 // Parameters:
 //   - issueKey: The issue key (e.g., "PROJ-123")
 //   - commentID: The comment ID to update
-//   - text: The new comment text (will be converted to ADF format)
+//   - text: The new comment text as Markdown (converted to ADF format)
 // Returns error if update fails (e.g., insufficient permissions)
 func (s *CommentService) UpdateComment(issueKey, commentID, text string) error {
+	if text == "" {
+		return fmt.Errorf("comment text cannot be empty")
+	}
+
+	return s.UpdateCommentADF(issueKey, commentID, MarkdownToADF(text))
+}
+
+// UpdateCommentADF replaces the body of an existing comment with an ADF document
+// Parameters:
+//   - issueKey: The issue key (e.g., "PROJ-123")
+//   - commentID: The comment ID to update
+//   - doc: The new comment body as an ADF document (see ToADF)
+// Returns error if update fails (e.g., insufficient permissions)
+func (s *CommentService) UpdateCommentADF(issueKey, commentID string, doc map[string]interface{}) error {
 	if issueKey == "" {
 		return fmt.Errorf("issue key cannot be empty")
 	}
@@ -162,27 +231,12 @@ func (s *CommentService) UpdateComment(issueKey, commentID, text string) error {
 		return fmt.Errorf("comment ID cannot be empty")
 	}
 
-	if text == "" {
+	if isEmptyADF(doc) {
 		return fmt.Errorf("comment text cannot be empty")
 	}
 
-	// Convert plain text to ADF
 	body := map[string]interface{}{
-		"body": map[string]interface{}{
-			"type":    "doc",
-			"version": 1,
-			"content": []map[string]interface{}{
-				{
-					"type": "paragraph",
-					"content": []map[string]interface{}{
-						{
-							"type": "text",
-							"text": text,
-						},
-					},
-				},
-			},
-		},
+		"body": doc,
 	}
 
 	var errorResp models.ErrorResponse
@@ -198,12 +252,12 @@ func (s *CommentService) UpdateComment(issueKey, commentID, text string) error {
 
 	if resp.IsError() {
 		if resp.StatusCode() == 404 {
-			return fmt.Errorf("comment '%s' not found on issue '%s'", commentID, issueKey)
+			return client.NewError(resp.StatusCode(), fmt.Sprintf("comment '%s' not found on issue '%s'", commentID, issueKey))
 		}
 		if resp.StatusCode() == 403 {
-			return fmt.Errorf("you don't have permission to update this comment")
+			return client.NewError(resp.StatusCode(), "you don't have permission to update this comment")
 		}
-		return fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return formatErrorResponse(resp, &errorResp)
 	}
 
 	return nil
@@ -235,12 +289,12 @@ func (s *CommentService) DeleteComment(issueKey, commentID string) error {
 
 	if resp.IsError() {
 		if resp.StatusCode() == 404 {
-			return fmt.Errorf("comment '%s' not found on issue '%s'", commentID, issueKey)
+			return client.NewError(resp.StatusCode(), fmt.Sprintf("comment '%s' not found on issue '%s'", commentID, issueKey))
 		}
 		if resp.StatusCode() == 403 {
-			return fmt.Errorf("you don't have permission to delete this comment")
+			return client.NewError(resp.StatusCode(), "you don't have permission to delete this comment")
 		}
-		return fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return formatErrorResponse(resp, &errorResp)
 	}
 
 	return nil
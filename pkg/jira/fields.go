@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/go-resty/resty/v2"
 	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
 	"github.com/sanisideup/jira-cli-for-agents/pkg/config"
 	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
@@ -38,7 +39,7 @@ func (s *FieldService) ListFields(projectKey string) ([]models.Field, error) {
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return nil, formatErrorResponse(resp, &errorResp)
 	}
 
 	// If project key is provided, we could filter results here
@@ -141,29 +142,20 @@ func (s *FieldService) ResolveFieldID(nameOrID string, cfg *config.Config) (stri
 	// Try to find by name
 	field, err := s.GetFieldByName(nameOrID)
 	if err != nil {
-		return "", fmt.Errorf("could not resolve '%s': not a valid field ID, alias, or field name. Run 'jcfa fields list' to see available fields", nameOrID)
+		msg := fmt.Sprintf("could not resolve '%s': not a valid field ID, alias, or field name. Run 'jcfa fields list' to see available fields", nameOrID)
+		validationErr := client.NewValidationError(msg, map[string]string{nameOrID: msg})
+		validationErr.Hints = []string{"Run 'jcfa fields list' to see available fields, or 'jcfa fields map <alias> <field-id>' to add an alias"}
+		return "", validationErr
 	}
 
 	return field.ID, nil
 }
 
-// formatErrorResponse formats a Jira error response for display
-func formatErrorResponse(errResp *models.ErrorResponse) string {
-	var messages []string
-
-	if len(errResp.ErrorMessages) > 0 {
-		messages = append(messages, strings.Join(errResp.ErrorMessages, "; "))
-	}
-
-	if len(errResp.Errors) > 0 {
-		for field, msg := range errResp.Errors {
-			messages = append(messages, fmt.Sprintf("%s: %s", field, msg))
-		}
-	}
-
-	if len(messages) == 0 {
-		return "unknown error"
-	}
-
-	return strings.Join(messages, "; ")
+// formatErrorResponse converts a Jira error response into a typed client error
+// (client.NotFoundError, client.ValidationError, ...)
+// Parameters:
+//   - resp: The failed response
+//   - errResp: The decoded error body
+func formatErrorResponse(resp *resty.Response, errResp *models.ErrorResponse) error {
+	return client.NewAPIError(resp.StatusCode(), resp.Header(), errResp)
 }
@@ -56,7 +56,7 @@ func (s *IssueService) CreateIssue(fields map[string]interface{}) (*models.Issue
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return nil, formatErrorResponse(resp, &errorResp)
 	}
 
 	return &result, nil
@@ -131,7 +131,7 @@ func (s *IssueService) bulkCreateBatch(issues []map[string]interface{}) (*models
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return nil, formatErrorResponse(resp, &errorResp)
 	}
 
 	return &result, nil
@@ -154,9 +154,9 @@ func (s *IssueService) GetIssue(keyOrID string) (*models.Issue, error) {
 
 	if resp.IsError() {
 		if resp.StatusCode() == 404 {
-			return nil, fmt.Errorf("issue '%s' not found", keyOrID)
+			return nil, client.NewError(resp.StatusCode(), fmt.Sprintf("issue '%s' not found", keyOrID))
 		}
-		return nil, fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return nil, formatErrorResponse(resp, &errorResp)
 	}
 
 	return &issue, nil
@@ -133,7 +133,7 @@ func (s *LinkService) updateEpicLinkField(storyKey, epicKey, epicLinkField strin
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("API error updating epic link: %s", formatErrorResponse(&errorResp))
+		return fmt.Errorf("failed to update epic link: %w", formatErrorResponse(resp, &errorResp))
 	}
 
 	return nil
@@ -182,7 +182,7 @@ func (s *LinkService) createEpicStoryLink(storyKey, epicKey string) error {
 			}
 		}
 
-		return fmt.Errorf("API error creating epic-story link: %s", formatErrorResponse(&errorResp))
+		return fmt.Errorf("failed to create epic-story link: %w", formatErrorResponse(resp, &errorResp))
 	}
 
 	return nil
@@ -214,7 +214,7 @@ func (s *LinkService) CreateIssueLink(inwardKey, outwardKey, linkTypeName string
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return formatErrorResponse(resp, &errorResp)
 	}
 
 	return nil
@@ -236,7 +236,7 @@ func (s *LinkService) GetAvailableLinkTypes() ([]models.IssueLinkType, error) {
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return nil, formatErrorResponse(resp, &errorResp)
 	}
 
 	return linkTypesResp.IssueLinkTypes, nil
@@ -269,9 +269,9 @@ func (s *LinkService) GetIssueLinks(issueKey string) ([]models.IssueLink, error)
 
 	if resp.IsError() {
 		if resp.StatusCode() == 404 {
-			return nil, fmt.Errorf("issue '%s' not found", issueKey)
+			return nil, client.NewError(resp.StatusCode(), fmt.Sprintf("issue '%s' not found", issueKey))
 		}
-		return nil, fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return nil, formatErrorResponse(resp, &errorResp)
 	}
 
 	// Extract issuelinks from the fields map
@@ -387,12 +387,12 @@ func (s *LinkService) DeleteIssueLink(linkID string) error {
 
 	if resp.IsError() {
 		if resp.StatusCode() == 404 {
-			return fmt.Errorf("link ID '%s' not found", linkID)
+			return client.NewError(resp.StatusCode(), fmt.Sprintf("link ID '%s' not found", linkID))
 		}
 		if resp.StatusCode() == 403 {
-			return fmt.Errorf("you don't have permission to delete this link")
+			return client.NewError(resp.StatusCode(), "you don't have permission to delete this link")
 		}
-		return fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return formatErrorResponse(resp, &errorResp)
 	}
 
 	return nil
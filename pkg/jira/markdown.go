@@ -0,0 +1,1079 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+// Text input formats accepted by ToADF
+const (
+	TextFormatMarkdown = "markdown" // Markdown converted to ADF (default)
+	TextFormatPlain    = "plain"    // Plain text; blank lines separate paragraphs
+	TextFormatADF      = "adf"      // A raw ADF document as JSON
+)
+
+// TextFormats lists the accepted text input formats
+var TextFormats = []string{TextFormatMarkdown, TextFormatPlain, TextFormatADF}
+
+// MentionResolver resolves an @mention token (an email address or display
+// name, without the @) to a user. It returns nil if the token should stay text.
+type MentionResolver func(token string) (*models.User, error)
+
+// ToADF converts text in the given format to an ADF document
+// Parameters:
+//   - text: The input text
+//   - format: One of TextFormats ("" = markdown)
+func ToADF(text, format string) (map[string]interface{}, error) {
+	return ToADFWithMentions(text, format, nil)
+}
+
+// ToADFWithMentions converts text like ToADF, turning @mentions in Markdown
+// into mention nodes
+// Parameters:
+//   - text: The input text
+//   - format: One of TextFormats ("" = markdown)
+//   - resolve: Resolves @mentions (nil = kept as text)
+func ToADFWithMentions(text, format string, resolve MentionResolver) (map[string]interface{}, error) {
+	switch strings.ToLower(format) {
+	case "", TextFormatMarkdown:
+		return MarkdownToADFWithMentions(text, resolve)
+
+	case TextFormatPlain:
+		return PlainTextToADF(text), nil
+
+	case TextFormatADF:
+		var doc map[string]interface{}
+		if err := json.Unmarshal([]byte(text), &doc); err != nil {
+			return nil, client.NewValidationError(fmt.Sprintf("invalid ADF JSON: %v", err), nil)
+		}
+		if doc["type"] != "doc" {
+			return nil, client.NewValidationError(`invalid ADF: the top-level node must have "type": "doc"`, nil)
+		}
+		if _, ok := doc["version"]; !ok {
+			doc["version"] = 1
+		}
+		return doc, nil
+	}
+
+	msg := fmt.Sprintf("unknown text format '%s' (use %s)", format, strings.Join(TextFormats, ", "))
+	return nil, client.NewAllowedValuesError("format", msg, TextFormats)
+}
+
+// IsRichTextField checks if a standard field holds rich text (ADF)
+func IsRichTextField(fieldID string) bool {
+	return fieldID == "description" || fieldID == "environment"
+}
+
+// isEmptyADF reports whether an ADF document has no content
+func isEmptyADF(doc map[string]interface{}) bool {
+	content, _ := doc["content"].([]interface{})
+	return len(content) == 0
+}
+
+// PlainTextToADF converts plain text to an ADF document without interpreting
+// any markup. Blank lines separate paragraphs; other line breaks are kept.
+func PlainTextToADF(text string) map[string]interface{} {
+	content := []interface{}{}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	for _, block := range regexp.MustCompile(`\n\s*\n`).Split(strings.Trim(text, "\n"), -1) {
+		if strings.TrimSpace(block) == "" {
+			continue
+		}
+
+		var inline []interface{}
+		for i, line := range strings.Split(block, "\n") {
+			if i > 0 {
+				inline = append(inline, map[string]interface{}{"type": "hardBreak"})
+			}
+			if line != "" {
+				inline = append(inline, textNode(line, nil))
+			}
+		}
+		content = append(content, map[string]interface{}{"type": "paragraph", "content": inline})
+	}
+
+	return adfDoc(content)
+}
+
+// MarkdownToADF converts Markdown to an ADF document.
+//
+// Supported syntax:
+//   - ATX headings (# to ######)
+//   - Bullet, ordered and task lists (- [ ] / - [x]), nested by indentation
+//   - Fenced code blocks (``` or ~~~) with an optional language
+//   - Blockquotes, horizontal rules and GFM tables
+//   - Inline: **strong**, *em*, ~~strike~~, `code`, [links](url), <autolinks>
+//     and bare http(s) URLs; a trailing double space or backslash is a hard break
+//
+// Anything else is kept as paragraph text.
+func MarkdownToADF(markdown string) map[string]interface{} {
+	doc, _ := MarkdownToADFWithMentions(markdown, nil)
+	return doc
+}
+
+// MarkdownToADFWithMentions converts Markdown like MarkdownToADF, and turns
+// @mentions into mention nodes using resolve:
+//   - @alice@example.com (email address)
+//   - @alice (a single-word display name or name prefix)
+//   - @[Alice Smith] (a display name with spaces)
+//
+// Mentions inside code and links, and escaped ones (\@alice), stay text.
+// Returns the first error from resolve.
+func MarkdownToADFWithMentions(markdown string, resolve MentionResolver) (map[string]interface{}, error) {
+	markdown = strings.ReplaceAll(markdown, "\r\n", "\n")
+
+	p := &mdParser{mentions: resolve}
+	doc := adfDoc(p.blocks(expandIndentTabs(strings.Split(markdown, "\n"))))
+	if p.err != nil {
+		return nil, p.err
+	}
+	return doc, nil
+}
+
+// adfDoc wraps block nodes in an ADF document
+func adfDoc(content []interface{}) map[string]interface{} {
+	if content == nil {
+		content = []interface{}{}
+	}
+	return map[string]interface{}{
+		"type":    "doc",
+		"version": 1,
+		"content": content,
+	}
+}
+
+var (
+	mdFenceRe     = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})\\s*([^`\\s]*)")
+	mdHeadingRe   = regexp.MustCompile(`^ {0,3}(#{1,6})(?:\s+(.*?))?(?:\s+#+)?\s*$`)
+	mdRuleRe      = regexp.MustCompile(`^ {0,3}(?:(?:\*\s*){3,}|(?:-\s*){3,}|(?:_\s*){3,})$`)
+	mdListItemRe  = regexp.MustCompile(`^( *)([-*+]|\d{1,9}[.)])( +|$)(.*)$`)
+	mdTaskRe      = regexp.MustCompile(`^\[([ xX])\](?:\s+(.*))?$`)
+	mdTableSepRe  = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(?:\|\s*:?-+:?\s*)*\|?\s*$`)
+	mdAutolinkRe  = regexp.MustCompile(`^<((?:https?|mailto):[^\s<>]+)>`)
+	mdBareURLRe   = regexp.MustCompile(`^https?://[^\s<>]+`)
+	mdAnyFenceRe  = regexp.MustCompile("^[ \t]*(`{3,}|~{3,})")
+	mdMarkerTabRe = regexp.MustCompile(`^( *(?:[-*+]|\d{1,9}[.)]))([ \t]*\t[ \t]*)`)
+	// Block types ADF allows in blockquotes and list items
+	mdNestedBlocks = map[string]bool{"paragraph": true, "bulletList": true, "orderedList": true, "codeBlock": true}
+)
+
+// mdParser converts Markdown lines to ADF nodes
+type mdParser struct {
+	taskIDs  int             // Counter for taskList/taskItem localIds
+	mentions MentionResolver // Resolves @mentions (nil = kept as text)
+	err      error           // First error from mentions
+}
+
+// blocks parses lines into ADF block nodes
+func (p *mdParser) blocks(lines []string) []interface{} {
+	var blocks []interface{}
+	var para []string
+
+	flush := func() {
+		if len(para) > 0 {
+			blocks = append(blocks, p.paragraph(para))
+			para = nil
+		}
+	}
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+			i++
+
+		case mdFenceRe.MatchString(line):
+			flush()
+			node, n := p.codeBlock(lines[i:])
+			blocks = append(blocks, node)
+			i += n
+
+		case mdHeadingRe.MatchString(line):
+			flush()
+			m := mdHeadingRe.FindStringSubmatch(line)
+			node := map[string]interface{}{
+				"type":  "heading",
+				"attrs": map[string]interface{}{"level": len(m[1])},
+			}
+			if inline := p.inline(m[2], nil); len(inline) > 0 {
+				node["content"] = inline
+			}
+			blocks = append(blocks, node)
+			i++
+
+		case mdRuleRe.MatchString(line):
+			flush()
+			blocks = append(blocks, map[string]interface{}{"type": "rule"})
+			i++
+
+		case strings.HasPrefix(strings.TrimLeft(line, " "), ">"):
+			flush()
+			node, n := p.blockquote(lines[i:])
+			blocks = append(blocks, node)
+			i += n
+
+		case len(para) == 0 && isTableStart(lines[i:]):
+			node, n := p.table(lines[i:])
+			blocks = append(blocks, node)
+			i += n
+
+		case mdListItemRe.MatchString(line) && (len(para) == 0 || listInterruptsParagraph(line)):
+			flush()
+			node, n := p.list(lines[i:])
+			blocks = append(blocks, node)
+			i += n
+
+		default:
+			para = append(para, line)
+			i++
+		}
+	}
+
+	flush()
+	return blocks
+}
+
+// listInterruptsParagraph reports whether a list item line starts a list
+// directly after paragraph text. As in CommonMark, an ordered list must start
+// at 1 and an item must not be empty, so wrapped text like "2024. was" stays
+// in the paragraph.
+func listInterruptsParagraph(line string) bool {
+	m := mdListItemRe.FindStringSubmatch(line)
+	if strings.TrimSpace(m[4]) == "" {
+		return false
+	}
+	marker := m[2]
+	return !isDigit(marker[0]) || marker[:len(marker)-1] == "1"
+}
+
+// paragraph builds a paragraph from consecutive lines
+func (p *mdParser) paragraph(lines []string) map[string]interface{} {
+	for i := range lines {
+		lines[i] = strings.TrimLeft(lines[i], " ")
+	}
+	text := strings.TrimRight(strings.Join(lines, "\n"), " ")
+
+	node := map[string]interface{}{"type": "paragraph"}
+	if inline := p.inline(text, nil); len(inline) > 0 {
+		node["content"] = inline
+	}
+	return node
+}
+
+// codeBlock parses a fenced code block, returning the node and the number of lines consumed
+func (p *mdParser) codeBlock(lines []string) (map[string]interface{}, int) {
+	m := mdFenceRe.FindStringSubmatch(lines[0])
+	indent, fence, language := len(m[1]), m[2], m[3]
+
+	var code []string
+	n := 1
+	for ; n < len(lines); n++ {
+		trimmed := strings.TrimSpace(lines[n])
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			n++
+			break
+		}
+		code = append(code, trimLeftSpaces(lines[n], indent))
+	}
+
+	node := map[string]interface{}{"type": "codeBlock"}
+	if language != "" {
+		node["attrs"] = map[string]interface{}{"language": language}
+	}
+	if text := strings.Join(code, "\n"); text != "" {
+		node["content"] = []interface{}{textNode(text, nil)}
+	}
+	return node, n
+}
+
+// blockquote parses consecutive "> " lines, returning the node and the number of lines consumed
+func (p *mdParser) blockquote(lines []string) (map[string]interface{}, int) {
+	var inner []string
+	n := 0
+	for ; n < len(lines); n++ {
+		trimmed := strings.TrimLeft(lines[n], " ")
+		if !strings.HasPrefix(trimmed, ">") {
+			break
+		}
+		trimmed = strings.TrimPrefix(trimmed, ">")
+		inner = append(inner, strings.TrimPrefix(trimmed, " "))
+	}
+
+	content := restrictBlocks(p.blocks(inner), mdNestedBlocks)
+	if len(content) == 0 {
+		content = []interface{}{map[string]interface{}{"type": "paragraph"}}
+	}
+	return map[string]interface{}{"type": "blockquote", "content": content}, n
+}
+
+// isTableStart reports whether lines begin with a GFM table header and delimiter row
+func isTableStart(lines []string) bool {
+	return len(lines) > 1 &&
+		strings.Contains(lines[0], "|") &&
+		strings.Contains(lines[1], "-") &&
+		mdTableSepRe.MatchString(lines[1]) &&
+		len(splitTableRow(lines[0])) == len(splitTableRow(lines[1]))
+}
+
+// table parses a GFM table, returning the node and the number of lines consumed
+func (p *mdParser) table(lines []string) (map[string]interface{}, int) {
+	header := splitTableRow(lines[0])
+	rows := []interface{}{p.tableRow(header, len(header), "tableHeader")}
+
+	n := 2
+	for ; n < len(lines); n++ {
+		if strings.TrimSpace(lines[n]) == "" || !strings.Contains(lines[n], "|") {
+			break
+		}
+		rows = append(rows, p.tableRow(splitTableRow(lines[n]), len(header), "tableCell"))
+	}
+
+	return map[string]interface{}{
+		"type": "table",
+		"attrs": map[string]interface{}{
+			"isNumberColumnEnabled": false,
+			"layout":                "default",
+		},
+		"content": rows,
+	}, n
+}
+
+// tableRow builds a row of exactly width cells of the given type
+func (p *mdParser) tableRow(cells []string, width int, cellType string) map[string]interface{} {
+	content := make([]interface{}, width)
+	for i := range content {
+		paragraph := map[string]interface{}{"type": "paragraph"}
+		if i < len(cells) {
+			if inline := p.inline(cells[i], nil); len(inline) > 0 {
+				paragraph["content"] = inline
+			}
+		}
+		content[i] = map[string]interface{}{
+			"type":    cellType,
+			"attrs":   map[string]interface{}{},
+			"content": []interface{}{paragraph},
+		}
+	}
+	return map[string]interface{}{"type": "tableRow", "content": content}
+}
+
+// splitTableRow splits a table row on unescaped pipes, dropping the outer pipes
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = strings.TrimSuffix(line, "|")
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// mdListItem is a list item's marker and content lines
+type mdListItem struct {
+	marker string
+	lines  []string
+}
+
+// list parses a list and any lists nested in it, returning the nodes and the
+// number of lines consumed. A list whose items are all tasks becomes a taskList.
+func (p *mdParser) list(lines []string) (map[string]interface{}, int) {
+	first := mdListItemRe.FindStringSubmatch(lines[0])
+	baseIndent := len(first[1])
+	ordered := isDigit(first[2][0])
+
+	var items []*mdListItem
+	var current *mdListItem
+	contentIndent := 0
+
+	n := 0
+	for ; n < len(lines); n++ {
+		line := lines[n]
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		if strings.TrimSpace(line) == "" {
+			// A blank line continues the list only if more of it follows
+			next := n + 1
+			for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+				next++
+			}
+			if next == len(lines) {
+				break
+			}
+			nextIndent := len(lines[next]) - len(strings.TrimLeft(lines[next], " "))
+			if nextIndent < contentIndent && !isSiblingItem(lines[next], baseIndent, ordered) {
+				break
+			}
+			current.lines = append(current.lines, "")
+			continue
+		}
+
+		if m := mdListItemRe.FindStringSubmatch(line); m != nil && indent < baseIndent+2 && !mdRuleRe.MatchString(line) {
+			if isDigit(m[2][0]) != ordered {
+				break
+			}
+			current = &mdListItem{marker: m[2], lines: []string{m[4]}}
+			items = append(items, current)
+			contentIndent = len(m[1]) + len(m[2]) + len(m[3])
+			if m[4] == "" {
+				contentIndent = len(m[1]) + len(m[2]) + 1
+			}
+			continue
+		}
+
+		// Continuation lines and nested lists, which may be indented less
+		// than the item content (e.g. two spaces under "1. ")
+		if indent >= contentIndent || (indent >= baseIndent+2 && mdListItemRe.MatchString(line)) {
+			current.lines = append(current.lines, trimLeftSpaces(line, contentIndent))
+			continue
+		}
+
+		// Lazy continuation of the item's paragraph
+		if strings.TrimSpace(lines[n-1]) != "" && !startsBlock(line) {
+			current.lines = append(current.lines, strings.TrimLeft(line, " "))
+			continue
+		}
+
+		break
+	}
+
+	if !ordered && allTasks(items) {
+		return p.taskList(items), n
+	}
+
+	listType := "bulletList"
+	if ordered {
+		listType = "orderedList"
+	}
+
+	content := make([]interface{}, len(items))
+	for i, item := range items {
+		blocks := restrictBlocks(p.blocks(item.lines), mdNestedBlocks)
+		if len(blocks) == 0 {
+			blocks = []interface{}{map[string]interface{}{"type": "paragraph"}}
+		}
+		content[i] = map[string]interface{}{"type": "listItem", "content": blocks}
+	}
+
+	node := map[string]interface{}{"type": listType, "content": content}
+	if ordered {
+		var start int
+		fmt.Sscanf(items[0].marker, "%d", &start)
+		if start != 1 {
+			node["attrs"] = map[string]interface{}{"order": start}
+		}
+	}
+	return node, n
+}
+
+// isSiblingItem reports whether line starts another item of the same list
+func isSiblingItem(line string, baseIndent int, ordered bool) bool {
+	m := mdListItemRe.FindStringSubmatch(line)
+	return m != nil && len(m[1]) < baseIndent+2 && isDigit(m[2][0]) == ordered
+}
+
+// startsBlock reports whether line starts a block that ends a lazy paragraph continuation
+func startsBlock(line string) bool {
+	return mdFenceRe.MatchString(line) || mdHeadingRe.MatchString(line) || mdRuleRe.MatchString(line) ||
+		mdListItemRe.MatchString(line) || strings.HasPrefix(strings.TrimLeft(line, " "), ">")
+}
+
+// allTasks reports whether every item starts with a [ ] or [x] checkbox
+func allTasks(items []*mdListItem) bool {
+	for _, item := range items {
+		if !mdTaskRe.MatchString(item.lines[0]) {
+			return false
+		}
+	}
+	return len(items) > 0
+}
+
+// taskList builds a taskList. Task text becomes the item content; nested task
+// lists are kept, other nested blocks are flattened into the item text.
+func (p *mdParser) taskList(items []*mdListItem) map[string]interface{} {
+	p.taskIDs++
+	listID := fmt.Sprintf("task-%d", p.taskIDs)
+
+	var content []interface{}
+	for _, item := range items {
+		m := mdTaskRe.FindStringSubmatch(item.lines[0])
+		state := "TODO"
+		if m[1] != " " {
+			state = "DONE"
+		}
+
+		lines := append([]string{m[2]}, item.lines[1:]...)
+		var inline []interface{}
+		var nested []interface{}
+		for _, block := range p.blocks(lines) {
+			node := block.(map[string]interface{})
+			if node["type"] == "taskList" {
+				nested = append(nested, node)
+				continue
+			}
+			if len(inline) > 0 {
+				inline = append(inline, map[string]interface{}{"type": "hardBreak"})
+			}
+			inline = append(inline, inlineContent(node)...)
+		}
+
+		p.taskIDs++
+		taskItem := map[string]interface{}{
+			"type":  "taskItem",
+			"attrs": map[string]interface{}{"localId": fmt.Sprintf("task-%d", p.taskIDs), "state": state},
+		}
+		if len(inline) > 0 {
+			taskItem["content"] = inline
+		}
+		content = append(content, taskItem)
+		content = append(content, nested...)
+	}
+
+	return map[string]interface{}{
+		"type":    "taskList",
+		"attrs":   map[string]interface{}{"localId": listID},
+		"content": content,
+	}
+}
+
+// inlineContent returns the inline nodes of a block, flattening nested blocks
+func inlineContent(node map[string]interface{}) []interface{} {
+	content, _ := node["content"].([]interface{})
+	if node["type"] == "paragraph" || node["type"] == "heading" || node["type"] == "codeBlock" {
+		return content
+	}
+
+	var inline []interface{}
+	for _, child := range content {
+		if childMap, ok := child.(map[string]interface{}); ok {
+			childInline := inlineContent(childMap)
+			if len(inline) > 0 && len(childInline) > 0 {
+				inline = append(inline, map[string]interface{}{"type": "hardBreak"})
+			}
+			inline = append(inline, childInline...)
+		}
+	}
+	return inline
+}
+
+// restrictBlocks adapts blocks to a container that only accepts the allowed
+// node types: headings become paragraphs, nested quotes are unwrapped and
+// other blocks are flattened into paragraphs
+func restrictBlocks(blocks []interface{}, allowed map[string]bool) []interface{} {
+	var result []interface{}
+	for _, block := range blocks {
+		node := block.(map[string]interface{})
+		nodeType, _ := node["type"].(string)
+
+		switch {
+		case allowed[nodeType]:
+			result = append(result, node)
+		case nodeType == "blockquote":
+			content, _ := node["content"].([]interface{})
+			result = append(result, restrictBlocks(content, allowed)...)
+		case nodeType == "rule":
+			// No equivalent inside the container
+		default:
+			paragraph := map[string]interface{}{"type": "paragraph"}
+			if inline := inlineContent(node); len(inline) > 0 {
+				paragraph["content"] = inline
+			}
+			result = append(result, paragraph)
+		}
+	}
+	return result
+}
+
+// inline parses inline Markdown into text nodes with marks
+// Parameters:
+//   - s: The text to parse
+//   - marks: Marks applied to all text in s (e.g. from an enclosing link)
+func (p *mdParser) inline(s string, marks []interface{}) []interface{} {
+	var nodes []interface{}
+	var buf strings.Builder
+
+	flush := func() {
+		if buf.Len() > 0 {
+			nodes = appendText(nodes, buf.String(), marks)
+			buf.Reset()
+		}
+	}
+	hardBreak := func() {
+		flush()
+		nodes = append(nodes, map[string]interface{}{"type": "hardBreak"})
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		rest := s[i:]
+
+		switch {
+		case c == '\\' && i+1 < len(s) && s[i+1] == '\n':
+			hardBreak()
+			i += 2
+
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			buf.WriteByte(s[i+1])
+			i += 2
+
+		case c == '\n':
+			text := buf.String()
+			buf.Reset()
+			buf.WriteString(strings.TrimRight(text, " "))
+			if strings.HasSuffix(text, "  ") {
+				hardBreak()
+			} else {
+				buf.WriteByte(' ')
+			}
+			i++
+
+		case c == '`':
+			ticks := len(rest) - len(strings.TrimLeft(rest, "`"))
+			end := findCodeSpanEnd(s, i+ticks, ticks)
+			if end < 0 {
+				buf.WriteString(rest[:ticks])
+				i += ticks
+				continue
+			}
+			code := strings.ReplaceAll(s[i+ticks:end], "\n", " ")
+			if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+				code = code[1 : len(code)-1]
+			}
+			flush()
+			nodes = appendText(nodes, code, codeMarks(marks))
+			i = end + ticks
+
+		case c == '!' && strings.HasPrefix(rest, "!["):
+			text, href, n := parseLink(rest[1:])
+			if n == 0 {
+				buf.WriteByte(c)
+				i++
+				continue
+			}
+			// Images can't be inline in ADF; keep them as links
+			if text == "" {
+				text = href
+			}
+			flush()
+			nodes = appendText(nodes, text, withMark(marks, linkMark(href)))
+			i += 1 + n
+
+		case c == '[':
+			text, href, n := parseLink(rest)
+			if n == 0 {
+				buf.WriteByte(c)
+				i++
+				continue
+			}
+			flush()
+			if text == "" {
+				nodes = appendText(nodes, href, withMark(marks, linkMark(href)))
+			} else {
+				nodes = appendNodes(nodes, p.inline(text, withMark(marks, linkMark(href))))
+			}
+			i += n
+
+		case c == '<' && mdAutolinkRe.MatchString(rest):
+			m := mdAutolinkRe.FindStringSubmatch(rest)
+			flush()
+			nodes = appendText(nodes, strings.TrimPrefix(m[1], "mailto:"), withMark(marks, linkMark(m[1])))
+			i += len(m[0])
+
+		case c == 'h' && (i == 0 || isURLBoundary(s[i-1])) && mdBareURLRe.MatchString(rest) && !hasMark(marks, "link"):
+			url := strings.TrimRight(mdBareURLRe.FindString(rest), ".,;:!?'\")*_")
+			flush()
+			nodes = appendText(nodes, url, withMark(marks, linkMark(url)))
+			i += len(url)
+
+		case c == '@' && p.mentions != nil && (i == 0 || !isWordChar(s[i-1])) && !hasMark(marks, "link"):
+			token, n := parseMentionToken(rest)
+			if n == 0 {
+				buf.WriteByte(c)
+				i++
+				continue
+			}
+			user := p.mention(token)
+			if user == nil {
+				buf.WriteString(rest[:n])
+				i += n
+				continue
+			}
+			flush()
+			nodes = append(nodes, map[string]interface{}{
+				"type": "mention",
+				"attrs": map[string]interface{}{
+					"id":   user.AccountID,
+					"text": "@" + user.DisplayName,
+				},
+			})
+			i += n
+
+		case c == '*' || c == '_' || c == '~':
+			delim := string(c)
+			markType := "em"
+			if strings.HasPrefix(rest, delim+delim) {
+				delim += delim
+				markType = "strong"
+			}
+			if c == '~' {
+				markType = "strike"
+				if delim != "~~" {
+					buf.WriteByte(c)
+					i++
+					continue
+				}
+			}
+
+			end := findClosingDelim(s, i, delim)
+			if end < 0 {
+				buf.WriteString(delim)
+				i += len(delim)
+				continue
+			}
+			flush()
+			nodes = appendNodes(nodes, p.inline(s[i+len(delim):end], withMark(marks, map[string]interface{}{"type": markType})))
+			i = end + len(delim)
+
+		default:
+			buf.WriteByte(c)
+			i++
+		}
+	}
+
+	flush()
+	return nodes
+}
+
+// mention resolves a mention token, recording the first error
+func (p *mdParser) mention(token string) *models.User {
+	if p.err != nil {
+		return nil
+	}
+	user, err := p.mentions(token)
+	if err != nil {
+		p.err = err
+		return nil
+	}
+	return user
+}
+
+// parseMentionToken parses a mention at the start of s ("@alice",
+// "@alice@example.com" or "@[Alice Smith]"). Returns the token without
+// the @ and the length consumed, or 0 if s doesn't start with a mention.
+func parseMentionToken(s string) (string, int) {
+	if strings.HasPrefix(s, "@[") {
+		end := strings.IndexAny(s, "]\n")
+		if end < 0 || s[end] != ']' {
+			return "", 0
+		}
+		token := strings.TrimSpace(s[2:end])
+		if token == "" {
+			return "", 0
+		}
+		return token, end + 1
+	}
+
+	end := 1
+	for end < len(s) && isMentionChar(s[end]) {
+		end++
+	}
+	// An email address continues after a second @ with its domain
+	if end < len(s) && s[end] == '@' && end > 1 {
+		domainEnd := end + 1
+		for domainEnd < len(s) && (isWordChar(s[domainEnd]) || s[domainEnd] == '.' || s[domainEnd] == '-') {
+			domainEnd++
+		}
+		domain := strings.TrimRight(s[end+1:domainEnd], ".-")
+		if strings.Contains(domain, ".") {
+			end = end + 1 + len(domain)
+		}
+	}
+
+	token := strings.TrimRight(s[1:end], ".-_")
+	if token == "" || token[0] == '_' || !isWordChar(token[0]) {
+		return "", 0
+	}
+	return token, len(token) + 1
+}
+
+// isMentionChar reports whether c can be part of an @mention name or email address
+func isMentionChar(c byte) bool {
+	return isWordChar(c) || c == '.' || c == '-' || c == '+' || c == '%'
+}
+
+// findClosingDelim finds the delimiter closing the emphasis opened at s[open:],
+// or -1. The opener must be followed by non-space; the closer must follow
+// non-space. Underscores don't open or close inside words.
+func findClosingDelim(s string, open int, delim string) int {
+	start := open + len(delim)
+	if start >= len(s) || s[start] == ' ' || s[start] == '\n' {
+		return -1
+	}
+	if delim[0] == '_' && open > 0 && isWordChar(s[open-1]) {
+		return -1
+	}
+
+	for j := start + 1; j < len(s); j++ {
+		switch {
+		case s[j] == '\\':
+			j++
+		case s[j] == '`':
+			ticks := len(s[j:]) - len(strings.TrimLeft(s[j:], "`"))
+			if end := findCodeSpanEnd(s, j+ticks, ticks); end >= 0 {
+				j = end + ticks - 1
+			} else {
+				j += ticks - 1
+			}
+		case strings.HasPrefix(s[j:], delim):
+			if len(delim) == 1 && j+1 < len(s) && s[j+1] == delim[0] {
+				// Part of a nested double delimiter; skip it
+				j++
+				continue
+			}
+			if s[j-1] == ' ' || s[j-1] == '\n' {
+				continue
+			}
+			if delim[0] == '_' && j+len(delim) < len(s) && isWordChar(s[j+len(delim)]) {
+				continue
+			}
+			// In a run like "***", close with the last delimiters so the
+			// inner one can close a nested emphasis
+			for j+len(delim) < len(s) && s[j+len(delim)] == delim[0] {
+				j++
+			}
+			return j
+		}
+	}
+	return -1
+}
+
+// findCodeSpanEnd finds the closing run of exactly ticks backticks at or after start, or -1
+func findCodeSpanEnd(s string, start, ticks int) int {
+	for j := start; j < len(s); {
+		if s[j] != '`' {
+			j++
+			continue
+		}
+		run := len(s[j:]) - len(strings.TrimLeft(s[j:], "`"))
+		if run == ticks {
+			return j
+		}
+		j += run
+	}
+	return -1
+}
+
+// parseLink parses [text](url "title") at the start of s.
+// Returns the text, the URL and the length consumed (0 if s doesn't start with a link).
+func parseLink(s string) (string, string, int) {
+	depth := 0
+	closeText := -1
+	for j := 0; j < len(s) && closeText < 0; j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closeText = j
+			}
+		}
+	}
+	if closeText < 0 || closeText+1 >= len(s) || s[closeText+1] != '(' {
+		return "", "", 0
+	}
+
+	depth = 0
+	for j := closeText + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				dest := strings.TrimSpace(s[closeText+2 : j])
+				if fields := strings.Fields(dest); len(fields) > 0 {
+					dest = fields[0] // Drop an optional title
+				}
+				dest = strings.TrimSuffix(strings.TrimPrefix(dest, "<"), ">")
+				if dest == "" {
+					return "", "", 0
+				}
+				return s[1:closeText], dest, j + 1
+			}
+		case '\n':
+			return "", "", 0
+		}
+	}
+	return "", "", 0
+}
+
+// textNode creates a text node with the given marks
+func textNode(text string, marks []interface{}) map[string]interface{} {
+	node := map[string]interface{}{"type": "text", "text": text}
+	if len(marks) > 0 {
+		node["marks"] = marks
+	}
+	return node
+}
+
+// appendText appends text, merging it into the previous node when the marks match
+func appendText(nodes []interface{}, text string, marks []interface{}) []interface{} {
+	if text == "" {
+		return nodes
+	}
+	return appendNodes(nodes, []interface{}{textNode(text, marks)})
+}
+
+// appendNodes appends inline nodes, merging adjacent text nodes with the same marks
+func appendNodes(nodes []interface{}, more []interface{}) []interface{} {
+	for _, n := range more {
+		node := n.(map[string]interface{})
+		if len(nodes) > 0 && node["type"] == "text" {
+			prev := nodes[len(nodes)-1].(map[string]interface{})
+			if prev["type"] == "text" && reflect.DeepEqual(prev["marks"], node["marks"]) {
+				prev["text"] = prev["text"].(string) + node["text"].(string)
+				continue
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// withMark returns marks plus mark, unless a mark of that type is already applied
+func withMark(marks []interface{}, mark map[string]interface{}) []interface{} {
+	if hasMark(marks, mark["type"].(string)) {
+		return marks
+	}
+	result := make([]interface{}, len(marks), len(marks)+1)
+	copy(result, marks)
+	return append(result, mark)
+}
+
+// hasMark reports whether marks include a mark of the given type
+func hasMark(marks []interface{}, markType string) bool {
+	for _, m := range marks {
+		if m.(map[string]interface{})["type"] == markType {
+			return true
+		}
+	}
+	return false
+}
+
+// codeMarks returns the marks for inline code: ADF only allows code to be combined with a link
+func codeMarks(marks []interface{}) []interface{} {
+	var result []interface{}
+	for _, m := range marks {
+		if m.(map[string]interface{})["type"] == "link" {
+			result = append(result, m)
+		}
+	}
+	return append(result, map[string]interface{}{"type": "code"})
+}
+
+// linkMark creates a link mark
+func linkMark(href string) map[string]interface{} {
+	return map[string]interface{}{"type": "link", "attrs": map[string]interface{}{"href": href}}
+}
+
+// expandIndentTabs expands tabs in the indentation of lines, and after list
+// markers, to 4-column tab stops so nesting can be measured in spaces. Lines
+// inside fenced code blocks and tabs within text are kept as written.
+func expandIndentTabs(lines []string) []string {
+	fence := ""
+	for i, line := range lines {
+		m := mdAnyFenceRe.FindStringSubmatch(line)
+		if fence != "" {
+			// Only a fence at least as long as the opening one closes it
+			if m != nil && m[1][0] == fence[0] && len(m[1]) >= len(fence) &&
+				strings.TrimSpace(line) == m[1] {
+				fence = ""
+			}
+			continue
+		}
+		if m != nil {
+			fence = m[1]
+		}
+		lines[i] = expandLeadingTabs(line)
+	}
+	return lines
+}
+
+// expandLeadingTabs expands tabs in the leading whitespace of line, and in the
+// whitespace after a list marker, to 4-column tab stops
+func expandLeadingTabs(line string) string {
+	indent := len(line) - len(strings.TrimLeft(line, " \t"))
+	if strings.Contains(line[:indent], "\t") {
+		line = strings.Repeat(" ", tabColumns(line[:indent], 0)) + line[indent:]
+	}
+	if m := mdMarkerTabRe.FindStringSubmatch(line); m != nil {
+		line = m[1] + strings.Repeat(" ", tabColumns(m[2], len(m[1]))) + line[len(m[0]):]
+	}
+	return line
+}
+
+// tabColumns returns the width of spaces and tabs in ws, starting at column
+// start, with tab stops every 4 columns
+func tabColumns(ws string, start int) int {
+	col := start
+	for _, c := range ws {
+		if c == '\t' {
+			col += 4 - col%4
+		} else {
+			col++
+		}
+	}
+	return col - start
+}
+
+// trimLeftSpaces removes up to n leading spaces
+func trimLeftSpaces(line string, n int) string {
+	i := 0
+	for i < n && i < len(line) && line[i] == ' ' {
+		i++
+	}
+	return line[i:]
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isURLBoundary(c byte) bool {
+	return c == ' ' || c == '\n' || c == '(' || c == '*' || c == '_' || c == '~'
+}
+
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
@@ -0,0 +1,230 @@
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+)
+
+// mustJSON encodes v for comparison in tests
+func mustJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	return string(b)
+}
+
+// docJSON decodes an expected document and re-encodes it with sorted keys
+func docJSON(t *testing.T, content string) string {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(`{"type":"doc","version":1,"content":`+content+`}`), &v); err != nil {
+		t.Fatalf("Invalid expected JSON: %v", err)
+	}
+	return mustJSON(t, v)
+}
+
+func TestMarkdownToADF(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{
+			name:     "heading",
+			markdown: "## Release notes ##",
+			expected: `[{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Release notes"}]}]`,
+		},
+		{
+			name:     "inline marks",
+			markdown: "**bold** *em* ~~gone~~ `x := 1` snake_case",
+			expected: `[{"type":"paragraph","content":[
+				{"type":"text","text":"bold","marks":[{"type":"strong"}]},{"type":"text","text":" "},
+				{"type":"text","text":"em","marks":[{"type":"em"}]},{"type":"text","text":" "},
+				{"type":"text","text":"gone","marks":[{"type":"strike"}]},{"type":"text","text":" "},
+				{"type":"text","text":"x := 1","marks":[{"type":"code"}]},{"type":"text","text":" snake_case"}]}]`,
+		},
+		{
+			name:     "nested marks",
+			markdown: "***both*** **bold *and em***",
+			expected: `[{"type":"paragraph","content":[
+				{"type":"text","text":"both","marks":[{"type":"strong"},{"type":"em"}]},{"type":"text","text":" "},
+				{"type":"text","text":"bold ","marks":[{"type":"strong"}]},
+				{"type":"text","text":"and em","marks":[{"type":"strong"},{"type":"em"}]}]}]`,
+		},
+		{
+			name:     "links",
+			markdown: "See [the **docs**](https://example.com/docs \"Docs\"), <https://a.io> or https://b.io/x.",
+			expected: `[{"type":"paragraph","content":[
+				{"type":"text","text":"See "},
+				{"type":"text","text":"the ","marks":[{"type":"link","attrs":{"href":"https://example.com/docs"}}]},
+				{"type":"text","text":"docs","marks":[{"type":"link","attrs":{"href":"https://example.com/docs"}},{"type":"strong"}]},
+				{"type":"text","text":", "},
+				{"type":"text","text":"https://a.io","marks":[{"type":"link","attrs":{"href":"https://a.io"}}]},
+				{"type":"text","text":" or "},
+				{"type":"text","text":"https://b.io/x","marks":[{"type":"link","attrs":{"href":"https://b.io/x"}}]},
+				{"type":"text","text":"."}]}]`,
+		},
+		{
+			name:     "soft and hard breaks",
+			markdown: "one\ntwo  \nthree\\\nfour\n\nnext",
+			expected: `[{"type":"paragraph","content":[
+				{"type":"text","text":"one two"},{"type":"hardBreak"},{"type":"text","text":"three"},{"type":"hardBreak"},{"type":"text","text":"four"}]},
+				{"type":"paragraph","content":[{"type":"text","text":"next"}]}]`,
+		},
+		{
+			name:     "escapes",
+			markdown: `\*not em\* and \[not a link\]`,
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"*not em* and [not a link]"}]}]`,
+		},
+		{
+			name:     "nested bullet list",
+			markdown: "- one\n- two\n  - nested\n* three",
+			expected: `[{"type":"bulletList","content":[
+				{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"one"}]}]},
+				{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"two"}]},
+					{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"nested"}]}]}]}]},
+				{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"three"}]}]}]}]`,
+		},
+		{
+			name:     "ordered list with start",
+			markdown: "3. third\n4. fourth",
+			expected: `[{"type":"orderedList","attrs":{"order":3},"content":[
+				{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"third"}]}]},
+				{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"fourth"}]}]}]}]`,
+		},
+		{
+			name:     "task list",
+			markdown: "- [ ] write tests\n- [x] ship it",
+			expected: `[{"type":"taskList","attrs":{"localId":"task-1"},"content":[
+				{"type":"taskItem","attrs":{"localId":"task-2","state":"TODO"},"content":[{"type":"text","text":"write tests"}]},
+				{"type":"taskItem","attrs":{"localId":"task-3","state":"DONE"},"content":[{"type":"text","text":"ship it"}]}]}]`,
+		},
+		{
+			name:     "fenced code with language",
+			markdown: "```go\nfunc main() {\n\t*x* = 1\n}\n```",
+			expected: `[{"type":"codeBlock","attrs":{"language":"go"},"content":[{"type":"text","text":"func main() {\n\t*x* = 1\n}"}]}]`,
+		},
+		{
+			name:     "tabs in text and inline code",
+			markdown: "a\tb `c\td`",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"a\tb "},{"type":"text","text":"c\td","marks":[{"type":"code"}]}]}]`,
+		},
+		{
+			name:     "tab-indented nested list",
+			markdown: "-\tone\n\t- two",
+			expected: `[{"type":"bulletList","content":[{"type":"listItem","content":[
+				{"type":"paragraph","content":[{"type":"text","text":"one"}]},
+				{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"two"}]}]}]}]}]}]`,
+		},
+		{
+			name:     "tabs in fenced code inside a list item",
+			markdown: "- item\n\n  ```\n\tindented\n  ```",
+			expected: `[{"type":"bulletList","content":[{"type":"listItem","content":[
+				{"type":"paragraph","content":[{"type":"text","text":"item"}]},
+				{"type":"codeBlock","content":[{"type":"text","text":"\tindented"}]}]}]}]`,
+		},
+		{
+			name:     "blockquote",
+			markdown: "> # Quoted\n> - item",
+			expected: `[{"type":"blockquote","content":[
+				{"type":"paragraph","content":[{"type":"text","text":"Quoted"}]},
+				{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"item"}]}]}]}]}]`,
+		},
+		{
+			name:     "table",
+			markdown: "| Key | Status |\n|:----|-------:|\n| PROJ-1 | **Done** |\n| PROJ-2 |",
+			expected: `[{"type":"table","attrs":{"isNumberColumnEnabled":false,"layout":"default"},"content":[
+				{"type":"tableRow","content":[
+					{"type":"tableHeader","attrs":{},"content":[{"type":"paragraph","content":[{"type":"text","text":"Key"}]}]},
+					{"type":"tableHeader","attrs":{},"content":[{"type":"paragraph","content":[{"type":"text","text":"Status"}]}]}]},
+				{"type":"tableRow","content":[
+					{"type":"tableCell","attrs":{},"content":[{"type":"paragraph","content":[{"type":"text","text":"PROJ-1"}]}]},
+					{"type":"tableCell","attrs":{},"content":[{"type":"paragraph","content":[{"type":"text","text":"Done","marks":[{"type":"strong"}]}]}]}]},
+				{"type":"tableRow","content":[
+					{"type":"tableCell","attrs":{},"content":[{"type":"paragraph","content":[{"type":"text","text":"PROJ-2"}]}]},
+					{"type":"tableCell","attrs":{},"content":[{"type":"paragraph"}]}]}]}]`,
+		},
+		{
+			name:     "rule",
+			markdown: "above\n\n---\n\nbelow",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"above"}]},{"type":"rule"},
+				{"type":"paragraph","content":[{"type":"text","text":"below"}]}]`,
+		},
+		{
+			name:     "number in wrapped text is not a list",
+			markdown: "Released in\n2024. Thanks",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"Released in 2024. Thanks"}]}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mustJSON(t, MarkdownToADF(tt.markdown))
+			expected := docJSON(t, tt.expected)
+			if got != expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+			}
+		})
+	}
+}
+
+func TestMarkdownToADF_PlainTextRoundTrip(t *testing.T) {
+	markdown := "# Summary\n\nFixed the **login** bug.\n\n- step one\n- step two"
+	expected := "Summary\nFixed the login bug.\n• step one\n• step two"
+
+	if got := ADFToPlainText(MarkdownToADF(markdown)); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestPlainTextToADF(t *testing.T) {
+	got := mustJSON(t, PlainTextToADF("# not a heading\nline two\n\n*second*"))
+	expected := docJSON(t, `[
+		{"type":"paragraph","content":[{"type":"text","text":"# not a heading"},{"type":"hardBreak"},{"type":"text","text":"line two"}]},
+		{"type":"paragraph","content":[{"type":"text","text":"*second*"}]}]`)
+
+	if got != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestToADF(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		format      string
+		expectError bool
+		expected    string
+	}{
+		{"default is markdown", "**hi**", "", false, `[{"type":"paragraph","content":[{"type":"text","text":"hi","marks":[{"type":"strong"}]}]}]`},
+		{"plain", "**hi**", "plain", false, `[{"type":"paragraph","content":[{"type":"text","text":"**hi**"}]}]`},
+		{"adf", `{"type":"doc","content":[{"type":"rule"}]}`, "ADF", false, `[{"type":"rule"}]`},
+		{"adf not a doc", `{"type":"paragraph"}`, "adf", true, ""},
+		{"adf invalid json", `{`, "adf", true, ""},
+		{"unknown format", "hi", "html", true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ToADF(tt.text, tt.format)
+			if tt.expectError {
+				var validationErr *client.ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Errorf("Expected validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got, expected := mustJSON(t, doc), docJSON(t, tt.expected); got != expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package jira
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -74,19 +77,21 @@ func (s *MetadataService) GetCreateMetadata(projectKey, issueType string) (*Issu
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("failed to fetch create metadata: HTTP %d", resp.StatusCode())
+		return nil, client.NewError(resp.StatusCode(), fmt.Sprintf("failed to fetch create metadata: HTTP %d", resp.StatusCode()))
 	}
 
 	// Parse response
 	if len(response.Projects) == 0 {
-		return nil, fmt.Errorf("project '%s' not found or you don't have access", projectKey)
+		msg := fmt.Sprintf("project '%s' not found or you don't have access", projectKey)
+		return nil, client.NewValidationError(msg, map[string]string{"project": msg})
 	}
 
 	project := response.Projects[0]
 
 	// Find the requested issue type
 	if len(project.IssueTypes) == 0 {
-		return nil, fmt.Errorf("issue type '%s' not found in project '%s'", issueType, projectKey)
+		msg := fmt.Sprintf("issue type '%s' not found in project '%s'", issueType, projectKey)
+		return nil, client.NewValidationError(msg, map[string]string{"issuetype": msg})
 	}
 
 	// Use the first issue type (should match our filter)
@@ -103,6 +108,49 @@ func (s *MetadataService) GetCreateMetadata(projectKey, issueType string) (*Issu
 	return meta, nil
 }
 
+// GetEditMetadata fetches the fields that can be edited on an issue, with
+// their schemas and allowed values
+func (s *MetadataService) GetEditMetadata(issueKey string) (*IssueTypeMeta, error) {
+	if issueKey == "" {
+		return nil, fmt.Errorf("issue key cannot be empty")
+	}
+
+	// Check cache first
+	cacheKey := "edit:" + issueKey
+	if meta := s.cache.get(cacheKey); meta != nil {
+		return meta, nil
+	}
+
+	var response models.EditMetaResponse
+	var errorResp models.ErrorResponse
+
+	resp, err := s.client.GetRequest().
+		SetResult(&response).
+		SetError(&errorResp).
+		Get(fmt.Sprintf("/issue/%s/editmeta", issueKey))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch edit metadata: %w", err)
+	}
+
+	if resp.IsError() {
+		if resp.StatusCode() == 404 {
+			return nil, client.NewError(resp.StatusCode(), fmt.Sprintf("issue '%s' not found", issueKey))
+		}
+		return nil, formatErrorResponse(resp, &errorResp)
+	}
+
+	meta := &IssueTypeMeta{
+		Name:   issueKey,
+		Fields: response.Fields,
+	}
+
+	// Cache the result
+	s.cache.set(cacheKey, meta)
+
+	return meta, nil
+}
+
 // ValidateIssueData validates issue data against the metadata schema
 // It checks for required fields, correct types, and allowed values
 func (s *MetadataService) ValidateIssueData(projectKey, issueType string, data map[string]interface{}) error {
@@ -125,12 +173,12 @@ func (s *MetadataService) ValidateIssueData(projectKey, issueType string, data m
 
 		value, exists := data[fieldID]
 		if !exists {
-			return fmt.Errorf("field '%s' (%s) is required for %s in project %s", fieldMeta.Name, fieldID, issueType, projectKey)
+			return fieldError(fieldID, "field '%s' (%s) is required for %s in project %s", fieldMeta.Name, fieldID, issueType, projectKey)
 		}
 
 		// Check for nil or empty values
 		if value == nil {
-			return fmt.Errorf("field '%s' (%s) cannot be nil", fieldMeta.Name, fieldID)
+			return fieldError(fieldID, "field '%s' (%s) cannot be nil", fieldMeta.Name, fieldID)
 		}
 	}
 
@@ -163,32 +211,192 @@ func (s *MetadataService) ValidateIssueData(projectKey, issueType string, data m
 	return nil
 }
 
+// ValidateEditData validates an update against an issue's edit metadata.
+// Each field must be editable (on the issue's edit screen and supporting the
+// operation), of the expected type and one of the allowed values, and
+// required fields can't be cleared. All problems are reported in a single
+// ValidationError.
+// Parameters:
+//   - issueKey: The issue being updated
+//   - data: New field values by field ID
+//   - update: Update operations by field ID (may be nil)
+func (s *MetadataService) ValidateEditData(issueKey string, data map[string]interface{}, update FieldOperations) error {
+	meta, err := s.GetEditMetadata(issueKey)
+	if err != nil {
+		return err
+	}
+
+	fieldIDs := make([]string, 0, len(data))
+	for fieldID := range data {
+		fieldIDs = append(fieldIDs, fieldID)
+	}
+	sort.Strings(fieldIDs)
+
+	var errs []error
+	for _, fieldID := range fieldIDs {
+		value := data[fieldID]
+
+		fieldMeta, exists := meta.Fields[fieldID]
+		if !exists {
+			errs = append(errs, fieldError(fieldID, "field '%s' is not editable on %s (not on its edit screen, or you lack permission)", fieldID, issueKey))
+			continue
+		}
+		if len(fieldMeta.Operations) > 0 && !containsString(fieldMeta.Operations, "set") {
+			errs = append(errs, fieldError(fieldID, "field '%s' (%s) cannot be set on %s (supported operations: %s)", fieldMeta.Name, fieldID, issueKey, strings.Join(fieldMeta.Operations, ", ")))
+			continue
+		}
+
+		if value == nil {
+			if fieldMeta.Required {
+				errs = append(errs, fieldError(fieldID, "field '%s' (%s) is required and cannot be cleared", fieldMeta.Name, fieldID))
+			}
+			continue
+		}
+
+		if err := s.validateFieldType(fieldID, fieldMeta, value); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if len(fieldMeta.AllowedValues) > 0 {
+			values := []interface{}{value}
+			if items, ok := value.([]interface{}); ok {
+				values = items
+			}
+			for _, item := range values {
+				if err := s.validateAllowedValues(fieldID, fieldMeta, item); err != nil {
+					errs = append(errs, err)
+					break
+				}
+			}
+		}
+	}
+
+	errs = append(errs, s.validateEditOperations(issueKey, meta, data, update)...)
+
+	return mergeValidationErrors(errs)
+}
+
+// validateEditOperations validates update operations (see ValidateEditData)
+func (s *MetadataService) validateEditOperations(issueKey string, meta *IssueTypeMeta, data map[string]interface{}, update FieldOperations) []error {
+	fieldIDs := make([]string, 0, len(update))
+	for fieldID := range update {
+		fieldIDs = append(fieldIDs, fieldID)
+	}
+	sort.Strings(fieldIDs)
+
+	var errs []error
+	for _, fieldID := range fieldIDs {
+		if _, ok := data[fieldID]; ok {
+			errs = append(errs, fieldError(fieldID, "field '%s' can't be both set and changed with update operations", fieldID))
+			continue
+		}
+
+		fieldMeta, exists := meta.Fields[fieldID]
+		if !exists {
+			errs = append(errs, fieldError(fieldID, "field '%s' is not editable on %s (not on its edit screen, or you lack permission)", fieldID, issueKey))
+			continue
+		}
+
+	operations:
+		for _, operation := range update[fieldID] {
+			for op, value := range operation {
+				if len(fieldMeta.Operations) > 0 && !containsString(fieldMeta.Operations, op) {
+					errs = append(errs, fieldError(fieldID, "field '%s' (%s) does not support '%s' on %s (supported operations: %s)", fieldMeta.Name, fieldID, op, issueKey, strings.Join(fieldMeta.Operations, ", ")))
+					break operations
+				}
+				// Values being removed may no longer be allowed
+				if op != "remove" && value != nil && len(fieldMeta.AllowedValues) > 0 {
+					if err := s.validateAllowedValues(fieldID, fieldMeta, value); err != nil {
+						errs = append(errs, err)
+						break operations
+					}
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+// mergeValidationErrors combines per-field validation errors into one
+// ValidationError (nil when there are none)
+func mergeValidationErrors(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+
+	messages := make([]string, 0, len(errs))
+	merged := client.NewValidationError("", make(map[string]string))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+
+		var validationErr *client.ValidationError
+		if !errors.As(err, &validationErr) {
+			continue
+		}
+		for field, msg := range validationErr.FieldErrors {
+			merged.FieldErrors[field] = msg
+		}
+		for field, allowed := range validationErr.AllowedValues {
+			if merged.AllowedValues == nil {
+				merged.AllowedValues = make(map[string][]string)
+			}
+			merged.AllowedValues[field] = allowed
+		}
+		merged.Hints = append(merged.Hints, validationErr.Hints...)
+	}
+	merged.Message = fmt.Sprintf("%d fields failed validation: %s", len(errs), strings.Join(messages, "; "))
+	return merged
+}
+
+// containsString reports whether list contains value
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
 // validateFieldType checks if the value matches the expected field type
 func (s *MetadataService) validateFieldType(fieldID string, meta models.FieldMeta, value interface{}) error {
 	schemaType := meta.Schema.Type
 
 	switch schemaType {
 	case "string":
+		// Rich-text fields take an ADF document (see ToADF)
+		if doc, ok := value.(map[string]interface{}); ok && IsRichTextSchema(meta.Schema) && doc["type"] == "doc" {
+			break
+		}
 		if _, ok := value.(string); !ok {
-			return fmt.Errorf("field '%s' (%s) expects string, got %T", meta.Name, fieldID, value)
+			return fieldError(fieldID, "field '%s' (%s) expects string, got %T", meta.Name, fieldID, value)
 		}
 	case "number":
 		switch value.(type) {
 		case int, int64, float64, float32:
 			// Valid number types
 		default:
-			return fmt.Errorf("field '%s' (%s) expects number, got %T", meta.Name, fieldID, value)
+			return fieldError(fieldID, "field '%s' (%s) expects number, got %T", meta.Name, fieldID, value)
 		}
 	case "array":
+		if meta.Schema.Custom == sprintFieldType {
+			// Set to a single sprint ID (see CoerceFieldValue)
+			break
+		}
 		if _, ok := value.([]interface{}); !ok {
-			return fmt.Errorf("field '%s' (%s) expects array, got %T", meta.Name, fieldID, value)
+			return fieldError(fieldID, "field '%s' (%s) expects array, got %T", meta.Name, fieldID, value)
 		}
 	case "option", "priority", "user", "project", "issuetype":
 		// These are typically objects with specific structures
 		if _, ok := value.(map[string]interface{}); !ok {
 			// Also allow string for some types (like project key)
 			if _, ok := value.(string); !ok {
-				return fmt.Errorf("field '%s' (%s) expects object or string, got %T", meta.Name, fieldID, value)
+				return fieldError(fieldID, "field '%s' (%s) expects object or string, got %T", meta.Name, fieldID, value)
 			}
 		}
 	}
@@ -205,9 +413,11 @@ func (s *MetadataService) validateAllowedValues(fieldID string, meta models.Fiel
 	case string:
 		valueToCheck = v
 	case map[string]interface{}:
-		// Try to get 'name' or 'id' field
+		// Try to get 'name', 'value' (select options) or 'id' field
 		if name, ok := v["name"].(string); ok {
 			valueToCheck = name
+		} else if optionValue, ok := v["value"].(string); ok {
+			valueToCheck = optionValue
 		} else if id, ok := v["id"].(string); ok {
 			valueToCheck = id
 		} else {
@@ -226,26 +436,29 @@ func (s *MetadataService) validateAllowedValues(fieldID string, meta models.Fiel
 			continue
 		}
 
-		// Check both name and id
+		// Check name, value and id
 		if name, ok := allowedMap["name"].(string); ok && name == valueToCheck {
 			return nil
 		}
+		if optionValue, ok := allowedMap["value"].(string); ok && optionValue == valueToCheck {
+			return nil
+		}
 		if id, ok := allowedMap["id"].(string); ok && id == valueToCheck {
 			return nil
 		}
 	}
 
 	// Build list of allowed values for error message
-	allowedList := make([]string, 0, len(meta.AllowedValues))
-	for _, allowed := range meta.AllowedValues {
-		if allowedMap, ok := allowed.(map[string]interface{}); ok {
-			if name, ok := allowedMap["name"].(string); ok {
-				allowedList = append(allowedList, name)
-			}
-		}
-	}
+	allowedList := allowedLabels(meta.AllowedValues)
+
+	msg := fmt.Sprintf("field '%s' (%s) value '%s' not in allowed values: %v", meta.Name, fieldID, valueToCheck, allowedList)
+	return client.NewAllowedValuesError(fieldID, msg, allowedList)
+}
 
-	return fmt.Errorf("field '%s' (%s) value '%s' not in allowed values: %v", meta.Name, fieldID, valueToCheck, allowedList)
+// fieldError returns a validation error for a single field
+func fieldError(fieldID, format string, args ...interface{}) error {
+	msg := fmt.Sprintf(format, args...)
+	return client.NewValidationError(msg, map[string]string{fieldID: msg})
 }
 
 // get retrieves a cached entry if it exists and hasn't expired
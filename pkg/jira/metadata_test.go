@@ -1,8 +1,10 @@
 package jira
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
 	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
 )
 
@@ -236,3 +238,176 @@ func TestCacheClear(t *testing.T) {
 		t.Error("Expected cache miss after clear")
 	}
 }
+
+func TestValidateAllowedValues_ErrorDetails(t *testing.T) {
+	svc := &MetadataService{}
+
+	fieldMeta := models.FieldMeta{
+		Name: "Priority",
+		AllowedValues: []interface{}{
+			map[string]interface{}{"id": "1", "name": "High"},
+			map[string]interface{}{"id": "2", "name": "Low"},
+		},
+	}
+
+	err := svc.validateAllowedValues("priority", fieldMeta, "Critical")
+
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %T", err)
+	}
+
+	allowed := validationErr.AllowedValues["priority"]
+	if len(allowed) != 2 || allowed[0] != "High" || allowed[1] != "Low" {
+		t.Errorf("Expected allowed values [High Low], got %v", allowed)
+	}
+	if validationErr.FieldErrors["priority"] == "" {
+		t.Error("Expected a field error for priority")
+	}
+}
+
+func TestValidateEditData(t *testing.T) {
+	color := coerceTestFields["customfield_10001"]
+	color.Operations = []string{"set"}
+
+	svc := NewMetadataService(nil)
+	svc.cache.set("edit:PROJ-1", &IssueTypeMeta{
+		Name: "PROJ-1",
+		Fields: map[string]models.FieldMeta{
+			"summary":           {Required: true, Name: "Summary", Schema: models.FieldSchema{Type: "string"}, Operations: []string{"set"}},
+			"priority":          coerceTestFields["priority"],
+			"components":        coerceTestFields["components"],
+			"customfield_10001": color,
+			"customfield_10005": coerceTestFields["customfield_10005"],
+			"comment":           {Name: "Comment", Schema: models.FieldSchema{Type: "comments-page"}, Operations: []string{"add"}},
+			"labels":            {Name: "Labels", Schema: models.FieldSchema{Type: "array", Items: "string"}, Operations: []string{"add", "set", "remove"}},
+			"description":       {Name: "Description", Schema: models.FieldSchema{Type: "string", System: "description"}, Operations: []string{"set"}},
+			"customfield_10020": {Name: "Notes", Schema: models.FieldSchema{Type: "string", Custom: textAreaFieldType}, Operations: []string{"set"}},
+		},
+	})
+
+	description, _ := ToADF("Steps:\n\n1. Log in", "markdown")
+	notes, _ := ToADF("Line one\nLine two", "plain")
+
+	tests := []struct {
+		name    string
+		data    map[string]interface{}
+		update  FieldOperations
+		invalid []string
+	}{
+		{
+			name: "valid",
+			data: map[string]interface{}{
+				"summary":           "New title",
+				"priority":          map[string]interface{}{"name": "High"},
+				"components":        []interface{}{map[string]interface{}{"name": "API"}},
+				"customfield_10001": map[string]interface{}{"value": "Red"},
+				"customfield_10005": 42,
+			},
+		},
+		{
+			name: "rich text documents",
+			data: map[string]interface{}{
+				"description":       description,
+				"customfield_10020": notes,
+			},
+		},
+		{
+			name: "non-document object in rich text",
+			data: map[string]interface{}{
+				"description": map[string]interface{}{"text": "x"},
+				"summary":     map[string]interface{}{"type": "doc"},
+			},
+			invalid: []string{"description", "summary"},
+		},
+		{
+			name:    "not on the edit screen",
+			data:    map[string]interface{}{"customfield_99999": "x"},
+			invalid: []string{"customfield_99999"},
+		},
+		{
+			name:    "set not supported",
+			data:    map[string]interface{}{"comment": "hello"},
+			invalid: []string{"comment"},
+		},
+		{
+			name:    "required field cleared",
+			data:    map[string]interface{}{"summary": nil},
+			invalid: []string{"summary"},
+		},
+		{
+			name: "wrong type and disallowed values",
+			data: map[string]interface{}{
+				"summary":           42,
+				"components":        []interface{}{map[string]interface{}{"name": "API"}, map[string]interface{}{"name": "Frontend"}},
+				"customfield_10001": map[string]interface{}{"value": "Purple"},
+			},
+			invalid: []string{"components", "customfield_10001", "summary"},
+		},
+		{
+			name: "valid operations",
+			update: FieldOperations{
+				"labels":     {{"add": "urgent"}, {"remove": "stale"}},
+				"components": {{"add": map[string]interface{}{"name": "API"}}, {"remove": map[string]interface{}{"name": "Legacy"}}},
+				"comment":    {{"add": map[string]interface{}{"body": "hello"}}},
+			},
+		},
+		{
+			name: "invalid operations",
+			data: map[string]interface{}{"labels": []interface{}{"a"}},
+			update: FieldOperations{
+				"labels":            {{"add": "urgent"}},
+				"components":        {{"add": map[string]interface{}{"name": "Frontend"}}},
+				"customfield_10001": {{"remove": map[string]interface{}{"value": "Red"}}},
+				"customfield_99999": {{"add": "x"}},
+			},
+			invalid: []string{"components", "customfield_10001", "customfield_99999", "labels"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.ValidateEditData("PROJ-1", tt.data, tt.update)
+			if len(tt.invalid) == 0 {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+				return
+			}
+
+			var validationErr *client.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %v", err)
+			}
+			if len(validationErr.FieldErrors) != len(tt.invalid) {
+				t.Errorf("Expected errors for %v, got %v", tt.invalid, validationErr.FieldErrors)
+			}
+			for _, field := range tt.invalid {
+				if validationErr.FieldErrors[field] == "" {
+					t.Errorf("Expected an error for %s, got %v", field, validationErr.FieldErrors)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateEditData_AllowedValues(t *testing.T) {
+	svc := NewMetadataService(nil)
+	svc.cache.set("edit:PROJ-1", &IssueTypeMeta{
+		Name:   "PROJ-1",
+		Fields: map[string]models.FieldMeta{"customfield_10001": coerceTestFields["customfield_10001"]},
+	})
+
+	err := svc.ValidateEditData("PROJ-1", map[string]interface{}{
+		"customfield_10001": map[string]interface{}{"value": "Purple"},
+	}, nil)
+
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+	allowed := validationErr.AllowedValues["customfield_10001"]
+	if len(allowed) != 2 || allowed[0] != "Red" || allowed[1] != "Blue" {
+		t.Errorf("Expected allowed values [Red Blue], got %v", allowed)
+	}
+}
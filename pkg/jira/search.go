@@ -2,7 +2,6 @@ package jira
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
 	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
@@ -20,12 +19,21 @@ func NewSearchService(client *client.Client) *SearchService {
 
 // SearchRequest represents a JQL search request
 type SearchRequest struct {
-	JQL        string   `json:"jql"`
-	StartAt    int      `json:"startAt,omitempty"`
-	MaxResults int      `json:"maxResults,omitempty"`
-	Fields     []string `json:"fields,omitempty"`
+	JQL           string   `json:"jql"`
+	StartAt       int      `json:"startAt,omitempty"`
+	MaxResults    int      `json:"maxResults,omitempty"`
+	Fields        []string `json:"fields,omitempty"`
+	NextPageToken string   `json:"nextPageToken,omitempty"`
 }
 
+const (
+	// searchPageSize is the page size used when paginating through all results
+	searchPageSize = 100
+
+	// DefaultSearchAllMax is the default hard cap on issues returned by SearchAll
+	DefaultSearchAllMax = 1000
+)
+
 // Search executes a JQL query and returns matching issues
 // Parameters:
 //   - jql: JQL query string (e.g., "project = PROJ AND status = Open")
@@ -42,7 +50,7 @@ func (s *SearchService) Search(jql string, maxResults int, fields []string) (*mo
 	}
 
 	// If no specific fields requested, get all fields (including custom fields)
-	if fields == nil || len(fields) == 0 {
+	if len(fields) == 0 {
 		fields = []string{"*all"}
 	}
 
@@ -52,10 +60,90 @@ func (s *SearchService) Search(jql string, maxResults int, fields []string) (*mo
 		Fields:     fields,
 	}
 
+	return s.searchPage(req)
+}
+
+// SearchAll executes a JQL query and follows pagination until the results are
+// exhausted or maxIssues issues have been returned.
+// Each page is handed to onPage as soon as it arrives, so callers can stream
+// output instead of buffering the full result set.
+// Parameters:
+//   - jql: JQL query string
+//   - fields: List of fields to include in response (nil = all fields)
+//   - maxIssues: Hard cap on the number of issues returned (0 = DefaultSearchAllMax)
+//   - onPage: Callback invoked with the issues of each page; returning an error stops the iteration
+//
+// Returns the number of issues delivered to onPage.
+func (s *SearchService) SearchAll(jql string, fields []string, maxIssues int, onPage func(issues []models.Issue) error) (int, error) {
+	if jql == "" {
+		return 0, fmt.Errorf("JQL query cannot be empty")
+	}
+
+	if maxIssues <= 0 {
+		maxIssues = DefaultSearchAllMax
+	}
+
+	if len(fields) == 0 {
+		fields = []string{"*all"}
+	}
+
+	req := SearchRequest{
+		JQL:    jql,
+		Fields: fields,
+	}
+
+	count := 0
+	for count < maxIssues {
+		req.MaxResults = searchPageSize
+		if remaining := maxIssues - count; remaining < searchPageSize {
+			req.MaxResults = remaining
+		}
+
+		page, err := s.searchPage(req)
+		if err != nil {
+			return count, err
+		}
+
+		issues := page.Issues
+		if len(issues) > maxIssues-count {
+			issues = issues[:maxIssues-count]
+		}
+
+		if len(issues) > 0 {
+			if err := onPage(issues); err != nil {
+				return count, err
+			}
+			count += len(issues)
+		}
+
+		// Work out where the next page starts. Token-based pagination takes
+		// precedence; offset-based pagination is used when the server reports
+		// a total instead of a token.
+		switch {
+		case page.NextPageToken != "" && !page.IsLast:
+			req.NextPageToken = page.NextPageToken
+		case page.NextPageToken == "" && page.Total > 0 && req.StartAt+len(page.Issues) < page.Total:
+			req.StartAt += len(page.Issues)
+		default:
+			return count, nil
+		}
+
+		if len(page.Issues) == 0 {
+			// Defensive: never loop on an empty page
+			return count, nil
+		}
+	}
+
+	return count, nil
+}
+
+// searchPage fetches a single page of search results
+func (s *SearchService) searchPage(req SearchRequest) (*models.SearchResponse, error) {
 	var result models.SearchResponse
 	var errorResp models.ErrorResponse
 
-	resp, err := s.client.HTTPClient.R().
+	// Searching is read-only, so the POST is safe to retry
+	resp, err := client.MarkIdempotent(s.client.HTTPClient.R()).
 		SetBody(req).
 		SetResult(&result).
 		SetError(&errorResp).
@@ -66,7 +154,7 @@ func (s *SearchService) Search(jql string, maxResults int, fields []string) (*mo
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return nil, formatErrorResponse(resp, &errorResp)
 	}
 
 	return &result, nil
@@ -93,29 +181,48 @@ func (s *SearchService) GetIssue(keyOrID string) (*models.Issue, error) {
 
 	if resp.IsError() {
 		if resp.StatusCode() == 404 {
-			return nil, fmt.Errorf("issue '%s' not found", keyOrID)
+			return nil, client.NewError(resp.StatusCode(), fmt.Sprintf("issue '%s' not found", keyOrID))
 		}
-		return nil, fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return nil, formatErrorResponse(resp, &errorResp)
 	}
 
 	return &issue, nil
 }
 
+// FieldOperations maps field IDs to Jira update operations (add, remove,
+// set or edit), e.g. {"labels": [{"add": "urgent"}, {"remove": "stale"}]}
+type FieldOperations map[string][]map[string]interface{}
+
 // UpdateIssue updates fields on an existing issue
 // Parameters:
 //   - keyOrID: Issue key (e.g., "PROJ-123") or ID
 //   - fields: Map of field IDs to values to update
 func (s *SearchService) UpdateIssue(keyOrID string, fields map[string]interface{}) error {
+	return s.EditIssue(keyOrID, fields, nil)
+}
+
+// EditIssue updates an existing issue, replacing field values and applying
+// update operations. Operations change multi-value fields in place, so
+// concurrent changes to other values (e.g., other labels) aren't lost.
+// Parameters:
+//   - keyOrID: Issue key (e.g., "PROJ-123") or ID
+//   - fields: Map of field IDs to new values (may be empty)
+//   - update: Operations by field ID (may be empty); a field can't be in both
+func (s *SearchService) EditIssue(keyOrID string, fields map[string]interface{}, update FieldOperations) error {
 	if keyOrID == "" {
 		return fmt.Errorf("issue key or ID cannot be empty")
 	}
 
-	if len(fields) == 0 {
+	if len(fields) == 0 && len(update) == 0 {
 		return fmt.Errorf("no fields to update")
 	}
 
-	body := map[string]interface{}{
-		"fields": fields,
+	body := map[string]interface{}{}
+	if len(fields) > 0 {
+		body["fields"] = fields
+	}
+	if len(update) > 0 {
+		body["update"] = update
 	}
 
 	var errorResp models.ErrorResponse
@@ -130,13 +237,13 @@ func (s *SearchService) UpdateIssue(keyOrID string, fields map[string]interface{
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return formatErrorResponse(resp, &errorResp)
 	}
 
 	return nil
 }
 
-// AddComment adds a comment to an issue
+// AddComment adds a comment to an issue, converting the Markdown text to ADF
 func (s *SearchService) AddComment(keyOrID, text string) (*models.Comment, error) {
 	if keyOrID == "" {
 		return nil, fmt.Errorf("issue key or ID cannot be empty")
@@ -147,21 +254,7 @@ func (s *SearchService) AddComment(keyOrID, text string) (*models.Comment, error
 	}
 
 	body := map[string]interface{}{
-		"body": map[string]interface{}{
-			"type":    "doc",
-			"version": 1,
-			"content": []map[string]interface{}{
-				{
-					"type": "paragraph",
-					"content": []map[string]interface{}{
-						{
-							"type": "text",
-							"text": text,
-						},
-					},
-				},
-			},
-		},
+		"body": MarkdownToADF(text),
 	}
 
 	var comment models.Comment
@@ -178,13 +271,14 @@ func (s *SearchService) AddComment(keyOrID, text string) (*models.Comment, error
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return nil, formatErrorResponse(resp, &errorResp)
 	}
 
 	return &comment, nil
 }
 
-// GetTransitions retrieves available transitions for an issue
+// GetTransitions retrieves available transitions for an issue, including the
+// fields on each transition's screen
 func (s *SearchService) GetTransitions(keyOrID string) ([]models.Transition, error) {
 	if keyOrID == "" {
 		return nil, fmt.Errorf("issue key or ID cannot be empty")
@@ -194,6 +288,7 @@ func (s *SearchService) GetTransitions(keyOrID string) ([]models.Transition, err
 	var errorResp models.ErrorResponse
 
 	resp, err := s.client.HTTPClient.R().
+		SetQueryParam("expand", "transitions.fields").
 		SetResult(&result).
 		SetError(&errorResp).
 		Get(fmt.Sprintf("/issue/%s/transitions", keyOrID))
@@ -203,7 +298,7 @@ func (s *SearchService) GetTransitions(keyOrID string) ([]models.Transition, err
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return nil, formatErrorResponse(resp, &errorResp)
 	}
 
 	return result.Transitions, nil
@@ -228,29 +323,45 @@ func (s *SearchService) TransitionIssue(keyOrID, statusName string) error {
 		return err
 	}
 
-	// Find matching transition (case-insensitive)
-	var transitionID string
-	for _, t := range transitions {
-		if strings.EqualFold(t.To.Name, statusName) {
-			transitionID = t.ID
-			break
-		}
+	transition, err := FindTransition(transitions, statusName)
+	if err != nil {
+		return err
 	}
 
-	if transitionID == "" {
-		available := make([]string, len(transitions))
-		for i, t := range transitions {
-			available[i] = t.To.Name
-		}
-		return fmt.Errorf("status '%s' not found. Available transitions: %v", statusName, available)
+	return s.PerformTransition(keyOrID, transition.ID, nil, nil)
+}
+
+// FindTransition finds the transition to a status (case-insensitive), or
+// with that name, among an issue's available transitions; otherwise the
+// error lists the statuses that can be reached
+func FindTransition(transitions []models.Transition, statusName string) (*models.Transition, error) {
+	return FindTransitionTo(transitions, TransitionTarget{Names: []string{statusName}})
+}
+
+// PerformTransition executes a transition on an issue, setting fields on the
+// transition screen (e.g., resolution) and applying update operations (e.g.,
+// adding a comment) at the same time
+// Parameters:
+//   - keyOrID: Issue key or ID
+//   - transitionID: ID of one of the issue's available transitions
+//   - fields: Field values to set (may be nil)
+//   - update: Update operations (may be nil)
+func (s *SearchService) PerformTransition(keyOrID, transitionID string, fields map[string]interface{}, update FieldOperations) error {
+	if keyOrID == "" {
+		return fmt.Errorf("issue key or ID cannot be empty")
 	}
 
-	// Execute transition
 	body := map[string]interface{}{
 		"transition": map[string]interface{}{
 			"id": transitionID,
 		},
 	}
+	if len(fields) > 0 {
+		body["fields"] = fields
+	}
+	if len(update) > 0 {
+		body["update"] = update
+	}
 
 	var errorResp models.ErrorResponse
 
@@ -264,7 +375,7 @@ func (s *SearchService) TransitionIssue(keyOrID, statusName string) error {
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return formatErrorResponse(resp, &errorResp)
 	}
 
 	return nil
@@ -308,7 +419,7 @@ func (s *SearchService) LinkIssues(inwardKey, outwardKey, linkType string) error
 	}
 
 	if resp.IsError() {
-		return fmt.Errorf("API error: %s", formatErrorResponse(&errorResp))
+		return formatErrorResponse(resp, &errorResp)
 	}
 
 	return nil
@@ -0,0 +1,229 @@
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+// newTestClient creates a client pointed at a test server
+func newTestClient(serverURL string) *client.Client {
+	return &client.Client{
+		BaseURL:    serverURL,
+		HTTPClient: resty.New().SetBaseURL(serverURL),
+	}
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// makeIssues builds n issues with sequential keys starting at start
+func makeIssues(start, n int) []models.Issue {
+	issues := make([]models.Issue, n)
+	for i := 0; i < n; i++ {
+		issues[i] = models.Issue{Key: fmt.Sprintf("PROJ-%d", start+i)}
+	}
+	return issues
+}
+
+func TestSearchAll_FollowsNextPageToken(t *testing.T) {
+	var requests []SearchRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req SearchRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, req)
+
+		var resp models.SearchResponse
+		switch req.NextPageToken {
+		case "":
+			resp = models.SearchResponse{Issues: makeIssues(1, 2), NextPageToken: "page2"}
+		case "page2":
+			resp = models.SearchResponse{Issues: makeIssues(3, 2), NextPageToken: "page3"}
+		default:
+			resp = models.SearchResponse{Issues: makeIssues(5, 1), IsLast: true}
+		}
+		writeJSON(w, resp)
+	}))
+	defer server.Close()
+
+	svc := NewSearchService(newTestClient(server.URL))
+
+	var keys []string
+	count, err := svc.SearchAll("project = PROJ", nil, 0, func(issues []models.Issue) error {
+		for _, issue := range issues {
+			keys = append(keys, issue.Key)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SearchAll() error = %v", err)
+	}
+
+	if count != 5 {
+		t.Errorf("expected 5 issues, got %d", count)
+	}
+	if len(requests) != 3 {
+		t.Errorf("expected 3 requests, got %d", len(requests))
+	}
+	if keys[0] != "PROJ-1" || keys[4] != "PROJ-5" {
+		t.Errorf("unexpected keys: %v", keys)
+	}
+}
+
+func TestSearchAll_FallsBackToStartAt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req SearchRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		remaining := 5 - req.StartAt
+		if remaining > 2 {
+			remaining = 2
+		}
+		writeJSON(w, models.SearchResponse{
+			StartAt: req.StartAt,
+			Total:   5,
+			Issues:  makeIssues(req.StartAt+1, remaining),
+		})
+	}))
+	defer server.Close()
+
+	svc := NewSearchService(newTestClient(server.URL))
+
+	count, err := svc.SearchAll("project = PROJ", nil, 0, func(issues []models.Issue) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SearchAll() error = %v", err)
+	}
+	if count != 5 {
+		t.Errorf("expected 5 issues, got %d", count)
+	}
+}
+
+func TestSearchAll_RespectsMax(t *testing.T) {
+	pages := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		writeJSON(w, models.SearchResponse{
+			Issues:        makeIssues(pages*10, 10),
+			NextPageToken: fmt.Sprintf("page%d", pages+1),
+		})
+	}))
+	defer server.Close()
+
+	svc := NewSearchService(newTestClient(server.URL))
+
+	count, err := svc.SearchAll("project = PROJ", nil, 15, func(issues []models.Issue) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SearchAll() error = %v", err)
+	}
+	if count != 15 {
+		t.Errorf("expected 15 issues, got %d", count)
+	}
+	if pages != 2 {
+		t.Errorf("expected 2 requests, got %d", pages)
+	}
+}
+
+func TestSearchAll_EmptyJQL(t *testing.T) {
+	svc := NewSearchService(newTestClient("http://localhost"))
+	if _, err := svc.SearchAll("", nil, 0, nil); err == nil {
+		t.Error("expected error for empty JQL")
+	}
+}
+
+func TestTransitionIssue_UnknownStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Unexpected %s request", r.Method)
+		}
+		writeJSON(w, map[string]interface{}{
+			"transitions": []map[string]interface{}{
+				{"id": "11", "name": "Start", "to": map[string]interface{}{"name": "In Progress"}},
+				{"id": "31", "name": "Finish", "to": map[string]interface{}{"name": "Done"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	svc := NewSearchService(newTestClient(server.URL))
+	err := svc.TransitionIssue("PROJ-1", "Closed")
+
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+	}
+
+	allowed := validationErr.AllowedValues["status"]
+	if len(allowed) != 2 || allowed[0] != "In Progress" || allowed[1] != "Done" {
+		t.Errorf("Expected available transitions [In Progress Done], got %v", allowed)
+	}
+}
+
+func TestEditIssue_Body(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/issue/PROJ-1" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	svc := NewSearchService(newTestClient(server.URL))
+	update := FieldOperations{"labels": {{"add": "urgent"}}}
+	if err := svc.EditIssue("PROJ-1", nil, update); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, ok := body["fields"]; ok {
+		t.Errorf("Expected no fields in the body, got %v", body)
+	}
+	got, _ := json.Marshal(body["update"])
+	if string(got) != `{"labels":[{"add":"urgent"}]}` {
+		t.Errorf("Expected the update operations, got %s", got)
+	}
+
+	if err := svc.EditIssue("PROJ-1", nil, nil); err == nil {
+		t.Error("Expected an error for an empty update")
+	}
+}
+
+func TestPerformTransition_Body(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/issue/PROJ-1/transitions" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	svc := NewSearchService(newTestClient(server.URL))
+	fields := map[string]interface{}{"resolution": map[string]interface{}{"name": "Done"}}
+	update := FieldOperations{"comment": {{"add": map[string]interface{}{"body": "Closed"}}}}
+	if err := svc.PerformTransition("PROJ-1", "31", fields, update); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got, _ := json.Marshal(body)
+	expected := `{"fields":{"resolution":{"name":"Done"}},"transition":{"id":"31"},"update":{"comment":[{"add":{"body":"Closed"}}]}}`
+	if string(got) != expected {
+		t.Errorf("Expected body %s, got %s", expected, got)
+	}
+}
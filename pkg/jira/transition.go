@@ -0,0 +1,245 @@
+package jira
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+// TransitionTarget describes the status to transition to: names, in order of
+// preference, matched against the target status and then the transition
+// name, or a status category
+type TransitionTarget struct {
+	Names    []string // Status or transition names (case-insensitive), e.g. a status and its aliases
+	Category string   // Status category key or name, e.g. "done" or "In Progress"
+}
+
+// String describes the target for messages, e.g. "status 'Done'" or
+// "category 'done'"
+func (t TransitionTarget) String() string {
+	if len(t.Names) > 0 {
+		return fmt.Sprintf("status '%s'", t.Names[0])
+	}
+	return fmt.Sprintf("category '%s'", t.Category)
+}
+
+// MatchesStatus reports whether an issue in a status has reached the target
+func (t TransitionTarget) MatchesStatus(status models.Status) bool {
+	for _, name := range t.Names {
+		if strings.EqualFold(status.Name, name) {
+			return true
+		}
+	}
+	return t.matchesCategory(status.StatusCategory)
+}
+
+// matchesCategory reports whether a status category is the target category,
+// ignoring case, spaces and dashes ("todo" matches "To Do")
+func (t TransitionTarget) matchesCategory(category models.StatusCategory) bool {
+	if t.Category == "" {
+		return false
+	}
+	want := normalizeCategory(t.Category)
+	return want == normalizeCategory(category.Key) || want == normalizeCategory(category.Name)
+}
+
+// normalizeCategory lowercases a category and strips separators
+func normalizeCategory(s string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(s))
+}
+
+// FindTransitionTo finds the transition to a target among an issue's
+// available transitions. Each name is tried against the target statuses,
+// then the transition names, before moving on to the next; the category
+// is tried last. Otherwise the error lists the statuses that can be
+// reached, with the closest names as suggestions.
+func FindTransitionTo(transitions []models.Transition, target TransitionTarget) (*models.Transition, error) {
+	for _, name := range target.Names {
+		for i, t := range transitions {
+			if strings.EqualFold(t.To.Name, name) {
+				return &transitions[i], nil
+			}
+		}
+		for i, t := range transitions {
+			if strings.EqualFold(t.Name, name) {
+				return &transitions[i], nil
+			}
+		}
+	}
+	for i, t := range transitions {
+		if target.matchesCategory(t.To.StatusCategory) {
+			return &transitions[i], nil
+		}
+	}
+
+	available := make([]string, len(transitions))
+	candidates := make([]string, 0, 2*len(transitions))
+	for i, t := range transitions {
+		available[i] = t.To.Name
+		candidates = append(candidates, t.To.Name, t.Name)
+	}
+	msg := fmt.Sprintf("%s not found. Available transitions: %v", target, available)
+	err := client.NewAllowedValuesError("status", msg, available)
+	if suggestions := suggestNames(target.Names, candidates); len(suggestions) > 0 {
+		err.Hints = append([]string{fmt.Sprintf("Did you mean: %s?", strings.Join(suggestions, ", "))}, err.Hints...)
+	}
+	return nil, err
+}
+
+// suggestNames returns the candidates close to any of the names: those
+// containing or contained in a name, or within a few edits of it
+func suggestNames(names, candidates []string) []string {
+	type suggestion struct {
+		name     string
+		distance int
+	}
+	var found []suggestion
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		lower := strings.ToLower(candidate)
+		if candidate == "" || seen[lower] {
+			continue
+		}
+		best := -1
+		for _, name := range names {
+			name = strings.ToLower(name)
+			if name == "" {
+				continue
+			}
+			distance := editDistance(name, lower)
+			if strings.Contains(lower, name) || strings.Contains(name, lower) {
+				distance = 0
+			}
+			if limit := len(name) / 3; distance <= limit || distance <= 1 {
+				if best < 0 || distance < best {
+					best = distance
+				}
+			}
+		}
+		if best >= 0 {
+			seen[lower] = true
+			found = append(found, suggestion{candidate, best})
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool { return found[i].distance < found[j].distance })
+	var suggestions []string
+	for i := 0; i < len(found) && i < 3; i++ {
+		suggestions = append(suggestions, found[i].name)
+	}
+	return suggestions
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// minInt returns the smallest of its arguments
+func minInt(values ...int) int {
+	smallest := values[0]
+	for _, v := range values[1:] {
+		if v < smallest {
+			smallest = v
+		}
+	}
+	return smallest
+}
+
+// ValidateTransitionFields checks the values given for a transition against
+// its screen: every field must be on the screen, and every required field
+// without a default must be given. Missing fields are reported together,
+// with their allowed values where Jira lists them.
+// Parameters:
+//   - transition: The transition, from GetTransitions
+//   - fields: Field values to set with the transition
+//   - update: Update operations to apply with the transition (e.g., a comment)
+func ValidateTransitionFields(transition *models.Transition, fields map[string]interface{}, update FieldOperations) error {
+	screenFields := make([]string, 0, len(transition.Fields))
+	for fieldID := range transition.Fields {
+		screenFields = append(screenFields, fieldID)
+	}
+	sort.Strings(screenFields)
+
+	var errs []error
+	for _, fieldID := range sortedFieldIDs(fields, update) {
+		// Comments can be added with any transition
+		if fieldID == "comment" {
+			continue
+		}
+		if _, ok := transition.Fields[fieldID]; !ok {
+			msg := fmt.Sprintf("field '%s' is not on the '%s' transition screen", fieldID, transition.Name)
+			err := client.NewValidationError(msg, map[string]string{fieldID: msg})
+			if len(screenFields) > 0 {
+				err.Hints = []string{fmt.Sprintf("Fields on the screen: %s", strings.Join(screenFields, ", "))}
+			} else {
+				err.Hints = []string{"This transition has no screen; remove --field and update the issue separately"}
+			}
+			errs = append(errs, err)
+		}
+	}
+
+	var missing []string
+	missingErr := client.NewValidationError("", make(map[string]string))
+	for _, fieldID := range screenFields {
+		meta := transition.Fields[fieldID]
+		_, isSet := fields[fieldID]
+		_, isUpdated := update[fieldID]
+		if !meta.Required || meta.HasDefaultValue || isSet || isUpdated {
+			continue
+		}
+
+		missing = append(missing, fmt.Sprintf("%s (%s)", meta.Name, fieldID))
+		missingErr.FieldErrors[fieldID] = fmt.Sprintf("field '%s' (%s) is required by the '%s' transition", meta.Name, fieldID, transition.Name)
+		if allowed := allowedLabels(meta.AllowedValues); len(allowed) > 0 {
+			if missingErr.AllowedValues == nil {
+				missingErr.AllowedValues = make(map[string][]string)
+			}
+			missingErr.AllowedValues[fieldID] = allowed
+			missingErr.Hints = append(missingErr.Hints, fmt.Sprintf("--field %s=<%s>", fieldID, strings.Join(allowed, "|")))
+		} else {
+			missingErr.Hints = append(missingErr.Hints, fmt.Sprintf("--field %s=<value>", fieldID))
+		}
+	}
+	if len(missing) > 0 {
+		missingErr.Message = fmt.Sprintf("the '%s' transition requires: %s", transition.Name, strings.Join(missing, ", "))
+		errs = append(errs, missingErr)
+	}
+
+	return mergeValidationErrors(errs)
+}
+
+// sortedFieldIDs returns the field IDs set or updated, sorted
+func sortedFieldIDs(fields map[string]interface{}, update FieldOperations) []string {
+	fieldIDs := make([]string, 0, len(fields)+len(update))
+	for fieldID := range fields {
+		fieldIDs = append(fieldIDs, fieldID)
+	}
+	for fieldID := range update {
+		if _, ok := fields[fieldID]; !ok {
+			fieldIDs = append(fieldIDs, fieldID)
+		}
+	}
+	sort.Strings(fieldIDs)
+	return fieldIDs
+}
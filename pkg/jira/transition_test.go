@@ -0,0 +1,165 @@
+package jira
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+// resolveTransition is a transition whose screen requires a resolution
+var resolveTransition = &models.Transition{
+	ID:   "31",
+	Name: "Resolve",
+	To:   models.Status{Name: "Done"},
+	Fields: map[string]models.FieldMeta{
+		"resolution": {
+			Name:     "Resolution",
+			Required: true,
+			Schema:   models.FieldSchema{Type: "resolution", System: "resolution"},
+			AllowedValues: []interface{}{
+				map[string]interface{}{"id": "1", "name": "Fixed"},
+				map[string]interface{}{"id": "2", "name": "Won't Do"},
+			},
+		},
+		"fixVersions": {
+			Name:   "Fix versions",
+			Schema: models.FieldSchema{Type: "array", Items: "version", System: "fixVersions"},
+		},
+	},
+}
+
+func TestValidateTransitionFields(t *testing.T) {
+	fields := map[string]interface{}{"resolution": map[string]interface{}{"name": "Fixed"}}
+	update := FieldOperations{"comment": {{"add": map[string]interface{}{"body": "Done"}}}}
+
+	if err := ValidateTransitionFields(resolveTransition, fields, update); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestValidateTransitionFields_MissingRequired(t *testing.T) {
+	err := ValidateTransitionFields(resolveTransition, nil, nil)
+
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a validation error, got %v", err)
+	}
+	if !strings.Contains(validationErr.Message, "Resolution (resolution)") {
+		t.Errorf("Expected the message to name the missing field, got %q", validationErr.Message)
+	}
+	if got := validationErr.AllowedValues["resolution"]; len(got) != 2 || got[0] != "Fixed" {
+		t.Errorf("Expected the allowed resolutions, got %v", got)
+	}
+	if len(validationErr.Hints) != 1 || validationErr.Hints[0] != "--field resolution=<Fixed|Won't Do>" {
+		t.Errorf("Expected a --field hint, got %v", validationErr.Hints)
+	}
+}
+
+func TestValidateTransitionFields_NotOnScreen(t *testing.T) {
+	fields := map[string]interface{}{
+		"resolution": map[string]interface{}{"name": "Fixed"},
+		"priority":   map[string]interface{}{"name": "High"},
+	}
+
+	err := ValidateTransitionFields(resolveTransition, fields, nil)
+
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a validation error, got %v", err)
+	}
+	if _, ok := validationErr.FieldErrors["priority"]; !ok {
+		t.Errorf("Expected an error for priority, got %v", validationErr.FieldErrors)
+	}
+	if len(validationErr.Hints) != 1 || validationErr.Hints[0] != "Fields on the screen: fixVersions, resolution" {
+		t.Errorf("Expected a hint listing the screen fields, got %v", validationErr.Hints)
+	}
+}
+
+func TestGetTransitions_ExpandsFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("expand"); got != "transitions.fields" {
+			t.Errorf("Expected expand=transitions.fields, got %q", got)
+		}
+		writeJSON(w, models.TransitionsResponse{Transitions: []models.Transition{*resolveTransition}})
+	}))
+	defer server.Close()
+
+	svc := NewSearchService(newTestClient(server.URL))
+	transitions, err := svc.GetTransitions("PROJ-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(transitions) != 1 || !transitions[0].Fields["resolution"].Required {
+		t.Errorf("Expected the screen fields to be decoded, got %+v", transitions)
+	}
+}
+
+func TestFindTransitionTo(t *testing.T) {
+	transitions := []models.Transition{
+		{ID: "11", Name: "Start", To: models.Status{Name: "In Progress", StatusCategory: models.StatusCategory{Key: "indeterminate", Name: "In Progress"}}},
+		{ID: "21", Name: "Resolve", To: models.Status{Name: "Resolved", StatusCategory: models.StatusCategory{Key: "done", Name: "Done"}}},
+		{ID: "31", Name: "Close", To: models.Status{Name: "Closed", StatusCategory: models.StatusCategory{Key: "done", Name: "Done"}}},
+	}
+
+	tests := []struct {
+		name     string
+		target   TransitionTarget
+		expected string
+	}{
+		{"status name", TransitionTarget{Names: []string{"closed"}}, "31"},
+		{"transition name", TransitionTarget{Names: []string{"start"}}, "11"},
+		{"aliases in order", TransitionTarget{Names: []string{"done", "Done", "Closed", "Resolved"}}, "31"},
+		{"status before transition name", TransitionTarget{Names: []string{"Close", "Resolved"}}, "31"},
+		{"category key", TransitionTarget{Category: "done"}, "21"},
+		{"category name", TransitionTarget{Category: "in-progress"}, "11"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transition, err := FindTransitionTo(transitions, tt.target)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if transition.ID != tt.expected {
+				t.Errorf("Expected transition %s, got %s", tt.expected, transition.ID)
+			}
+		})
+	}
+}
+
+func TestFindTransitionTo_Suggestions(t *testing.T) {
+	transitions := []models.Transition{
+		{ID: "11", Name: "Start", To: models.Status{Name: "In Progress"}},
+		{ID: "21", Name: "Resolve", To: models.Status{Name: "Resolved"}},
+	}
+
+	tests := []struct {
+		name     string
+		target   TransitionTarget
+		expected string
+	}{
+		{"typo", TransitionTarget{Names: []string{"Reslved"}}, "Did you mean: Resolved, Resolve?"},
+		{"partial", TransitionTarget{Names: []string{"progress"}}, "Did you mean: In Progress?"},
+		{"nothing close", TransitionTarget{Names: []string{"Archived"}}, "Use one of: In Progress, Resolved"},
+		{"category", TransitionTarget{Category: "done"}, "Use one of: In Progress, Resolved"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FindTransitionTo(transitions, tt.target)
+
+			var validationErr *client.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected a validation error, got %v", err)
+			}
+			if len(validationErr.Hints) == 0 || validationErr.Hints[0] != tt.expected {
+				t.Errorf("Expected first hint %q, got %v", tt.expected, validationErr.Hints)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package jira
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+// UserService handles user lookups
+type UserService struct {
+	client *client.Client
+
+	// Cache remembers resolved lookups (nil = always search)
+	Cache *UserCache
+}
+
+// NewUserService creates a new UserService instance
+func NewUserService(c *client.Client) *UserService {
+	return &UserService{client: c}
+}
+
+// SearchUsers finds users whose display name or email address matches query
+// Parameters:
+//   - query: Text to match (e.g., "alice" or "alice@example.com")
+//   - maxResults: Maximum number of users to return
+func (s *UserService) SearchUsers(query string, maxResults int) ([]models.User, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, client.NewValidationError("user search query cannot be empty", nil)
+	}
+
+	var users []models.User
+	var errorResp models.ErrorResponse
+
+	resp, err := s.client.HTTPClient.R().
+		SetQueryParams(map[string]string{
+			"query":      query,
+			"maxResults": strconv.Itoa(maxResults),
+		}).
+		SetResult(&users).
+		SetError(&errorResp).
+		Get("/user/search")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, formatErrorResponse(resp, &errorResp)
+	}
+
+	return users, nil
+}
+
+// GetUser retrieves a user by account ID
+func (s *UserService) GetUser(accountID string) (*models.User, error) {
+	if accountID == "" {
+		return nil, fmt.Errorf("account ID cannot be empty")
+	}
+
+	var user models.User
+	var errorResp models.ErrorResponse
+
+	resp, err := s.client.HTTPClient.R().
+		SetQueryParam("accountId", accountID).
+		SetResult(&user).
+		SetError(&errorResp).
+		Get("/user")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user %s: %w", accountID, err)
+	}
+
+	if resp.IsError() {
+		if resp.StatusCode() == 404 {
+			return nil, client.NewError(resp.StatusCode(), fmt.Sprintf("user '%s' not found", accountID))
+		}
+		return nil, formatErrorResponse(resp, &errorResp)
+	}
+
+	return &user, nil
+}
+
+// Myself retrieves the user the client is authenticated as
+func (s *UserService) Myself() (*models.User, error) {
+	var user models.User
+	var errorResp models.ErrorResponse
+
+	resp, err := s.client.HTTPClient.R().
+		SetResult(&user).
+		SetError(&errorResp).
+		Get("/myself")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, formatErrorResponse(resp, &errorResp)
+	}
+
+	return &user, nil
+}
+
+// accountIDRe matches Jira Cloud account IDs: 24 hex digits, or
+// "<number>:<uuid>" for migrated accounts
+var accountIDRe = regexp.MustCompile(`^(?i:[0-9a-f]{24}|\d+:[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})$`)
+
+// IsAccountID reports whether value looks like a Jira Cloud account ID
+func IsAccountID(value string) bool {
+	return accountIDRe.MatchString(value)
+}
+
+// ResolveUser resolves a user reference given on the command line: "me",
+// an account ID (used as-is, without a lookup), an email address or a
+// display name (see FindUser)
+// Parameters:
+//   - field: Field the value is for, used in errors (e.g., "assignee")
+//   - value: The user reference
+func (s *UserService) ResolveUser(field, value string) (*models.User, error) {
+	value = strings.TrimSpace(strings.TrimPrefix(value, "@"))
+	if value == "" {
+		return nil, client.NewValidationError(fmt.Sprintf("%s: user cannot be empty", field), map[string]string{field: "user cannot be empty"})
+	}
+
+	if strings.EqualFold(value, "me") {
+		// Cached by login email, since several profiles can share a site
+		if s.Cache != nil && s.client.Email != "" {
+			if user, ok := s.Cache.Get(s.client.Email); ok {
+				return user, nil
+			}
+		}
+		user, err := s.Myself()
+		if err != nil {
+			return nil, err
+		}
+		if s.Cache != nil && s.client.Email != "" {
+			s.Cache.Set(s.client.Email, user)
+		}
+		return user, nil
+	}
+
+	if IsAccountID(value) {
+		return &models.User{AccountID: value}, nil
+	}
+
+	user, candidates, err := s.FindUser(value)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil {
+		return user, nil
+	}
+
+	if len(candidates) > 1 {
+		names := make([]string, 0, len(candidates))
+		for _, candidate := range candidates {
+			names = append(names, describeUser(candidate))
+		}
+		msg := fmt.Sprintf("%s: '%s' matches %d users; use an email address, the full display name or an account ID", field, value, len(candidates))
+		return nil, client.NewAllowedValuesError(field, msg, names)
+	}
+
+	msg := fmt.Sprintf("%s: no active user matches '%s'", field, value)
+	notFound := client.NewValidationError(msg, map[string]string{field: msg})
+	notFound.Hints = []string{fmt.Sprintf("Run 'jcfa user search \"%s\"' to find the user", value)}
+	return nil, notFound
+}
+
+// FindUser resolves an email address or display name to a single user.
+// An exact email or display name match wins; otherwise a query matching
+// exactly one user resolves to it. Returns the user, or nil and the
+// candidates when the query is ambiguous (nil and none when nobody matches).
+func (s *UserService) FindUser(query string) (*models.User, []models.User, error) {
+	query = strings.TrimSpace(strings.TrimPrefix(query, "@"))
+	if s.Cache != nil {
+		if user, ok := s.Cache.Get(query); ok {
+			return user, nil, nil
+		}
+	}
+
+	found, err := s.SearchUsers(query, 20)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Apps and customer accounts can't be mentioned or assigned
+	var users []models.User
+	for _, user := range found {
+		if user.Active && (user.AccountType == "" || user.AccountType == "atlassian") {
+			users = append(users, user)
+		}
+	}
+
+	var exact []models.User
+	for _, user := range users {
+		if strings.EqualFold(user.EmailAddress, query) || strings.EqualFold(user.DisplayName, query) {
+			exact = append(exact, user)
+		}
+	}
+
+	var match *models.User
+	switch {
+	case len(exact) == 1:
+		match = &exact[0]
+	case len(exact) > 1:
+		return nil, exact, nil
+	case len(users) == 1:
+		match = &users[0]
+	default:
+		return nil, users, nil
+	}
+
+	if s.Cache != nil {
+		s.Cache.Set(query, match)
+	}
+	return match, nil, nil
+}
+
+// MentionResolver returns a resolver for @mentions in Markdown that looks
+// users up with FindUser. Mentions that match nobody stay plain text, as do
+// ambiguous ones unless strict is set, in which case they're an error
+// listing the candidates.
+func (s *UserService) MentionResolver(strict bool) MentionResolver {
+	seen := make(map[string]*models.User)
+
+	return func(token string) (*models.User, error) {
+		key := userCacheKey(token)
+		if user, ok := seen[key]; ok {
+			return user, nil
+		}
+
+		user, candidates, err := s.FindUser(token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve mention @%s: %w", token, err)
+		}
+		if user == nil && len(candidates) > 1 && strict {
+			names := make([]string, 0, len(candidates))
+			for _, candidate := range candidates {
+				names = append(names, describeUser(candidate))
+			}
+			msg := fmt.Sprintf("mention @%s matches %d users; use an email address or the full display name", token, len(candidates))
+			return nil, client.NewAllowedValuesError("mention", msg, names)
+		}
+
+		seen[key] = user
+		return user, nil
+	}
+}
+
+// describeUser formats a user as "Display Name <email>", or with the
+// account ID when the email address is hidden
+func describeUser(user models.User) string {
+	if user.EmailAddress != "" {
+		return fmt.Sprintf("%s <%s>", user.DisplayName, user.EmailAddress)
+	}
+	return fmt.Sprintf("%s (%s)", user.DisplayName, user.AccountID)
+}
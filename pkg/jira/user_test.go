@@ -0,0 +1,298 @@
+package jira
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+// testUsers is the directory searched by newUserTestServer
+var testUsers = []models.User{
+	{AccountID: "a1", AccountType: "atlassian", DisplayName: "Alice Smith", EmailAddress: "alice@example.com", Active: true},
+	{AccountID: "a2", AccountType: "atlassian", DisplayName: "Alice Jones", Active: true},
+	{AccountID: "b1", AccountType: "atlassian", DisplayName: "Bob Brown", EmailAddress: "bob@example.com", Active: true},
+	{AccountID: "b2", AccountType: "atlassian", DisplayName: "Bobby Old", Active: false},
+	{AccountID: "bot", AccountType: "app", DisplayName: "Bob Bot", Active: true},
+}
+
+// newUserTestServer serves /user/search by substring match and counts requests
+func newUserTestServer(t *testing.T, requests *int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user/search" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+		*requests++
+		query := strings.ToLower(r.URL.Query().Get("query"))
+		matches := []models.User{}
+		for _, user := range testUsers {
+			if strings.Contains(strings.ToLower(user.DisplayName), query) || strings.Contains(user.EmailAddress, query) {
+				matches = append(matches, user)
+			}
+		}
+		writeJSON(w, matches)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFindUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		expected   string
+		candidates int
+	}{
+		{"email", "alice@example.com", "a1", 0},
+		{"exact display name", "Alice Smith", "a1", 0},
+		{"case-insensitive name with @", "@alice jones", "a2", 0},
+		{"single match skips inactive users and apps", "bob", "b1", 0},
+		{"ambiguous", "alice", "", 2},
+		{"not found", "carol", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			svc := NewUserService(newTestClient(newUserTestServer(t, &requests).URL))
+
+			user, candidates, err := svc.FindUser(tt.query)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			got := ""
+			if user != nil {
+				got = user.AccountID
+			}
+			if got != tt.expected {
+				t.Errorf("Expected account %q, got %q", tt.expected, got)
+			}
+			if len(candidates) != tt.candidates {
+				t.Errorf("Expected %d candidates, got %d", tt.candidates, len(candidates))
+			}
+		})
+	}
+}
+
+func TestFindUser_Cache(t *testing.T) {
+	var requests int
+	server := newUserTestServer(t, &requests)
+	path := filepath.Join(t.TempDir(), "users-example.atlassian.net.json")
+
+	svc := NewUserService(newTestClient(server.URL))
+	svc.Cache = NewUserCache(path)
+	if _, _, err := svc.FindUser("bob"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A new service (as in a later jcfa run) reads the cache file
+	svc = NewUserService(newTestClient(server.URL))
+	svc.Cache = NewUserCache(path)
+	user, _, err := svc.FindUser("@Bob")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if user == nil || user.AccountID != "b1" || user.DisplayName != "Bob Brown" {
+		t.Errorf("Expected cached user b1, got %+v", user)
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 search request, got %d", requests)
+	}
+
+	// Ambiguous queries aren't cached
+	svc.FindUser("alice")
+	svc.FindUser("alice")
+	if requests != 3 {
+		t.Errorf("Expected ambiguous queries to search each time, got %d requests", requests)
+	}
+}
+
+func TestUserCachePath(t *testing.T) {
+	got := UserCachePath("/home/me/.jcfa", "acme.atlassian.net/x")
+	expected := filepath.Join("/home/me/.jcfa", "users-acme.atlassian.net_x.json")
+	if got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestMarkdownToADFWithMentions(t *testing.T) {
+	var requests int
+	svc := NewUserService(newTestClient(newUserTestServer(t, &requests).URL))
+
+	tests := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{
+			name:     "email and bracketed name",
+			markdown: "@alice@example.com and @[Bob Brown], please review.",
+			expected: `[{"type":"paragraph","content":[{"type":"mention","attrs":{"id":"a1","text":"@Alice Smith"}},{"type":"text","text":" and "},{"type":"mention","attrs":{"id":"b1","text":"@Bob Brown"}},{"type":"text","text":", please review."}]}]`,
+		},
+		{
+			name:     "single word with trailing punctuation",
+			markdown: "Thanks @bob.",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"Thanks "},{"type":"mention","attrs":{"id":"b1","text":"@Bob Brown"}},{"type":"text","text":"."}]}]`,
+		},
+		{
+			name:     "ambiguous and unknown stay text",
+			markdown: "@alice @carol",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"@alice @carol"}]}]`,
+		},
+		{
+			name:     "code, escapes and email addresses stay text",
+			markdown: "`@bob` \\@bob mail bob@example.com",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"@bob","marks":[{"type":"code"}]},{"type":"text","text":" @bob mail bob@example.com"}]}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := MarkdownToADFWithMentions(tt.markdown, svc.MentionResolver(false))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got, expected := mustJSON(t, doc), docJSON(t, tt.expected); got != expected {
+				t.Errorf("Expected %s, got %s", expected, got)
+			}
+		})
+	}
+}
+
+func TestMarkdownToADFWithMentions_Strict(t *testing.T) {
+	var requests int
+	svc := NewUserService(newTestClient(newUserTestServer(t, &requests).URL))
+
+	_, err := MarkdownToADFWithMentions("cc @alice", svc.MentionResolver(true))
+
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a validation error, got %v", err)
+	}
+	allowed := validationErr.AllowedValues["mention"]
+	if len(allowed) != 2 || allowed[0] != "Alice Smith <alice@example.com>" || allowed[1] != "Alice Jones (a2)" {
+		t.Errorf("Expected both Alices as candidates, got %v", allowed)
+	}
+
+	// Unknown users aren't an error, even in strict mode
+	if _, err := MarkdownToADFWithMentions("cc @carol", svc.MentionResolver(true)); err != nil {
+		t.Errorf("Expected no error for an unknown user, got %v", err)
+	}
+}
+
+func TestParseMentionToken(t *testing.T) {
+	tests := []struct {
+		input    string
+		token    string
+		consumed int
+	}{
+		{"@alice", "alice", 6},
+		{"@alice, hi", "alice", 6},
+		{"@alice.smith.", "alice.smith", 12},
+		{"@alice@example.com.", "alice@example.com", 18},
+		{"@alice@localhost", "alice", 6},
+		{"@[Alice Smith] hi", "Alice Smith", 14},
+		{"@[ ]", "", 0},
+		{"@[unclosed", "", 0},
+		{"@ alice", "", 0},
+		{"@-alice", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			token, consumed := parseMentionToken(tt.input)
+			if token != tt.token || consumed != tt.consumed {
+				t.Errorf("parseMentionToken(%q) = (%q, %d), expected (%q, %d)", tt.input, token, consumed, tt.token, tt.consumed)
+			}
+		})
+	}
+}
+
+func TestResolveUser(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/myself" {
+			writeJSON(w, models.User{AccountID: "me1", DisplayName: "Me Myself", Active: true})
+			return
+		}
+		query := strings.ToLower(r.URL.Query().Get("query"))
+		matches := []models.User{}
+		for _, user := range testUsers {
+			if strings.Contains(strings.ToLower(user.DisplayName), query) {
+				matches = append(matches, user)
+			}
+		}
+		writeJSON(w, matches)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	c.Email = "me@example.com"
+	svc := NewUserService(c)
+	svc.Cache = NewUserCache("")
+
+	// "me" is looked up once, then cached by login email
+	for i := 0; i < 2; i++ {
+		user, err := svc.ResolveUser("assignee", "me")
+		if err != nil || user.AccountID != "me1" {
+			t.Fatalf("Expected me1, got %+v (err %v)", user, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request for 'me', got %d", requests)
+	}
+
+	// Account IDs are used without a lookup
+	requests = 0
+	for _, id := range []string{"5b10a2844c20165700ede21a", "557058:f58131cb-b67d-43c7-b30d-6b58d40bd077"} {
+		user, err := svc.ResolveUser("assignee", id)
+		if err != nil || user.AccountID != id {
+			t.Errorf("Expected account ID %s to be used as-is, got %+v (err %v)", id, user, err)
+		}
+	}
+	if requests != 0 {
+		t.Errorf("Expected no requests for account IDs, got %d", requests)
+	}
+
+	user, err := svc.ResolveUser("reporter", "Bob Brown")
+	if err != nil || user.AccountID != "b1" {
+		t.Errorf("Expected b1, got %+v (err %v)", user, err)
+	}
+
+	var validationErr *client.ValidationError
+	_, err = svc.ResolveUser("assignee", "alice")
+	if !errors.As(err, &validationErr) || len(validationErr.AllowedValues["assignee"]) != 2 {
+		t.Errorf("Expected an ambiguity error with 2 candidates, got %v", err)
+	}
+
+	_, err = svc.ResolveUser("assignee", "carol")
+	if !errors.As(err, &validationErr) || validationErr.FieldErrors["assignee"] == "" || len(validationErr.Hints) == 0 {
+		t.Errorf("Expected a not-found validation error with a hint, got %v", err)
+	}
+}
+
+func TestIsAccountID(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"5b10a2844c20165700ede21a", true},
+		{"557058:f58131cb-b67d-43c7-b30d-6b58d40bd077", true},
+		{"alice@example.com", false},
+		{"Alice Smith", false},
+		{"5b10a2844c", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsAccountID(tt.value); got != tt.expected {
+			t.Errorf("IsAccountID(%q) = %v, expected %v", tt.value, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+const (
+	// userCacheTTL is how long a resolved user lookup is trusted (7 days)
+	userCacheTTL = 7 * 24 * time.Hour
+)
+
+// UserCache is an on-disk cache of user lookups (email or display name to
+// account ID), so repeated mentions and assignments don't search again.
+// It's shared by all jcfa processes using the same file; errors reading or
+// writing it are ignored and the lookup is simply repeated.
+type UserCache struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]userCacheEntry
+	loaded  bool
+}
+
+// userCacheEntry is a cached lookup result
+type userCacheEntry struct {
+	AccountID   string    `json:"accountId"`
+	DisplayName string    `json:"displayName"`
+	CachedAt    time.Time `json:"cachedAt"`
+}
+
+// NewUserCache creates a cache stored at path ("" keeps it in memory only)
+func NewUserCache(path string) *UserCache {
+	return &UserCache{
+		path:    path,
+		entries: make(map[string]userCacheEntry),
+	}
+}
+
+// UserCachePath returns the cache file for a Jira site in dir,
+// e.g. ~/.jcfa/users-yourcompany.atlassian.net.json
+func UserCachePath(dir, domain string) string {
+	name := regexp.MustCompile(`[^A-Za-z0-9.-]`).ReplaceAllString(domain, "_")
+	return filepath.Join(dir, fmt.Sprintf("users-%s.json", name))
+}
+
+// Get returns the cached user for a query, if present and not expired
+func (c *UserCache) Get(query string) (*models.User, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.load()
+	entry, ok := c.entries[userCacheKey(query)]
+	if !ok || time.Since(entry.CachedAt) > userCacheTTL {
+		return nil, false
+	}
+	return &models.User{AccountID: entry.AccountID, DisplayName: entry.DisplayName, Active: true}, true
+}
+
+// Set caches the user a query resolved to and saves the cache file
+func (c *UserCache) Set(query string, user *models.User) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.load()
+	c.entries[userCacheKey(query)] = userCacheEntry{
+		AccountID:   user.AccountID,
+		DisplayName: user.DisplayName,
+		CachedAt:    time.Now(),
+	}
+	c.save()
+}
+
+// load reads the cache file once
+func (c *UserCache) load() {
+	if c.loaded || c.path == "" {
+		return
+	}
+	c.loaded = true
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return
+	}
+	var entries map[string]userCacheEntry
+	if json.Unmarshal(data, &entries) == nil {
+		for key, entry := range entries {
+			c.entries[key] = entry
+		}
+	}
+}
+
+// save writes the cache file, dropping expired entries
+func (c *UserCache) save() {
+	if c.path == "" {
+		return
+	}
+
+	for key, entry := range c.entries {
+		if time.Since(entry.CachedAt) > userCacheTTL {
+			delete(c.entries, key)
+		}
+	}
+
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return
+	}
+	writeCacheFile(c.path, data)
+}
+
+// writeCacheFile writes a cache file, creating its directory. The file is
+// replaced atomically so concurrent readers never see a partial write.
+func writeCacheFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// userCacheKey normalizes a query, so "@Alice" and "alice" share an entry
+func userCacheKey(query string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(query, "@")))
+}
@@ -5,7 +5,6 @@ import (
 	"mime"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 )
 
@@ -55,68 +54,6 @@ func FormatDate(iso8601 string) string {
 	return t.Format("2006-01-02 15:04")
 }
 
-// ExtractPlainText converts ADF (Atlassian Document Format) to plain text for preview
-// This is a simplified implementation that extracts text content
-func ExtractPlainText(adf interface{}) string {
-	if adf == nil {
-		return ""
-	}
-
-	// Handle ADF document structure
-	adfMap, ok := adf.(map[string]interface{})
-	if !ok {
-		// If it's already a string, return it
-		if str, ok := adf.(string); ok {
-			return str
-		}
-		return ""
-	}
-
-	var result strings.Builder
-
-	// Recursively extract text from content array
-	if content, ok := adfMap["content"].([]interface{}); ok {
-		for _, item := range content {
-			if itemMap, ok := item.(map[string]interface{}); ok {
-				result.WriteString(extractTextFromNode(itemMap))
-			}
-		}
-	}
-
-	return strings.TrimSpace(result.String())
-}
-
-// extractTextFromNode recursively extracts text from an ADF node
-func extractTextFromNode(node map[string]interface{}) string {
-	var result strings.Builder
-
-	// Check node type
-	nodeType, _ := node["type"].(string)
-
-	// If it's a text node, return its text
-	if nodeType == "text" {
-		if text, ok := node["text"].(string); ok {
-			result.WriteString(text)
-		}
-	}
-
-	// Recursively process content
-	if content, ok := node["content"].([]interface{}); ok {
-		for _, item := range content {
-			if itemMap, ok := item.(map[string]interface{}); ok {
-				result.WriteString(extractTextFromNode(itemMap))
-			}
-		}
-	}
-
-	// Add newline after paragraphs
-	if nodeType == "paragraph" {
-		result.WriteString("\n")
-	}
-
-	return result.String()
-}
-
 // ValidateFilePath checks if file exists and is readable
 func ValidateFilePath(path string) error {
 	// Check if file exists
@@ -0,0 +1,314 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+// WorkflowService handles fetching the statuses and transitions of issue
+// type workflows, so a transition to a status that isn't directly reachable
+// can be planned as a chain of transitions
+type WorkflowService struct {
+	client *client.Client
+
+	// Cache holds fetched workflows (in memory only unless given a path)
+	Cache *WorkflowCache
+
+	mu       sync.Mutex
+	statuses map[string]statusesCacheEntry
+}
+
+// statusesCacheEntry is a project's cached statuses per issue type
+type statusesCacheEntry struct {
+	data      []models.IssueTypeStatuses
+	expiresAt time.Time
+}
+
+// NewWorkflowService creates a new WorkflowService
+func NewWorkflowService(c *client.Client) *WorkflowService {
+	return &WorkflowService{
+		client:   c,
+		Cache:    NewWorkflowCache(""),
+		statuses: make(map[string]statusesCacheEntry),
+	}
+}
+
+// GetProjectStatuses fetches the statuses of each issue type in a project
+func (s *WorkflowService) GetProjectStatuses(projectKey string) ([]models.IssueTypeStatuses, error) {
+	if projectKey == "" {
+		return nil, fmt.Errorf("project key cannot be empty")
+	}
+
+	s.mu.Lock()
+	entry, ok := s.statuses[projectKey]
+	s.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.data, nil
+	}
+
+	var result []models.IssueTypeStatuses
+	var errorResp models.ErrorResponse
+
+	resp, err := s.client.HTTPClient.R().
+		SetResult(&result).
+		SetError(&errorResp).
+		Get(fmt.Sprintf("/project/%s/statuses", projectKey))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statuses for project %s: %w", projectKey, err)
+	}
+
+	if resp.IsError() {
+		if resp.StatusCode() == 404 {
+			return nil, client.NewError(resp.StatusCode(), fmt.Sprintf("project '%s' not found", projectKey))
+		}
+		return nil, formatErrorResponse(resp, &errorResp)
+	}
+
+	s.mu.Lock()
+	s.statuses[projectKey] = statusesCacheEntry{data: result, expiresAt: time.Now().Add(cacheTTL)}
+	s.mu.Unlock()
+
+	return result, nil
+}
+
+// GetWorkflow fetches the workflow an issue type uses in a project: the
+// workflow scheme of the project maps the issue type to a workflow, whose
+// transitions are then fetched. Statuses include their categories.
+// Parameters:
+//   - projectKey: Project key (e.g., "PROJ")
+//   - issueType: Issue type name (case-insensitive) or ID
+func (s *WorkflowService) GetWorkflow(projectKey, issueType string) (*models.Workflow, error) {
+	cacheKey := projectKey + ":" + strings.ToLower(issueType)
+	if workflow, ok := s.Cache.Get(cacheKey); ok {
+		return workflow, nil
+	}
+
+	issueTypes, err := s.GetProjectStatuses(projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var typeStatuses *models.IssueTypeStatuses
+	names := make([]string, len(issueTypes))
+	for i, t := range issueTypes {
+		names[i] = t.Name
+		if t.ID == issueType || strings.EqualFold(t.Name, issueType) {
+			typeStatuses = &issueTypes[i]
+		}
+	}
+	if typeStatuses == nil {
+		msg := fmt.Sprintf("issue type '%s' not found in project '%s'", issueType, projectKey)
+		return nil, client.NewAllowedValuesError("issuetype", msg, names)
+	}
+
+	workflowName, err := s.workflowName(projectKey, typeStatuses.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.WorkflowSearchResponse
+	var errorResp models.ErrorResponse
+
+	resp, err := s.client.HTTPClient.R().
+		SetQueryParams(map[string]string{
+			"workflowName": workflowName,
+			"expand":       "transitions,statuses",
+		}).
+		SetResult(&result).
+		SetError(&errorResp).
+		Get("/workflow/search")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow '%s': %w", workflowName, err)
+	}
+
+	if resp.IsError() {
+		return nil, workflowAccessError(formatErrorResponse(resp, &errorResp))
+	}
+
+	if len(result.Values) == 0 {
+		return nil, client.NewError(404, fmt.Sprintf("workflow '%s' not found", workflowName))
+	}
+
+	// The project statuses carry status categories; add any status that only
+	// the workflow lists
+	workflow := &models.Workflow{
+		Name:        result.Values[0].ID.Name,
+		IssueType:   typeStatuses.Name,
+		Statuses:    append([]models.Status{}, typeStatuses.Statuses...),
+		Transitions: result.Values[0].Transitions,
+	}
+	for _, status := range result.Values[0].Statuses {
+		if WorkflowStatusName(workflow, status.ID) == "" {
+			workflow.Statuses = append(workflow.Statuses, status)
+		}
+	}
+
+	s.Cache.Set(cacheKey, workflow)
+
+	return workflow, nil
+}
+
+// workflowAccessError explains a refused workflow scheme or workflow request:
+// Jira only lets administrators read them
+func workflowAccessError(err error) error {
+	var permissionErr *client.PermissionError
+	if errors.As(err, &permissionErr) {
+		permissionErr.Message = "reading workflows requires Jira administrator permission"
+		permissionErr.Hints = append(permissionErr.Hints, "Transition one step at a time instead: the error of a plain 'jcfa transition' lists the transitions available from the current status")
+	}
+	return err
+}
+
+// GetIssueWorkflow fetches the workflow of an issue's project and issue type
+func (s *WorkflowService) GetIssueWorkflow(issue *models.Issue) (*models.Workflow, error) {
+	project, _ := issue.Fields["project"].(map[string]interface{})
+	projectKey, _ := project["key"].(string)
+	issueType, _ := issue.Fields["issuetype"].(map[string]interface{})
+	issueTypeID, _ := issueType["id"].(string)
+
+	if projectKey == "" || issueTypeID == "" {
+		return nil, fmt.Errorf("issue %s has no project or issue type", issue.Key)
+	}
+
+	return s.GetWorkflow(projectKey, issueTypeID)
+}
+
+// workflowName returns the name of the workflow an issue type uses in a
+// project, from the project's workflow scheme
+func (s *WorkflowService) workflowName(projectKey, issueTypeID string) (string, error) {
+	var project models.Project
+	var errorResp models.ErrorResponse
+
+	resp, err := s.client.HTTPClient.R().
+		SetResult(&project).
+		SetError(&errorResp).
+		Get(fmt.Sprintf("/project/%s", projectKey))
+
+	if err != nil {
+		return "", fmt.Errorf("failed to get project %s: %w", projectKey, err)
+	}
+
+	if resp.IsError() {
+		if resp.StatusCode() == 404 {
+			return "", client.NewError(resp.StatusCode(), fmt.Sprintf("project '%s' not found", projectKey))
+		}
+		return "", formatErrorResponse(resp, &errorResp)
+	}
+
+	var schemes models.WorkflowSchemeProjectsResponse
+
+	resp, err = s.client.HTTPClient.R().
+		SetQueryParam("projectId", project.ID).
+		SetResult(&schemes).
+		SetError(&errorResp).
+		Get("/workflowscheme/project")
+
+	if err != nil {
+		return "", fmt.Errorf("failed to get workflow scheme for project %s: %w", projectKey, err)
+	}
+
+	if resp.IsError() {
+		return "", workflowAccessError(formatErrorResponse(resp, &errorResp))
+	}
+
+	if len(schemes.Values) == 0 {
+		return "", client.NewError(404, fmt.Sprintf("no workflow scheme found for project '%s'", projectKey))
+	}
+
+	scheme := schemes.Values[0].WorkflowScheme
+	if name, ok := scheme.IssueTypeMappings[issueTypeID]; ok {
+		return name, nil
+	}
+	return scheme.DefaultWorkflow, nil
+}
+
+// WorkflowStatusName returns the name of a status in a workflow ("" if the
+// workflow doesn't have it)
+func WorkflowStatusName(workflow *models.Workflow, statusID string) string {
+	for _, status := range workflow.Statuses {
+		if status.ID == statusID {
+			return status.Name
+		}
+	}
+	return ""
+}
+
+// FindTransitionPath finds the shortest chain of transitions from a status
+// to a target in a workflow: a status with one of the target's names or in
+// its category, or the status a transition with one of its names leads to.
+// Global transitions can be taken from any status. An empty path means the
+// issue is already in a target status.
+// Parameters:
+//   - workflow: The issue type's workflow, from GetWorkflow
+//   - fromStatusID: ID of the issue's current status
+//   - target: The target
+func FindTransitionPath(workflow *models.Workflow, fromStatusID string, target TransitionTarget) ([]models.WorkflowTransition, error) {
+	targets := make(map[string]bool)
+	names := make([]string, len(workflow.Statuses))
+	for i, status := range workflow.Statuses {
+		names[i] = status.Name
+		if target.MatchesStatus(status) {
+			targets[status.ID] = true
+		}
+	}
+	for _, t := range workflow.Transitions {
+		for _, name := range target.Names {
+			if t.Type != "initial" && strings.EqualFold(t.Name, name) {
+				targets[t.To] = true
+			}
+		}
+	}
+	if len(targets) == 0 {
+		msg := fmt.Sprintf("%s is not in the '%s' workflow", target, workflow.Name)
+		err := client.NewAllowedValuesError("status", msg, names)
+		if suggestions := suggestNames(target.Names, names); len(suggestions) > 0 {
+			err.Hints = append([]string{fmt.Sprintf("Did you mean: %s?", strings.Join(suggestions, ", "))}, err.Hints...)
+		}
+		return nil, err
+	}
+	if targets[fromStatusID] {
+		return nil, nil
+	}
+
+	// Breadth-first search over statuses, remembering the transition that
+	// first reached each one
+	reachedBy := map[string]models.WorkflowTransition{}
+	previous := map[string]string{fromStatusID: ""}
+	queue := []string{fromStatusID}
+	for len(queue) > 0 {
+		statusID := queue[0]
+		queue = queue[1:]
+
+		for _, t := range workflow.Transitions {
+			if t.Type == "initial" || (len(t.From) > 0 && !containsString(t.From, statusID)) {
+				continue
+			}
+			if _, seen := previous[t.To]; seen {
+				continue
+			}
+			previous[t.To] = statusID
+			reachedBy[t.To] = t
+
+			if targets[t.To] {
+				var path []models.WorkflowTransition
+				for id := t.To; id != fromStatusID; id = previous[id] {
+					path = append([]models.WorkflowTransition{reachedBy[id]}, path...)
+				}
+				return path, nil
+			}
+			queue = append(queue, t.To)
+		}
+	}
+
+	from := WorkflowStatusName(workflow, fromStatusID)
+	msg := fmt.Sprintf("%s can't be reached from '%s' in the '%s' workflow", target, from, workflow.Name)
+	return nil, client.NewValidationError(msg, map[string]string{"status": msg})
+}
@@ -0,0 +1,187 @@
+package jira
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/client"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+// testWorkflow is To Do -> In Progress -> In Review -> Done, with a global
+// transition to Blocked and Blocked -> To Do
+var testWorkflow = &models.Workflow{
+	Name: "Software workflow",
+	Statuses: []models.Status{
+		{ID: "1", Name: "To Do"},
+		{ID: "2", Name: "In Progress"},
+		{ID: "3", Name: "In Review"},
+		{ID: "4", Name: "Done"},
+		{ID: "5", Name: "Blocked"},
+		{ID: "6", Name: "Archived"},
+	},
+	Transitions: []models.WorkflowTransition{
+		{ID: "1", Name: "Create", To: "1", Type: "initial"},
+		{ID: "11", Name: "Start", From: []string{"1"}, To: "2", Type: "directed"},
+		{ID: "21", Name: "Review", From: []string{"2"}, To: "3", Type: "directed"},
+		{ID: "31", Name: "Approve", From: []string{"3"}, To: "4", Type: "directed"},
+		{ID: "41", Name: "Block", To: "5", Type: "global"},
+		{ID: "51", Name: "Unblock", From: []string{"5"}, To: "1", Type: "directed"},
+	},
+}
+
+func TestFindTransitionPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		target   string
+		expected string
+	}{
+		{"direct", "1", "In Progress", "Start"},
+		{"multiple steps", "1", "done", "Start,Review,Approve"},
+		{"global transition", "3", "Blocked", "Block"},
+		{"through a global transition", "4", "To Do", "Block,Unblock"},
+		{"already there", "4", "Done", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := FindTransitionPath(testWorkflow, tt.from, TransitionTarget{Names: []string{tt.target}})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			names := make([]string, len(path))
+			for i, step := range path {
+				names[i] = step.Name
+			}
+			if got := strings.Join(names, ","); got != tt.expected {
+				t.Errorf("Expected path %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFindTransitionPath_Errors(t *testing.T) {
+	var validationErr *client.ValidationError
+
+	_, err := FindTransitionPath(testWorkflow, "1", TransitionTarget{Names: []string{"Shipped"}})
+	if !errors.As(err, &validationErr) || len(validationErr.AllowedValues["status"]) != 6 {
+		t.Errorf("Expected the workflow's statuses for an unknown status, got %v", err)
+	}
+
+	_, err = FindTransitionPath(testWorkflow, "1", TransitionTarget{Names: []string{"Archived"}})
+	if !errors.As(err, &validationErr) || !strings.Contains(err.Error(), "can't be reached from 'To Do'") {
+		t.Errorf("Expected an unreachable status error, got %v", err)
+	}
+}
+
+func TestGetWorkflow(t *testing.T) {
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/project/PROJ/statuses":
+			writeJSON(w, []models.IssueTypeStatuses{
+				{ID: "10001", Name: "Story", Statuses: []models.Status{
+					{ID: "1", Name: "To Do", StatusCategory: models.StatusCategory{Key: "new"}},
+				}},
+				{ID: "10002", Name: "Bug"},
+			})
+		case "/project/PROJ":
+			writeJSON(w, models.Project{ID: "100", Key: "PROJ"})
+		case "/workflowscheme/project":
+			if got := r.URL.Query().Get("projectId"); got != "100" {
+				t.Errorf("Expected projectId=100, got %q", got)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"values":[{"projectIds":["100"],"workflowScheme":{"defaultWorkflow":"jira","issueTypeMappings":{"10001":"Story workflow"}}}]}`))
+		case "/workflow/search":
+			if got := r.URL.Query().Get("workflowName"); got != "Story workflow" {
+				t.Errorf("Expected the Story workflow, got %q", got)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"values":[{"id":{"name":"Story workflow"},` +
+				`"transitions":[{"id":"11","name":"Start","from":["1"],"to":"2","type":"directed"}],` +
+				`"statuses":[{"id":"1","name":"To Do"},{"id":"2","name":"In Progress"}]}]}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "workflows-example.atlassian.net.json")
+	svc := NewWorkflowService(newTestClient(server.URL))
+	svc.Cache = NewWorkflowCache(path)
+	workflow, err := svc.GetWorkflow("PROJ", "story")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if workflow.Name != "Story workflow" || workflow.IssueType != "Story" {
+		t.Errorf("Expected the Story workflow, got %s for %s", workflow.Name, workflow.IssueType)
+	}
+	if len(workflow.Statuses) != 2 || workflow.Statuses[0].StatusCategory.Key != "new" || workflow.Statuses[1].Name != "In Progress" {
+		t.Errorf("Expected project statuses plus workflow-only statuses, got %+v", workflow.Statuses)
+	}
+	if len(workflow.Transitions) != 1 || workflow.Transitions[0].From[0] != "1" {
+		t.Errorf("Expected the workflow transitions, got %+v", workflow.Transitions)
+	}
+
+	// Cached
+	if _, err := svc.GetWorkflow("PROJ", "Story"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if requests["/workflow/search"] != 1 || requests["/project/PROJ/statuses"] != 1 {
+		t.Errorf("Expected the workflow to be cached, got requests %v", requests)
+	}
+
+	// A new service (as in a later jcfa run) reads the cache file
+	later := NewWorkflowService(newTestClient(server.URL))
+	later.Cache = NewWorkflowCache(path)
+	cached, err := later.GetWorkflow("PROJ", "STORY")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cached.Name != "Story workflow" || len(cached.Transitions) != 1 || requests["/workflow/search"] != 1 {
+		t.Errorf("Expected the workflow from the cache file, got %+v after requests %v", cached, requests)
+	}
+
+	// Unknown issue type
+	var validationErr *client.ValidationError
+	if _, err := svc.GetWorkflow("PROJ", "Epic"); !errors.As(err, &validationErr) {
+		t.Errorf("Expected a validation error for an unknown issue type, got %v", err)
+	}
+}
+
+func TestGetWorkflow_Forbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/project/PROJ/statuses":
+			writeJSON(w, []models.IssueTypeStatuses{{ID: "10001", Name: "Story"}})
+		case "/project/PROJ":
+			writeJSON(w, models.Project{ID: "100", Key: "PROJ"})
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	_, err := NewWorkflowService(newTestClient(server.URL)).GetWorkflow("PROJ", "Story")
+
+	var permissionErr *client.PermissionError
+	if !errors.As(err, &permissionErr) || !strings.Contains(err.Error(), "administrator") || len(permissionErr.Hints) == 0 {
+		t.Errorf("Expected a permission error explaining admin access, got %v", err)
+	}
+}
+
+func TestWorkflowCachePath(t *testing.T) {
+	got := WorkflowCachePath("/home/me/.jcfa", "acme.atlassian.net/x")
+	expected := filepath.Join("/home/me/.jcfa", "workflows-acme.atlassian.net_x.json")
+	if got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,111 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/models"
+)
+
+const (
+	// workflowCacheTTL is how long a fetched workflow is trusted (1 hour)
+	workflowCacheTTL = time.Hour
+)
+
+// WorkflowCache is an on-disk cache of issue type workflows, so planning a
+// transition path doesn't fetch the workflow (four requests, two of them
+// admin-only) on every run. Like UserCache, errors reading or writing the
+// file are ignored and the workflow is simply fetched again.
+type WorkflowCache struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]workflowCacheEntry
+	loaded  bool
+}
+
+// workflowCacheEntry is a cached workflow
+type workflowCacheEntry struct {
+	Workflow *models.Workflow `json:"workflow"`
+	CachedAt time.Time        `json:"cachedAt"`
+}
+
+// NewWorkflowCache creates a cache stored at path ("" keeps it in memory only)
+func NewWorkflowCache(path string) *WorkflowCache {
+	return &WorkflowCache{
+		path:    path,
+		entries: make(map[string]workflowCacheEntry),
+	}
+}
+
+// WorkflowCachePath returns the cache file for a Jira site in dir,
+// e.g. ~/.jcfa/workflows-yourcompany.atlassian.net.json
+func WorkflowCachePath(dir, domain string) string {
+	name := regexp.MustCompile(`[^A-Za-z0-9.-]`).ReplaceAllString(domain, "_")
+	return filepath.Join(dir, fmt.Sprintf("workflows-%s.json", name))
+}
+
+// Get returns the cached workflow for a key, if present and not expired
+func (c *WorkflowCache) Get(key string) (*models.Workflow, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.load()
+	entry, ok := c.entries[key]
+	if !ok || entry.Workflow == nil || time.Since(entry.CachedAt) > workflowCacheTTL {
+		return nil, false
+	}
+	return entry.Workflow, true
+}
+
+// Set caches a workflow and saves the cache file
+func (c *WorkflowCache) Set(key string, workflow *models.Workflow) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.load()
+	c.entries[key] = workflowCacheEntry{Workflow: workflow, CachedAt: time.Now()}
+	c.save()
+}
+
+// load reads the cache file once
+func (c *WorkflowCache) load() {
+	if c.loaded || c.path == "" {
+		return
+	}
+	c.loaded = true
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return
+	}
+	var entries map[string]workflowCacheEntry
+	if json.Unmarshal(data, &entries) == nil {
+		for key, entry := range entries {
+			c.entries[key] = entry
+		}
+	}
+}
+
+// save writes the cache file, dropping expired entries
+func (c *WorkflowCache) save() {
+	if c.path == "" {
+		return
+	}
+
+	for key, entry := range c.entries {
+		if time.Since(entry.CachedAt) > workflowCacheTTL {
+			delete(c.entries, key)
+		}
+	}
+
+	data, err := json.Marshal(c.entries)
+	if err != nil {
+		return
+	}
+	writeCacheFile(c.path, data)
+}
@@ -33,6 +33,7 @@ type IssueType struct {
 // FieldSchema represents the schema of a field
 type FieldSchema struct {
 	Type     string `json:"type"`
+	Items    string `json:"items,omitempty"`
 	System   string `json:"system,omitempty"`
 	Custom   string `json:"custom,omitempty"`
 	CustomID int    `json:"customId,omitempty"`
@@ -85,12 +86,16 @@ type ErrorResponse struct {
 }
 
 // SearchResponse represents a JQL search response
+// The /search/jql endpoint paginates with NextPageToken/IsLast; StartAt and
+// Total are only populated by the legacy offset-based search.
 type SearchResponse struct {
-	Expand     string  `json:"expand"`
-	StartAt    int     `json:"startAt"`
-	MaxResults int     `json:"maxResults"`
-	Total      int     `json:"total"`
-	Issues     []Issue `json:"issues"`
+	Expand        string  `json:"expand"`
+	StartAt       int     `json:"startAt"`
+	MaxResults    int     `json:"maxResults"`
+	Total         int     `json:"total"`
+	Issues        []Issue `json:"issues"`
+	NextPageToken string  `json:"nextPageToken,omitempty"`
+	IsLast        bool    `json:"isLast,omitempty"`
 }
 
 // IssueLinkType represents a type of issue link
@@ -175,6 +180,12 @@ type FieldMeta struct {
 	HasDefaultValue bool          `json:"hasDefaultValue"`
 	AllowedValues   []interface{} `json:"allowedValues,omitempty"`
 	AutoCompleteURL string        `json:"autoCompleteUrl,omitempty"`
+	Operations      []string      `json:"operations,omitempty"` // Edit metadata only: set, add, remove, edit
+}
+
+// EditMetaResponse represents the edit metadata response for an issue
+type EditMetaResponse struct {
+	Fields map[string]FieldMeta `json:"fields"`
 }
 
 // Comment represents a comment on an issue
@@ -190,9 +201,11 @@ type Comment struct {
 
 // Transition represents a workflow transition
 type Transition struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
-	To   Status `json:"to"`
+	ID        string               `json:"id"`
+	Name      string               `json:"name"`
+	To        Status               `json:"to"`
+	HasScreen bool                 `json:"hasScreen,omitempty"`
+	Fields    map[string]FieldMeta `json:"fields,omitempty"` // Fields on the transition screen
 }
 
 // TransitionsResponse represents available transitions for an issue
@@ -201,6 +214,57 @@ type TransitionsResponse struct {
 	Transitions []Transition `json:"transitions"`
 }
 
+// IssueTypeStatuses represents the statuses an issue type can be in
+// (from /project/{key}/statuses)
+type IssueTypeStatuses struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Subtask  bool     `json:"subtask"`
+	Statuses []Status `json:"statuses"`
+}
+
+// Workflow represents the statuses of an issue type's workflow and the
+// transitions between them
+type Workflow struct {
+	Name        string               `json:"name"`
+	IssueType   string               `json:"issueType,omitempty"`
+	Statuses    []Status             `json:"statuses"`
+	Transitions []WorkflowTransition `json:"transitions"`
+}
+
+// WorkflowTransition represents a transition in a workflow definition
+type WorkflowTransition struct {
+	ID   string   `json:"id"`
+	Name string   `json:"name"`
+	From []string `json:"from"` // Status IDs the transition starts from; empty for global and initial transitions
+	To   string   `json:"to"`   // Status ID
+	Type string   `json:"type"` // "initial", "global" or "directed"
+}
+
+// WorkflowSearchResponse represents a page of workflows from /workflow/search
+type WorkflowSearchResponse struct {
+	Values []struct {
+		ID struct {
+			Name string `json:"name"`
+		} `json:"id"`
+		Transitions []WorkflowTransition `json:"transitions"`
+		Statuses    []Status             `json:"statuses"`
+	} `json:"values"`
+}
+
+// WorkflowSchemeProjectsResponse represents the workflow schemes used by
+// projects (from /workflowscheme/project)
+type WorkflowSchemeProjectsResponse struct {
+	Values []struct {
+		ProjectIDs     []string `json:"projectIds"`
+		WorkflowScheme struct {
+			Name              string            `json:"name"`
+			DefaultWorkflow   string            `json:"defaultWorkflow"`
+			IssueTypeMappings map[string]string `json:"issueTypeMappings"`
+		} `json:"workflowScheme"`
+	} `json:"values"`
+}
+
 // CommentsResponse represents a paginated list of comments
 type CommentsResponse struct {
 	StartAt    int       `json:"startAt"`
@@ -221,3 +285,61 @@ type Attachment struct {
 	Content   string `json:"content"`   // Download URL
 	Thumbnail string `json:"thumbnail,omitempty"`
 }
+
+// Board represents a Jira Software board
+type Board struct {
+	ID       int           `json:"id"`
+	Name     string        `json:"name"`
+	Type     string        `json:"type"` // scrum, kanban or simple
+	Location BoardLocation `json:"location"`
+}
+
+// BoardLocation represents the project a board belongs to
+type BoardLocation struct {
+	ProjectID   int    `json:"projectId,omitempty"`
+	ProjectKey  string `json:"projectKey,omitempty"`
+	ProjectName string `json:"projectName,omitempty"`
+}
+
+// BoardsResponse represents a page of boards
+type BoardsResponse struct {
+	StartAt    int     `json:"startAt"`
+	MaxResults int     `json:"maxResults"`
+	Total      int     `json:"total"`
+	IsLast     bool    `json:"isLast"`
+	Values     []Board `json:"values"`
+}
+
+// Sprint represents a sprint on a scrum board
+type Sprint struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	State         string `json:"state"` // future, active or closed
+	StartDate     string `json:"startDate,omitempty"`
+	EndDate       string `json:"endDate,omitempty"`
+	CompleteDate  string `json:"completeDate,omitempty"`
+	Goal          string `json:"goal,omitempty"`
+	OriginBoardID int    `json:"originBoardId,omitempty"`
+}
+
+// SprintsResponse represents a page of sprints
+type SprintsResponse struct {
+	StartAt    int      `json:"startAt"`
+	MaxResults int      `json:"maxResults"`
+	IsLast     bool     `json:"isLast"`
+	Values     []Sprint `json:"values"`
+}
+
+// RankEntry represents the outcome of ranking a single issue
+type RankEntry struct {
+	IssueID  int      `json:"issueId,omitempty"`
+	IssueKey string   `json:"issueKey"`
+	Status   int      `json:"status"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// RankResponse represents the per-issue outcomes of a partially failed rank
+// request (HTTP 207)
+type RankResponse struct {
+	Entries []RankEntry `json:"entries"`
+}
@@ -0,0 +1,460 @@
+// Package output renders command results in the formats selectable via --output.
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format identifies an output format
+type Format string
+
+const (
+	// FormatTable renders aligned, human-readable columns
+	FormatTable Format = "table"
+	// FormatJSON renders pretty-printed JSON
+	FormatJSON Format = "json"
+	// FormatNDJSON renders one compact JSON object per line
+	FormatNDJSON Format = "ndjson"
+	// FormatYAML renders YAML
+	FormatYAML Format = "yaml"
+	// FormatCSV renders comma-separated values with a header row
+	FormatCSV Format = "csv"
+	// FormatTSV renders tab-separated values with a header row
+	FormatTSV Format = "tsv"
+	// FormatTemplate renders each record through a Go text/template
+	FormatTemplate Format = "template"
+)
+
+// Formats lists every supported format in display order
+var Formats = []Format{FormatTable, FormatJSON, FormatNDJSON, FormatYAML, FormatCSV, FormatTSV, FormatTemplate}
+
+const (
+	// maxCellWidth is the maximum width of a table cell before truncation
+	maxCellWidth = 60
+)
+
+// ParseFormat validates a format name (case-insensitive)
+func ParseFormat(name string) (Format, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, f := range Formats {
+		if string(f) == name {
+			return f, nil
+		}
+	}
+
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = string(f)
+	}
+	return "", fmt.Errorf("invalid output format '%s': must be one of %s", name, strings.Join(names, ", "))
+}
+
+// IsStructured reports whether the format is meant for machines rather than humans
+func (f Format) IsStructured() bool {
+	return f != FormatTable
+}
+
+// Column describes one column of tabular (table, csv, tsv) output
+type Column struct {
+	// Header is the column title
+	Header string
+	// Path is a dotted path into the record (e.g. "key", "status", "fields.assignee").
+	// A path that isn't found at the top level is also looked up under "fields".
+	Path string
+	// Value optionally computes the cell from the whole record instead of Path
+	Value func(record map[string]interface{}) interface{}
+	// Format optionally renders the resolved value; defaults to RenderValue
+	Format func(value interface{}) string
+}
+
+// ParseColumns builds columns from user-supplied paths (e.g. --columns key,status)
+func ParseColumns(paths []string) []Column {
+	columns := make([]Column, 0, len(paths))
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		columns = append(columns, Column{
+			Header: strings.ToUpper(path),
+			Path:   path,
+		})
+	}
+	return columns
+}
+
+// cell resolves and renders the column value for a record
+func (c Column) cell(record map[string]interface{}) string {
+	var value interface{}
+	if c.Value != nil {
+		value = c.Value(record)
+	} else {
+		value = Lookup(record, c.Path)
+	}
+
+	if c.Format != nil {
+		return c.Format(value)
+	}
+	return RenderValue(value)
+}
+
+// Formatter writes records in a single output format
+type Formatter struct {
+	format   Format
+	columns  []Column
+	template *template.Template
+	out      io.Writer
+}
+
+// New creates a formatter
+// Parameters:
+//   - out: Destination writer
+//   - format: Output format
+//   - columns: Columns for table/csv/tsv output (nil = caller defaults)
+//   - tmpl: Go template text, required for FormatTemplate
+func New(out io.Writer, format Format, columns []Column, tmpl string) (*Formatter, error) {
+	f := &Formatter{
+		format:  format,
+		columns: columns,
+		out:     out,
+	}
+
+	if format == FormatTemplate {
+		if strings.TrimSpace(tmpl) == "" {
+			return nil, fmt.Errorf("--template is required when using --output template")
+		}
+		parsed, err := template.New("output").Funcs(templateFuncs).Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse output template: %w", err)
+		}
+		f.template = parsed
+	}
+
+	return f, nil
+}
+
+// Format returns the formatter's output format
+func (f *Formatter) Format() Format {
+	return f.format
+}
+
+// WriteItem renders a single value.
+// Tabular formats render one row using defaultColumns unless the user chose
+// columns; with neither, the record's top-level keys are used. Slices are
+// rendered as lists, except in JSON and YAML which encode them as-is.
+func (f *Formatter) WriteItem(item interface{}, defaultColumns []Column) error {
+	switch f.format {
+	case FormatJSON:
+		encoder := json.NewEncoder(f.out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(item); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return nil
+
+	case FormatYAML:
+		record, err := toGeneric(item)
+		if err != nil {
+			return err
+		}
+		data, err := yaml.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("failed to encode YAML: %w", err)
+		}
+		_, err = f.out.Write(data)
+		return err
+	}
+
+	if isSlice(item) {
+		return f.WriteList(item, defaultColumns)
+	}
+
+	stream := f.NewStream(defaultColumns)
+	if err := stream.Write(item); err != nil {
+		return err
+	}
+	return stream.Close()
+}
+
+// WriteList renders a slice of records using defaultColumns unless the user chose columns
+func (f *Formatter) WriteList(items interface{}, defaultColumns []Column) error {
+	records, err := toSlice(items)
+	if err != nil {
+		return err
+	}
+
+	stream := f.NewStream(defaultColumns)
+	for _, item := range records {
+		if err := stream.Write(item); err != nil {
+			return err
+		}
+	}
+	return stream.Close()
+}
+
+// Stream writes records incrementally, so paginated results can be rendered
+// as each page arrives.
+type Stream struct {
+	f       *Formatter
+	columns []Column
+	count   int
+
+	table *tabwriter.Writer
+	csv   *csv.Writer
+}
+
+// NewStream starts an incremental write using defaultColumns unless the user chose columns
+func (f *Formatter) NewStream(defaultColumns []Column) *Stream {
+	columns := f.columns
+	if len(columns) == 0 {
+		columns = defaultColumns
+	}
+	return &Stream{f: f, columns: columns}
+}
+
+// Count returns the number of records written so far
+func (s *Stream) Count() int {
+	return s.count
+}
+
+// Write renders one record
+func (s *Stream) Write(item interface{}) error {
+	switch s.f.format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(item, "  ", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		prefix := ",\n  "
+		if s.count == 0 {
+			prefix = "[\n  "
+		}
+		if _, err := io.WriteString(s.f.out, prefix); err != nil {
+			return err
+		}
+		if _, err := s.f.out.Write(data); err != nil {
+			return err
+		}
+
+	case FormatNDJSON:
+		data, err := json.Marshal(item)
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		if _, err := s.f.out.Write(append(data, '\n')); err != nil {
+			return err
+		}
+
+	case FormatYAML:
+		record, err := toGeneric(item)
+		if err != nil {
+			return err
+		}
+		// Marshalling a one-element list yields a "- ..." entry; concatenated
+		// entries form a valid YAML sequence.
+		data, err := yaml.Marshal([]interface{}{record})
+		if err != nil {
+			return fmt.Errorf("failed to encode YAML: %w", err)
+		}
+		if _, err := s.f.out.Write(data); err != nil {
+			return err
+		}
+
+	case FormatTemplate:
+		var buf bytes.Buffer
+		if err := s.f.template.Execute(&buf, item); err != nil {
+			return fmt.Errorf("failed to execute output template: %w", err)
+		}
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		if _, err := s.f.out.Write(buf.Bytes()); err != nil {
+			return err
+		}
+
+	case FormatCSV, FormatTSV:
+		record, err := toRecord(item)
+		if err != nil {
+			return err
+		}
+		if s.csv == nil {
+			s.csv = csv.NewWriter(s.f.out)
+			if s.f.format == FormatTSV {
+				s.csv.Comma = '\t'
+			}
+			if len(s.columns) == 0 {
+				s.columns = keyColumns(record)
+			}
+			if err := s.csv.Write(headers(s.columns)); err != nil {
+				return err
+			}
+		}
+		row := make([]string, len(s.columns))
+		for i, col := range s.columns {
+			row[i] = col.cell(record)
+			if s.f.format == FormatTSV {
+				row[i] = singleLine(row[i])
+			}
+		}
+		if err := s.csv.Write(row); err != nil {
+			return err
+		}
+
+	default: // FormatTable
+		record, err := toRecord(item)
+		if err != nil {
+			return err
+		}
+		if s.table == nil {
+			s.table = tabwriter.NewWriter(s.f.out, 0, 0, 2, ' ', 0)
+			if len(s.columns) == 0 {
+				s.columns = keyColumns(record)
+			}
+			headerRow := headers(s.columns)
+			rules := make([]string, len(headerRow))
+			for i, h := range headerRow {
+				rules[i] = strings.Repeat("-", len(h))
+			}
+			fmt.Fprintln(s.table, strings.Join(headerRow, "\t"))
+			fmt.Fprintln(s.table, strings.Join(rules, "\t"))
+		}
+		row := make([]string, len(s.columns))
+		for i, col := range s.columns {
+			row[i] = truncate(singleLine(col.cell(record)), maxCellWidth)
+		}
+		fmt.Fprintln(s.table, strings.Join(row, "\t"))
+	}
+
+	s.count++
+	return nil
+}
+
+// Flush writes any buffered rows; call it between pages when streaming
+func (s *Stream) Flush() error {
+	if s.table != nil {
+		return s.table.Flush()
+	}
+	if s.csv != nil {
+		s.csv.Flush()
+		return s.csv.Error()
+	}
+	return nil
+}
+
+// Close terminates the output (e.g. closes the JSON array) and flushes buffers
+func (s *Stream) Close() error {
+	if s.f.format == FormatJSON {
+		closing := "\n]\n"
+		if s.count == 0 {
+			closing = "[]\n"
+		}
+		if _, err := io.WriteString(s.f.out, closing); err != nil {
+			return err
+		}
+	}
+
+	if (s.f.format == FormatCSV || s.f.format == FormatTSV) && s.csv == nil && len(s.columns) > 0 {
+		// Emit the header even for an empty result so consumers see the schema
+		s.csv = csv.NewWriter(s.f.out)
+		if s.f.format == FormatTSV {
+			s.csv.Comma = '\t'
+		}
+		if err := s.csv.Write(headers(s.columns)); err != nil {
+			return err
+		}
+	}
+
+	return s.Flush()
+}
+
+// headers returns the header row for a set of columns
+func headers(columns []Column) []string {
+	row := make([]string, len(columns))
+	for i, col := range columns {
+		row[i] = col.Header
+	}
+	return row
+}
+
+// keyColumns derives columns from a record's top-level keys, sorted
+func keyColumns(record map[string]interface{}) []Column {
+	keys := make([]string, 0, len(record))
+	for k := range record {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return ParseColumns(keys)
+}
+
+// toGeneric converts any value into its JSON-equivalent generic form
+// (maps, slices, strings, float64s), so field names match the JSON output.
+func toGeneric(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal value: %w", err)
+	}
+	return generic, nil
+}
+
+// toRecord converts a value into a generic map record
+func toRecord(v interface{}) (map[string]interface{}, error) {
+	if m, ok := v.(map[string]interface{}); ok {
+		return m, nil
+	}
+
+	generic, err := toGeneric(v)
+	if err != nil {
+		return nil, err
+	}
+
+	record, ok := generic.(map[string]interface{})
+	if !ok {
+		// Scalars and lists become a single "value" column
+		return map[string]interface{}{"value": generic}, nil
+	}
+	return record, nil
+}
+
+// toSlice converts a slice of any element type to []interface{}, keeping the
+// original elements so templates can use their Go field names.
+func toSlice(items interface{}) ([]interface{}, error) {
+	if items == nil {
+		return nil, nil
+	}
+	if s, ok := items.([]interface{}); ok {
+		return s, nil
+	}
+
+	return reflectSlice(items), nil
+}
+
+// truncate shortens s to maxLen runes, adding "..." if truncated
+func truncate(s string, maxLen int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+	return string(runes[:maxLen-3]) + "..."
+}
+
+// singleLine collapses newlines and tabs so a value fits in one cell
+func singleLine(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", " ")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return strings.ReplaceAll(s, "\t", " ")
+}
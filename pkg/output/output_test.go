@@ -0,0 +1,202 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type testIssue struct {
+	Key    string                 `json:"key"`
+	Fields map[string]interface{} `json:"fields"`
+}
+
+func testIssues() []testIssue {
+	return []testIssue{
+		{
+			Key: "PROJ-1",
+			Fields: map[string]interface{}{
+				"summary":           "First, with comma",
+				"status":            map[string]interface{}{"name": "To Do"},
+				"assignee":          map[string]interface{}{"displayName": "Jane Doe", "accountId": "abc"},
+				"customfield_10016": 5,
+				"labels":            []string{"backend", "urgent"},
+			},
+		},
+		{
+			Key: "PROJ-2",
+			Fields: map[string]interface{}{
+				"summary": "Second",
+				"status":  map[string]interface{}{"name": "Done"},
+			},
+		},
+	}
+}
+
+func render(t *testing.T, format Format, columns []string, tmpl string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	f, err := New(&buf, format, ParseColumns(columns), tmpl)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := f.WriteList(testIssues(), nil); err != nil {
+		t.Fatalf("WriteList() error = %v", err)
+	}
+	return buf.String()
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Format
+		wantErr bool
+	}{
+		{"table", FormatTable, false},
+		{"JSON", FormatJSON, false},
+		{" yaml ", FormatYAML, false},
+		{"tsv", FormatTSV, false},
+		{"xml", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFormat(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseFormat(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestWriteList_CSV(t *testing.T) {
+	out := render(t, FormatCSV, []string{"key", "status", "assignee", "customfield_10016", "labels"}, "")
+
+	expected := "KEY,STATUS,ASSIGNEE,CUSTOMFIELD_10016,LABELS\n" +
+		"PROJ-1,To Do,Jane Doe,5,\"backend, urgent\"\n" +
+		"PROJ-2,Done,,,\n"
+	if out != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, out)
+	}
+}
+
+func TestWriteList_TSV(t *testing.T) {
+	out := render(t, FormatTSV, []string{"key", "summary"}, "")
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %d: %q", len(lines), out)
+	}
+	if lines[1] != "PROJ-1\tFirst, with comma" {
+		t.Errorf("Unexpected TSV row: %q", lines[1])
+	}
+}
+
+func TestWriteList_Table(t *testing.T) {
+	out := render(t, FormatTable, []string{"key", "status"}, "")
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected header, rule and 2 rows, got %d lines: %q", len(lines), out)
+	}
+	if !strings.HasPrefix(lines[0], "KEY") || !strings.Contains(lines[0], "STATUS") {
+		t.Errorf("Unexpected header: %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[2], "PROJ-1") || !strings.Contains(lines[2], "To Do") {
+		t.Errorf("Unexpected row: %q", lines[2])
+	}
+}
+
+func TestWriteList_Template(t *testing.T) {
+	out := render(t, FormatTemplate, nil, "{{.Key}} {{.Fields.summary}}")
+
+	expected := "PROJ-1 First, with comma\nPROJ-2 Second\n"
+	if out != expected {
+		t.Errorf("Expected %q, got %q", expected, out)
+	}
+}
+
+func TestWriteList_YAML(t *testing.T) {
+	out := render(t, FormatYAML, nil, "")
+
+	if !strings.HasPrefix(out, "- fields:") {
+		t.Errorf("Expected a YAML sequence, got:\n%s", out)
+	}
+	if strings.Count(out, "\n- ") != 1 {
+		t.Errorf("Expected 2 sequence entries, got:\n%s", out)
+	}
+}
+
+func TestStream_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	f, _ := New(&buf, FormatJSON, nil, "")
+
+	stream := f.NewStream(nil)
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if buf.String() != "[]\n" {
+		t.Errorf("Expected empty array, got %q", buf.String())
+	}
+}
+
+func TestNew_TemplateRequired(t *testing.T) {
+	if _, err := New(&bytes.Buffer{}, FormatTemplate, nil, ""); err == nil {
+		t.Error("Expected error for empty template")
+	}
+	if _, err := New(&bytes.Buffer{}, FormatTemplate, nil, "{{.Key"); err == nil {
+		t.Error("Expected error for invalid template")
+	}
+}
+
+func TestRenderValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"nil", nil, ""},
+		{"number", float64(3.5), "3.5"},
+		{"user", map[string]interface{}{"displayName": "Jane", "name": "jane"}, "Jane"},
+		{"option", map[string]interface{}{"value": "High", "id": "1"}, "High"},
+		{"cascading", map[string]interface{}{"value": "A", "child": map[string]interface{}{"value": "B"}}, "A / B"},
+		{"list", []interface{}{map[string]interface{}{"name": "Backend"}, "x"}, "Backend, x"},
+		{"adf", map[string]interface{}{
+			"type":    "doc",
+			"version": float64(1),
+			"content": []interface{}{map[string]interface{}{
+				"type":    "paragraph",
+				"content": []interface{}{map[string]interface{}{"type": "text", "text": "Hello"}},
+			}},
+		}, "Hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderValue(tt.value); got != tt.want {
+				t.Errorf("RenderValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookup_FallsBackToFields(t *testing.T) {
+	record := map[string]interface{}{
+		"key": "PROJ-1",
+		"fields": map[string]interface{}{
+			"status": map[string]interface{}{"name": "Done"},
+		},
+	}
+
+	if got := Lookup(record, "status.name"); got != "Done" {
+		t.Errorf("Expected 'Done', got %v", got)
+	}
+	if got := Lookup(record, "fields.status.name"); got != "Done" {
+		t.Errorf("Expected 'Done' via full path, got %v", got)
+	}
+	if got := Lookup(record, "missing"); got != nil {
+		t.Errorf("Expected nil for missing path, got %v", got)
+	}
+}
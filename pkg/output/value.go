@@ -0,0 +1,149 @@
+package output
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
+)
+
+// templateFuncs are available inside --template
+var templateFuncs = template.FuncMap{
+	// json renders a value as compact JSON
+	"json": func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	},
+	// text renders a value the same way table cells are rendered
+	// (objects by name, ADF documents as plain text)
+	"text": func(v interface{}) string {
+		generic, err := toGeneric(v)
+		if err != nil {
+			return ""
+		}
+		return RenderValue(generic)
+	},
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// Lookup resolves a dotted path in a record.
+// A path whose first segment isn't a top-level key is looked up under
+// "fields", so issue columns can be written as "status" or "customfield_10016".
+func Lookup(record map[string]interface{}, path string) interface{} {
+	if path == "" {
+		return nil
+	}
+
+	segments := strings.Split(path, ".")
+	if _, ok := record[segments[0]]; !ok {
+		if fields, ok := record["fields"].(map[string]interface{}); ok {
+			if _, ok := fields[segments[0]]; ok {
+				return walk(fields, segments)
+			}
+		}
+	}
+	return walk(record, segments)
+}
+
+// walk follows path segments through nested maps
+func walk(value interface{}, segments []string) interface{} {
+	for _, segment := range segments {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = m[segment]
+	}
+	return value
+}
+
+// RenderValue renders a generic JSON value as a single cell string.
+// Jira objects are shown by their most readable attribute (displayName, name,
+// value, key), ADF documents as plain text, and lists as comma-separated values.
+func RenderValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if s := RenderValue(item); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		if v["type"] == "doc" {
+			return strings.TrimSpace(jira.ADFToPlainText(v))
+		}
+		for _, key := range []string{"displayName", "name", "value", "key"} {
+			if s, ok := v[key].(string); ok && s != "" {
+				// Cascading selects carry the second level in "child"
+				if child, ok := v["child"].(map[string]interface{}); ok {
+					if c := RenderValue(child); c != "" {
+						return s + " / " + c
+					}
+				}
+				return s
+			}
+		}
+		if id, ok := v["id"]; ok {
+			return RenderValue(id)
+		}
+		data, _ := json.Marshal(v)
+		return string(data)
+	default:
+		// Typed values (structs, ints) are rendered via their JSON form
+		generic, err := toGeneric(v)
+		if err != nil {
+			return ""
+		}
+		return RenderValue(generic)
+	}
+}
+
+// reflectSlice copies the elements of any slice or array into []interface{}
+func reflectSlice(items interface{}) []interface{} {
+	rv := reflect.ValueOf(items)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return []interface{}{items}
+	}
+
+	result := make([]interface{}, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		result[i] = rv.Index(i).Interface()
+	}
+	return result
+}
+
+// isSlice reports whether v is a slice or array (other than raw bytes)
+func isSlice(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+		return false
+	}
+	return rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array
+}
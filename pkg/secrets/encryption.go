@@ -0,0 +1,156 @@
+package secrets
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// Credentials file layout (version 1):
+//
+//	magic "JCFA" | version (1) | scrypt logN (1) | r (1) | p (1) | salt (16) | nonce (12) | AES-256-GCM ciphertext
+//
+// The header (everything before the ciphertext) is authenticated as
+// additional data, so tampering with the KDF parameters is detected.
+// Files without the magic prefix are legacy XOR/base64 files.
+const (
+	fileMagic = "JCFA"
+
+	// fileVersionScryptAESGCM is scrypt key derivation with AES-256-GCM
+	fileVersionScryptAESGCM byte = 1
+
+	// scrypt parameters (N = 2^15) as recommended for interactive logins
+	scryptLogN = 15
+	scryptR    = 8
+	scryptP    = 1
+
+	// Upper bounds on the scrypt parameters read from a file, checked before
+	// anything is authenticated so a tampered header can't demand gigabytes
+	// of memory (scrypt needs 128 * 2^logN * r bytes)
+	maxScryptLogN = 20
+	maxScryptR    = scryptR
+	maxScryptP    = 4
+
+	saltSize = 16
+	keySize  = 32
+
+	headerSize = len(fileMagic) + 4 + saltSize
+)
+
+// ErrDecryptionFailed is returned when the credentials file can't be decrypted,
+// usually because JIRA_KEYRING_PASSWORD is wrong or the file was modified
+var ErrDecryptionFailed = errors.New("wrong password or corrupted file")
+
+// encrypt seals data with a key derived from password using scrypt and AES-256-GCM.
+// A fresh salt and nonce are generated for every call.
+func encrypt(data []byte, password string) ([]byte, error) {
+	header := make([]byte, headerSize)
+	copy(header, fileMagic)
+	header[4] = fileVersionScryptAESGCM
+	header[5] = scryptLogN
+	header[6] = scryptR
+	header[7] = scryptP
+
+	salt := header[8:]
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	gcm, err := newGCM(password, salt, scryptLogN, scryptR, scryptP)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	out := make([]byte, 0, len(header)+len(nonce)+len(data)+gcm.Overhead())
+	out = append(out, header...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, data, header), nil
+}
+
+// decrypt opens data produced by encrypt
+func decrypt(data []byte, password string) ([]byte, error) {
+	if !isEncryptedFile(data) {
+		return nil, fmt.Errorf("not an encrypted credentials file")
+	}
+	if len(data) < headerSize {
+		return nil, fmt.Errorf("credentials file is truncated")
+	}
+
+	header := data[:headerSize]
+	if version := header[4]; version != fileVersionScryptAESGCM {
+		return nil, fmt.Errorf("unsupported credentials file version %d (upgrade jcfa)", version)
+	}
+
+	logN, r, p := int(header[5]), int(header[6]), int(header[7])
+	if logN < 10 || logN > maxScryptLogN || r == 0 || r > maxScryptR || p == 0 || p > maxScryptP {
+		return nil, fmt.Errorf("invalid key derivation parameters in credentials file")
+	}
+
+	gcm, err := newGCM(password, header[8:], logN, r, p)
+	if err != nil {
+		return nil, err
+	}
+
+	rest := data[headerSize:]
+	if len(rest) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, fmt.Errorf("credentials file is truncated")
+	}
+
+	nonce, ciphertext := rest[:gcm.NonceSize()], rest[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, header)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+
+	return plaintext, nil
+}
+
+// newGCM derives an AES-256 key from password and salt and returns an AES-GCM AEAD
+func newGCM(password string, salt []byte, logN, r, p int) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(password), salt, 1<<logN, r, p, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// isEncryptedFile reports whether data starts with the versioned file header.
+// The version byte is never a base64 character, so legacy files can't match.
+func isEncryptedFile(data []byte) bool {
+	return len(data) > len(fileMagic) &&
+		bytes.HasPrefix(data, []byte(fileMagic)) &&
+		data[len(fileMagic)] < '+'
+}
+
+// decryptLegacy decodes credentials files written before versioned encryption
+// (repeating-key XOR with base64). Only used to migrate existing files.
+func decryptLegacy(data []byte, password string) ([]byte, error) {
+	decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	key := []byte(password)
+	decrypted := make([]byte, len(decoded))
+	for i := 0; i < len(decoded); i++ {
+		decrypted[i] = decoded[i] ^ key[i%len(key)]
+	}
+	return decrypted, nil
+}
@@ -2,7 +2,6 @@
 package secrets
 
 import (
-	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -167,29 +166,19 @@ func (s *Store) storeFile(account string, creds *Credentials) error {
 		return err
 	}
 
-	// Load existing credentials or create new map
-	allCreds := make(map[string]*Credentials)
-	if data, err := os.ReadFile(filePath); err == nil {
-		decrypted, err := decrypt(data, password)
-		if err == nil {
-			json.Unmarshal(decrypted, &allCreds)
+	// Load existing credentials, or start a new file. Any other error (such
+	// as a wrong password) must not overwrite the other accounts' credentials.
+	allCreds, _, err := readCredentialsFile(filePath, password)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return err
 		}
+		allCreds = make(map[string]*Credentials)
 	}
 
 	// Add/update account credentials
 	allCreds[account] = creds
 
-	// Serialize and encrypt
-	data, err := json.Marshal(allCreds)
-	if err != nil {
-		return fmt.Errorf("failed to marshal credentials: %w", err)
-	}
-
-	encrypted, err := encrypt(data, password)
-	if err != nil {
-		return fmt.Errorf("failed to encrypt credentials: %w", err)
-	}
-
 	// Ensure config directory exists
 	configDir, err := config.GetConfigDir()
 	if err != nil {
@@ -199,15 +188,11 @@ func (s *Store) storeFile(account string, creds *Credentials) error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	// Write encrypted file
-	if err := os.WriteFile(filePath, encrypted, config.ConfigFilePerms); err != nil {
-		return fmt.Errorf("failed to write credentials file: %w", err)
-	}
-
-	return nil
+	return writeCredentialsFile(filePath, password, allCreds)
 }
 
-// retrieveFile retrieves credentials from encrypted file
+// retrieveFile retrieves credentials from encrypted file.
+// Legacy files are re-encrypted in the current format after a successful read.
 func (s *Store) retrieveFile(account string) (*Credentials, error) {
 	password := os.Getenv("JIRA_KEYRING_PASSWORD")
 	if password == "" {
@@ -219,22 +204,19 @@ func (s *Store) retrieveFile(account string) (*Credentials, error) {
 		return nil, err
 	}
 
-	data, err := os.ReadFile(filePath)
+	allCreds, legacy, err := readCredentialsFile(filePath, password)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, fmt.Errorf("no credentials found for account %s", account)
 		}
-		return nil, fmt.Errorf("failed to read credentials file: %w", err)
-	}
-
-	decrypted, err := decrypt(data, password)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt credentials: %w", err)
+		return nil, err
 	}
 
-	var allCreds map[string]*Credentials
-	if err := json.Unmarshal(decrypted, &allCreds); err != nil {
-		return nil, fmt.Errorf("failed to parse credentials: %w", err)
+	if legacy {
+		// Best effort: the credentials are still usable if the upgrade can't be written
+		if err := writeCredentialsFile(filePath, password, allCreds); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to upgrade credentials file encryption: %v\n", err)
+		}
 	}
 
 	creds, ok := allCreds[account]
@@ -257,38 +239,99 @@ func (s *Store) deleteFile(account string) error {
 		return err
 	}
 
-	data, err := os.ReadFile(filePath)
+	allCreds, _, err := readCredentialsFile(filePath, password)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil // Already deleted
 		}
-		return fmt.Errorf("failed to read credentials file: %w", err)
+		return err
 	}
 
-	decrypted, err := decrypt(data, password)
+	delete(allCreds, account)
+
+	// Re-encrypt and save
+	return writeCredentialsFile(filePath, password, allCreds)
+}
+
+// readCredentialsFile reads and decrypts the credentials file
+// Returns:
+//   - The credentials keyed by account
+//   - Whether the file uses the legacy XOR encoding and should be rewritten
+//   - An error satisfying os.IsNotExist if the file doesn't exist
+func readCredentialsFile(filePath, password string) (map[string]*Credentials, bool, error) {
+	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to decrypt credentials: %w", err)
+		if os.IsNotExist(err) {
+			return nil, false, err
+		}
+		return nil, false, fmt.Errorf("failed to read credentials file: %w", err)
 	}
 
-	var allCreds map[string]*Credentials
+	legacy := !isEncryptedFile(data)
+
+	var decrypted []byte
+	if legacy {
+		decrypted, err = decryptLegacy(data, password)
+	} else {
+		decrypted, err = decrypt(data, password)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to decrypt credentials: %w", err)
+	}
+
+	allCreds := make(map[string]*Credentials)
 	if err := json.Unmarshal(decrypted, &allCreds); err != nil {
-		return fmt.Errorf("failed to parse credentials: %w", err)
+		if legacy {
+			// XOR with the wrong password yields garbage rather than an error
+			return nil, false, fmt.Errorf("failed to decrypt credentials: %w", ErrDecryptionFailed)
+		}
+		return nil, false, fmt.Errorf("failed to parse credentials: %w", err)
 	}
 
-	delete(allCreds, account)
+	return allCreds, legacy, nil
+}
 
-	// Re-encrypt and save
-	newData, err := json.Marshal(allCreds)
+// writeCredentialsFile encrypts and writes the credentials file
+func writeCredentialsFile(filePath, password string, allCreds map[string]*Credentials) error {
+	// Serialize and encrypt
+	data, err := json.Marshal(allCreds)
 	if err != nil {
 		return fmt.Errorf("failed to marshal credentials: %w", err)
 	}
 
-	encrypted, err := encrypt(newData, password)
+	encrypted, err := encrypt(data, password)
 	if err != nil {
 		return fmt.Errorf("failed to encrypt credentials: %w", err)
 	}
 
-	return os.WriteFile(filePath, encrypted, config.ConfigFilePerms)
+	// Write to a temporary file and rename it over the original, so a crash
+	// mid-write can't leave a truncated file and lose every account
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(config.ConfigFilePerms); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	if _, err := tmp.Write(encrypted); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+
+	return nil
 }
 
 // getCredentialsFilePath returns the path to the encrypted credentials file
@@ -299,29 +342,3 @@ func getCredentialsFilePath() (string, error) {
 	}
 	return filepath.Join(configDir, EncryptedFileName), nil
 }
-
-// Simple XOR-based encryption with base64 encoding
-// Note: For production, consider using a proper encryption library like golang.org/x/crypto/nacl/secretbox
-func encrypt(data []byte, password string) ([]byte, error) {
-	key := []byte(password)
-	encrypted := make([]byte, len(data))
-	for i := 0; i < len(data); i++ {
-		encrypted[i] = data[i] ^ key[i%len(key)]
-	}
-	encoded := base64.StdEncoding.EncodeToString(encrypted)
-	return []byte(encoded), nil
-}
-
-func decrypt(data []byte, password string) ([]byte, error) {
-	decoded, err := base64.StdEncoding.DecodeString(string(data))
-	if err != nil {
-		return nil, err
-	}
-
-	key := []byte(password)
-	decrypted := make([]byte, len(decoded))
-	for i := 0; i < len(decoded); i++ {
-		decrypted[i] = decoded[i] ^ key[i%len(key)]
-	}
-	return decrypted, nil
-}
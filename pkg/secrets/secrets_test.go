@@ -1,7 +1,11 @@
 package secrets
 
 import (
+	"bytes"
+	"encoding/base64"
+	"errors"
 	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
 )
@@ -115,6 +119,9 @@ func TestStore_Keychain_Delete(t *testing.T) {
 }
 
 func TestStore_File_StoreAndRetrieve(t *testing.T) {
+	// Use a credentials file of its own
+	t.Setenv("HOME", t.TempDir())
+
 	// Set up test password
 	origPassword := os.Getenv("JIRA_KEYRING_PASSWORD")
 	os.Setenv("JIRA_KEYRING_PASSWORD", "test-password-123")
@@ -146,6 +153,9 @@ func TestStore_File_StoreAndRetrieve(t *testing.T) {
 }
 
 func TestStore_File_Delete(t *testing.T) {
+	// Use a credentials file of its own
+	t.Setenv("HOME", t.TempDir())
+
 	// Set up test password
 	origPassword := os.Getenv("JIRA_KEYRING_PASSWORD")
 	os.Setenv("JIRA_KEYRING_PASSWORD", "test-password-123")
@@ -200,6 +210,9 @@ func TestStore_File_RequiresPassword(t *testing.T) {
 }
 
 func TestStore_File_MultipleAccounts(t *testing.T) {
+	// Use a credentials file of its own
+	t.Setenv("HOME", t.TempDir())
+
 	// Set up test password
 	origPassword := os.Getenv("JIRA_KEYRING_PASSWORD")
 	os.Setenv("JIRA_KEYRING_PASSWORD", "test-password-456")
@@ -259,3 +272,212 @@ func TestStore_File_MultipleAccounts(t *testing.T) {
 		store.Delete(account)
 	}
 }
+
+func TestEncrypt_RoundTrip(t *testing.T) {
+	data := []byte(`{"user@example.com":{"api_token":"secret"}}`)
+
+	encrypted, err := encrypt(data, "correct-password")
+	if err != nil {
+		t.Fatalf("encrypt() error = %v", err)
+	}
+
+	if !isEncryptedFile(encrypted) {
+		t.Error("encrypt() output should start with the versioned header")
+	}
+	if bytes.Contains(encrypted, []byte("secret")) {
+		t.Error("encrypt() output should not contain the plaintext")
+	}
+
+	decrypted, err := decrypt(encrypted, "correct-password")
+	if err != nil {
+		t.Fatalf("decrypt() error = %v", err)
+	}
+	if !bytes.Equal(decrypted, data) {
+		t.Errorf("decrypt() = %s, want %s", decrypted, data)
+	}
+
+	// Same input must not produce the same ciphertext (fresh salt and nonce)
+	again, _ := encrypt(data, "correct-password")
+	if bytes.Equal(again, encrypted) {
+		t.Error("encrypt() should produce different output for each call")
+	}
+}
+
+func TestDecrypt_WrongPasswordAndTampering(t *testing.T) {
+	encrypted, err := encrypt([]byte("payload"), "correct-password")
+	if err != nil {
+		t.Fatalf("encrypt() error = %v", err)
+	}
+
+	if _, err := decrypt(encrypted, "wrong-password"); err != ErrDecryptionFailed {
+		t.Errorf("decrypt() with wrong password error = %v, want %v", err, ErrDecryptionFailed)
+	}
+
+	// Flip a ciphertext bit
+	tampered := append([]byte(nil), encrypted...)
+	tampered[len(tampered)-1] ^= 0x01
+	if _, err := decrypt(tampered, "correct-password"); err != ErrDecryptionFailed {
+		t.Errorf("decrypt() of tampered ciphertext error = %v, want %v", err, ErrDecryptionFailed)
+	}
+
+	// Header bytes are authenticated too
+	tampered = append([]byte(nil), encrypted...)
+	tampered[10] ^= 0x01
+	if _, err := decrypt(tampered, "correct-password"); err != ErrDecryptionFailed {
+		t.Errorf("decrypt() with tampered salt error = %v, want %v", err, ErrDecryptionFailed)
+	}
+}
+
+func TestStore_File_MigratesLegacyFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("JIRA_KEYRING_PASSWORD", "legacy-password")
+
+	filePath, err := getCredentialsFilePath()
+	if err != nil {
+		t.Fatalf("getCredentialsFilePath() error = %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	// Write a credentials file in the legacy format
+	legacy := encodeLegacy(`{"legacy@example.com":{"api_token":"legacy-token"}}`, "legacy-password")
+	if err := os.WriteFile(filePath, legacy, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewStore(BackendFile)
+	retrieved, err := store.Retrieve("legacy@example.com")
+	if err != nil {
+		t.Fatalf("Retrieve() error = %v", err)
+	}
+	if retrieved.APIToken != "legacy-token" {
+		t.Errorf("Retrieve() APIToken = %v, want legacy-token", retrieved.APIToken)
+	}
+
+	// The file should have been rewritten in the current format
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isEncryptedFile(data) {
+		t.Error("Legacy credentials file should be migrated after a successful read")
+	}
+
+	retrieved, err = store.Retrieve("legacy@example.com")
+	if err != nil {
+		t.Fatalf("Retrieve() after migration error = %v", err)
+	}
+	if retrieved.APIToken != "legacy-token" {
+		t.Errorf("Retrieve() after migration APIToken = %v, want legacy-token", retrieved.APIToken)
+	}
+}
+
+func TestStore_File_LegacyWrongPasswordNotMigrated(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	filePath, _ := getCredentialsFilePath()
+	os.MkdirAll(filepath.Dir(filePath), 0700)
+
+	legacy := encodeLegacy(`{"legacy@example.com":{"api_token":"legacy-token"}}`, "legacy-password")
+	os.WriteFile(filePath, legacy, 0600)
+
+	t.Setenv("JIRA_KEYRING_PASSWORD", "not-the-password")
+	if _, err := NewStore(BackendFile).Retrieve("legacy@example.com"); err == nil {
+		t.Error("Retrieve() with wrong password should fail")
+	}
+
+	data, _ := os.ReadFile(filePath)
+	if !bytes.Equal(data, legacy) {
+		t.Error("Legacy file should be left untouched when it can't be decrypted")
+	}
+}
+
+// encodeLegacy produces a credentials file in the pre-versioned XOR/base64 format
+func encodeLegacy(plain, password string) []byte {
+	key := []byte(password)
+	xored := make([]byte, len(plain))
+	for i := 0; i < len(plain); i++ {
+		xored[i] = plain[i] ^ key[i%len(key)]
+	}
+	return []byte(base64.StdEncoding.EncodeToString(xored))
+}
+
+func TestStore_File_WrongPasswordKeepsOtherAccounts(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("JIRA_KEYRING_PASSWORD", "correct-password")
+
+	store := NewStore(BackendFile)
+	if err := store.Store("alice@example.com", &Credentials{APIToken: "alice-token"}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	filePath, _ := getCredentialsFilePath()
+	before, _ := os.ReadFile(filePath)
+
+	t.Setenv("JIRA_KEYRING_PASSWORD", "wrong-password")
+	if err := store.Store("bob@example.com", &Credentials{APIToken: "bob-token"}); !errors.Is(err, ErrDecryptionFailed) {
+		t.Errorf("Store() with wrong password error = %v, want %v", err, ErrDecryptionFailed)
+	}
+
+	after, _ := os.ReadFile(filePath)
+	if !bytes.Equal(before, after) {
+		t.Error("Credentials file should be left untouched when it can't be decrypted")
+	}
+}
+
+func TestDecrypt_RejectsExcessiveKDFParameters(t *testing.T) {
+	encrypted, err := encrypt([]byte("payload"), "correct-password")
+	if err != nil {
+		t.Fatalf("encrypt() error = %v", err)
+	}
+
+	// logN, r and p sit at offsets 5, 6 and 7 of the header
+	tests := []struct {
+		name   string
+		offset int
+		value  byte
+	}{
+		{"logN", 5, 30},
+		{"r", 6, 255},
+		{"p", 7, 255},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tampered := append([]byte(nil), encrypted...)
+			tampered[tt.offset] = tt.value
+			if _, err := decrypt(tampered, "correct-password"); err == nil || err == ErrDecryptionFailed {
+				t.Errorf("decrypt() with excessive %s error = %v, want invalid parameters", tt.name, err)
+			}
+		})
+	}
+}
+
+func TestWriteCredentialsFile_ReplacesAtomically(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, EncryptedFileName)
+
+	for _, token := range []string{"first-token", "second-token"} {
+		creds := map[string]*Credentials{"user@example.com": {APIToken: token}}
+		if err := writeCredentialsFile(filePath, "correct-password", creds); err != nil {
+			t.Fatalf("writeCredentialsFile() error = %v", err)
+		}
+	}
+
+	allCreds, _, err := readCredentialsFile(filePath, "correct-password")
+	if err != nil {
+		t.Fatalf("readCredentialsFile() error = %v", err)
+	}
+	if allCreds["user@example.com"].APIToken != "second-token" {
+		t.Errorf("APIToken = %v, want second-token", allCreds["user@example.com"].APIToken)
+	}
+
+	// Only the credentials file is left behind, with owner-only permissions
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("Expected only the credentials file, got %d entries", len(entries))
+	}
+	if info, err := os.Stat(filePath); err == nil && runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("Credentials file mode = %v, want 0600", info.Mode().Perm())
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"text/template"
 
 	"github.com/sanisideup/jira-cli-for-agents/pkg/config"
+	"github.com/sanisideup/jira-cli-for-agents/pkg/jira"
 	"gopkg.in/yaml.v3"
 )
 
@@ -20,6 +21,9 @@ var defaultTemplates embed.FS
 // Service handles template loading, rendering, and management
 type Service struct {
 	templateDir string
+
+	// Mentions resolves @mentions in rich text fields (nil = kept as text)
+	Mentions jira.MentionResolver
 }
 
 // Template represents a Jira issue template
@@ -67,7 +71,9 @@ func (s *Service) parseTemplate(data []byte) (*Template, error) {
 	return &tmpl, nil
 }
 
-// RenderTemplate renders a template with the provided data and resolves field aliases
+// RenderTemplate renders a template with the provided data and resolves field aliases.
+// Rich text fields (e.g. description) rendered as strings are converted from
+// Markdown to ADF; fields that render to an ADF object are kept as-is.
 func (s *Service) RenderTemplate(tmpl *Template, data map[string]interface{}, cfg *config.Config) (map[string]interface{}, error) {
 	// Create a copy of template fields
 	renderedFields := make(map[string]interface{})
@@ -101,6 +107,15 @@ func (s *Service) RenderTemplate(tmpl *Template, data map[string]interface{}, cf
 
 		// Resolve field aliases to actual field IDs
 		actualFieldID := s.resolveFieldID(fieldKey, cfg)
+
+		if text, ok := rendered.(string); ok && text != "" && jira.IsRichTextField(actualFieldID) {
+			doc, err := jira.MarkdownToADFWithMentions(text, s.Mentions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render field '%s': %w", fieldKey, err)
+			}
+			rendered = doc
+		}
+
 		renderedFields[actualFieldID] = rendered
 	}
 
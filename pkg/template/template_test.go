@@ -80,6 +80,15 @@ func TestRenderTemplate(t *testing.T) {
 		t.Error("Expected summary to be a string")
 	}
 
+	// Check description is converted from Markdown to ADF
+	if description, ok := rendered["description"].(map[string]interface{}); ok {
+		if description["type"] != "doc" {
+			t.Errorf("Expected description to be an ADF doc, got type '%v'", description["type"])
+		}
+	} else {
+		t.Errorf("Expected description to be an ADF document, got %T", rendered["description"])
+	}
+
 	// Check field alias resolution
 	if _, exists := rendered["customfield_10016"]; !exists {
 		t.Error("Expected story_points to be resolved to customfield_10016")